@@ -47,6 +47,56 @@ examples:
 				Flags:  swapKeyFlags,
 				Description: `
 pass swap with big value
+`,
+			},
+			{
+				Name:   "holdbigvalue",
+				Usage:  "hold swap with big value",
+				Action: holdbigvalue,
+				Flags:  swapKeyFlags,
+				Description: `
+hold swap with big value from passing automatically after its timelock,
+the held swap can only be canceled by 'cancelbigvalue',
+or be passed by 'passbigvalue' after unholding by 'unholdbigvalue'
+`,
+			},
+			{
+				Name:   "unholdbigvalue",
+				Usage:  "unhold swap with big value",
+				Action: unholdbigvalue,
+				Flags:  swapKeyFlags,
+				Description: `
+unhold swap with big value (only admin can do),
+the unheld swap can be passed manually or automatically after its timelock
+`,
+			},
+			{
+				Name:   "cancelbigvalue",
+				Usage:  "cancel swap with big value",
+				Action: cancelbigvalue,
+				Flags:  append(swapKeyFlags, utils.MemoFlag),
+				Description: `
+cancel swap with big value, the canceled swap will never be passed
+`,
+			},
+			{
+				Name:   "approvescreening",
+				Usage:  "approve swap flagged by address screening",
+				Action: approvescreening,
+				Flags:  swapKeyFlags,
+				Description: `
+approve swap flagged by address screening after manual review,
+the approved swap will continue without screening again
+`,
+			},
+			{
+				Name:   "rejectscreening",
+				Usage:  "reject swap flagged by address screening",
+				Action: rejectscreening,
+				Flags:  append(swapKeyFlags, utils.MemoFlag),
+				Description: `
+reject swap flagged by address screening after manual review,
+the rejected swap is set to black list status
 `,
 			},
 			{
@@ -65,6 +115,106 @@ reswap failed swap
 				Flags:  append(swapKeyFlags, utils.GasPriceFlag),
 				Description: `
 replace pending swap with same nonce and new gas price
+`,
+			},
+			{
+				Name:   "rebroadcast",
+				Usage:  "rebroadcast pending swap",
+				Action: rebroadcast,
+				Flags:  swapKeyFlags,
+				Description: `
+rebroadcast persisted signed txs of pending swap
+`,
+			},
+			{
+				Name:   "cancelswap",
+				Usage:  "cancel pending swap",
+				Action: cancelswap,
+				Flags:  swapKeyFlags,
+				Description: `
+cancel pending swap by sending zero value to self with same nonce
+`,
+			},
+			{
+				Name:   "reverify",
+				Usage:  "reverify failed swap",
+				Action: reverify,
+				Flags:  swapKeyFlags,
+				Description: `
+verify failed swap again (eg. tx not found, miss token config)
+`,
+			},
+			{
+				Name:   "refund",
+				Usage:  "refund unswappable swap",
+				Action: refund,
+				Flags:  swapKeyFlags,
+				Description: `
+approve refund of unswappable swap (eg. with wrong value, miss token config)
+back to its sender on the source chain, the refund fee is deducted.
+`,
+			},
+			{
+				Name:      "desttag",
+				Usage:     "manage destination tag routes",
+				Action:    desttag,
+				ArgsUsage: "<add|remove> <chainID> <tag> [bind] [toChainID]",
+				Description: `
+manage destination tag routes, which are used to route
+deposits without valid memos (eg. ripple destination tag).
+
+examples:
+
+add <chainID> <tag> <bind> <toChainID>
+remove <chainID> <tag>
+`,
+			},
+			{
+				Name:      "anycallbudget",
+				Usage:     "manage anycall prepaid budget",
+				Action:    anycallbudget,
+				ArgsUsage: "deposit <chainID> <callFrom> <amount>",
+				Description: `
+top up prepaid anycall execution budget of call from on chain,
+amount is in the smallest unit of the native gas token.
+
+examples:
+
+deposit <chainID> <callFrom> <amount>
+`,
+			},
+			{
+				Name:      "drain",
+				Usage:     "drain swap server",
+				Action:    drain,
+				ArgsUsage: "<start|stop> <all|chainIDs> [timeout]",
+				Description: `
+stop accepting new swap tasks of chains (comma separated) or all chains,
+and wait the in flight swap tasks to finish until timeout (in seconds).
+the drain state is persisted, and the progress is reported in server info.
+
+examples:
+
+start all 600
+start <chainID1,chainID2>
+stop all
+`,
+			},
+			{
+				Name:      "loglevel",
+				Usage:     "set log level of module",
+				Action:    setLogLevel,
+				ArgsUsage: "<module|global> <level|reset>",
+				Description: `
+set log level of module at runtime, module of worker logs is its job name.
+level can be one of panic, fatal, error, warn, info, debug and trace.
+reset will remove the module level and use the global log level.
+
+examples:
+
+doSwap debug
+doSwap reset
+global info
 `,
 			},
 		},
@@ -148,6 +298,113 @@ func passbigvalue(ctx *cli.Context) error {
 	return err
 }
 
+func holdbigvalue(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "holdbigvalue"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, txid, logIndex, err := getKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%v: %v %v %v", method, chainID, txid, logIndex)
+
+	params := []string{chainID, txid, logIndex}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func unholdbigvalue(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "unholdbigvalue"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, txid, logIndex, err := getKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%v: %v %v %v", method, chainID, txid, logIndex)
+
+	params := []string{chainID, txid, logIndex}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func cancelbigvalue(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "cancelbigvalue"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, txid, logIndex, err := getKeys(ctx)
+	if err != nil {
+		return err
+	}
+	memo := ctx.String(utils.MemoFlag.Name)
+
+	log.Printf("%v: %v %v %v %v", method, chainID, txid, logIndex, memo)
+
+	params := []string{chainID, txid, logIndex, memo}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func approvescreening(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "approvescreening"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, txid, logIndex, err := getKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%v: %v %v %v", method, chainID, txid, logIndex)
+
+	params := []string{chainID, txid, logIndex}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func rejectscreening(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "rejectscreening"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, txid, logIndex, err := getKeys(ctx)
+	if err != nil {
+		return err
+	}
+	memo := ctx.String(utils.MemoFlag.Name)
+
+	log.Printf("%v: %v %v %v %v", method, chainID, txid, logIndex, memo)
+
+	params := []string{chainID, txid, logIndex, memo}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
 func reswap(ctx *cli.Context) error {
 	utils.SetLogger(ctx)
 	method := "reswap"
@@ -169,6 +426,48 @@ func reswap(ctx *cli.Context) error {
 	return err
 }
 
+func reverify(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "reverify"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, txid, logIndex, err := getKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%v: %v %v %v", method, chainID, txid, logIndex)
+
+	params := []string{chainID, txid, logIndex}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func refund(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "refund"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, txid, logIndex, err := getKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%v: %v %v %v", method, chainID, txid, logIndex)
+
+	params := []string{chainID, txid, logIndex}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
 func replaceswap(ctx *cli.Context) error {
 	utils.SetLogger(ctx)
 	method := "replaceswap"
@@ -193,3 +492,133 @@ func replaceswap(ctx *cli.Context) error {
 	log.Printf("result is '%v'", result)
 	return err
 }
+
+func rebroadcast(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "rebroadcast"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, txid, logIndex, err := getKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%v: %v %v %v", method, chainID, txid, logIndex)
+
+	params := []string{chainID, txid, logIndex}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func cancelswap(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	method := "cancelswap"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+	chainID, txid, logIndex, err := getKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%v: %v %v %v", method, chainID, txid, logIndex)
+
+	params := []string{chainID, txid, logIndex}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func desttag(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	if ctx.NArg() < 3 {
+		return fmt.Errorf("desttag: wrong number of arguments, have %v want at least 3", ctx.NArg())
+	}
+
+	method := "desttag"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := ctx.Args().Slice()
+
+	log.Printf("%v: %v", method, params)
+
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func anycallbudget(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	if ctx.NArg() != 4 {
+		return fmt.Errorf("anycallbudget: wrong number of arguments, have %v want 4", ctx.NArg())
+	}
+
+	method := "anycallbudget"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := ctx.Args().Slice()
+
+	log.Printf("%v: %v", method, params)
+
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func drain(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	if ctx.NArg() < 2 || ctx.NArg() > 3 {
+		return fmt.Errorf("drain: wrong number of arguments, have %v want 2 or 3", ctx.NArg())
+	}
+
+	method := "drain"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := ctx.Args().Slice()
+
+	log.Printf("%v: %v", method, params)
+
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func setLogLevel(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	if ctx.NArg() != 2 {
+		return fmt.Errorf("loglevel: wrong number of arguments, have %v want 2", ctx.NArg())
+	}
+
+	method := "loglevel"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := ctx.Args().Slice()
+
+	log.Printf("%v: %v", method, params)
+
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
@@ -3,10 +3,13 @@ package main
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
 	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
 	"github.com/mr-tron/base58"
 	"github.com/urfave/cli/v2"
 )
@@ -48,6 +51,16 @@ useful tools
 				ArgsUsage: "[message]",
 				Flags:     []cli.Flag{messageFlag, isHexFlag},
 			},
+			{
+				Name:   "mpchealth",
+				Usage:  "query mpc sign groups health",
+				Action: queryMPCHealth,
+				Flags:  []cli.Flag{utils.SwapServerFlag, withEnodesFlag},
+				Description: `
+query success/failure counts, latency and usable state
+of sign groups of all initiator nodes from swap server
+`,
+			},
 		},
 	}
 
@@ -77,6 +90,11 @@ useful tools
 		Name:  "raw",
 		Usage: "omits padding characters",
 	}
+
+	withEnodesFlag = &cli.BoolFlag{
+		Name:  "enodes",
+		Usage: "query enodes of sign groups",
+	}
 )
 
 func getMessage(ctx *cli.Context) (string, error) {
@@ -198,3 +216,25 @@ func sha256Hash(ctx *cli.Context) error {
 	}
 	return nil
 }
+
+func queryMPCHealth(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	swapServer := ctx.String(utils.SwapServerFlag.Name)
+	if swapServer == "" {
+		return errors.New("must specify swapserver")
+	}
+	args := map[string]interface{}{
+		"withEnodes": ctx.Bool(withEnodesFlag.Name),
+	}
+	var result interface{}
+	err := client.RPCPost(&result, swapServer, "swap.GetMPCHealth", args)
+	if err != nil {
+		return err
+	}
+	jsdata, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(jsdata))
+	return nil
+}
@@ -0,0 +1,123 @@
+package swapapi
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+var (
+	defAnalyticsQueryDays   = 7
+	defTopSwapSendersLimit  = 10
+	maxTopSwapSendersLimit  = 100
+	errAnalyticsNotEnabled  = newRPCError(-32000, "analytics is disabled")
+	errWrongAnalyticsDayArg = newRPCError(-32099, "wrong day range")
+)
+
+// getAnalyticsDayRange check and get day range (inclusive) in layout of mongodb.SwapRollupDayLayout.
+// end day defaults to today, start day defaults to 7 days before end day (inclusive).
+func getAnalyticsDayRange(startDay, endDay string) (start, end string, err error) {
+	if !params.IsAnalyticsEnabled() {
+		return "", "", errAnalyticsNotEnabled
+	}
+	endTime := time.Now().UTC()
+	if endDay != "" {
+		endTime, err = time.Parse(mongodb.SwapRollupDayLayout, endDay)
+		if err != nil {
+			return "", "", errWrongAnalyticsDayArg
+		}
+	}
+	startTime := endTime.AddDate(0, 0, 1-defAnalyticsQueryDays)
+	if startDay != "" {
+		startTime, err = time.Parse(mongodb.SwapRollupDayLayout, startDay)
+		if err != nil {
+			return "", "", errWrongAnalyticsDayArg
+		}
+	}
+	start = startTime.Format(mongodb.SwapRollupDayLayout)
+	end = endTime.Format(mongodb.SwapRollupDayLayout)
+	if start > end {
+		return "", "", errWrongAnalyticsDayArg
+	}
+	maxDays := params.GetAnalyticsMaxQueryDays()
+	if end > startTime.AddDate(0, 0, maxDays-1).Format(mongodb.SwapRollupDayLayout) {
+		return "", "", newRPCError(-32099, fmt.Sprintf("day range exceeds %v days", maxDays))
+	}
+	return start, end, nil
+}
+
+// GetSwapVolumeStats get daily volumes, latencies and replace counts of token and chain pair
+func GetSwapVolumeStats(startDay, endDay, tokenID, fromChainID, toChainID string) ([]*mongodb.MgoSwapVolumeRollup, error) {
+	start, end, err := getAnalyticsDayRange(startDay, endDay)
+	if err != nil {
+		return nil, err
+	}
+	result, err := mongodb.FindSwapVolumeRollups(start, end, tokenID, fromChainID, toChainID)
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	return result, nil
+}
+
+// GetSwapFailureStats get failure rates by status
+func GetSwapFailureStats(startDay, endDay string) (*SwapFailureStats, error) {
+	start, end, err := getAnalyticsDayRange(startDay, endDay)
+	if err != nil {
+		return nil, err
+	}
+	rollups, err := mongodb.FindSwapStatusRollups(start, end)
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	stats := &SwapFailureStats{
+		StartDay: start,
+		EndDay:   end,
+	}
+	counts := make(map[mongodb.SwapStatus]int64)
+	for _, rollup := range rollups {
+		counts[rollup.Status] += rollup.Count
+		stats.Total += rollup.Count
+		if rollup.Status.IsFailedStatus() {
+			stats.Failed += rollup.Count
+		}
+	}
+	if stats.Total == 0 {
+		return stats, nil
+	}
+	stats.FailureRate = float64(stats.Failed) / float64(stats.Total)
+	stats.Statuses = make([]*SwapStatusStat, 0, len(counts))
+	for status, count := range counts {
+		stats.Statuses = append(stats.Statuses, &SwapStatusStat{
+			Status:   status,
+			Name:     status.String(),
+			Count:    count,
+			Rate:     float64(count) / float64(stats.Total),
+			IsFailed: status.IsFailedStatus(),
+		})
+	}
+	sort.Slice(stats.Statuses, func(i, j int) bool {
+		return stats.Statuses[i].Status < stats.Statuses[j].Status
+	})
+	return stats, nil
+}
+
+// GetTopSwapSenders get top senders by swap count
+func GetTopSwapSenders(startDay, endDay, tokenID string, limit int) ([]*mongodb.MgoSwapSenderRollup, error) {
+	start, end, err := getAnalyticsDayRange(startDay, endDay)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defTopSwapSendersLimit
+	} else if limit > maxTopSwapSendersLimit {
+		limit = maxTopSwapSendersLimit
+	}
+	result, err := mongodb.FindTopSwapSenders(start, end, tokenID, int64(limit))
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	return result, nil
+}
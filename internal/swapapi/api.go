@@ -1,6 +1,7 @@
 package swapapi
 
 import (
+	"errors"
 	"strings"
 	"sync"
 	"time"
@@ -46,6 +47,7 @@ func GetServerInfo() *ServerInfo {
 		ExtraConfig:    extraCfg,
 		AllChainIDs:    router.AllChainIDs,
 		PausedChainIDs: router.GetPausedChainIDs(),
+		Drain:          worker.GetDrainInfo(),
 	}
 }
 
@@ -66,6 +68,18 @@ func GetOracleInfo() map[string]*OracleInfo {
 	return result
 }
 
+// GetMPCHealth get mpc sign groups health info
+func GetMPCHealth(withEnodes bool) *MPCHealthInfo {
+	result := &MPCHealthInfo{}
+	if mpcConfig := mpc.GetMPCConfig(false); mpcConfig != nil {
+		result.MPC = mpcConfig.GetSignGroupsHealth(withEnodes)
+	}
+	if fastmpcConfig := mpc.GetMPCConfig(true); fastmpcConfig != nil {
+		result.FastMPC = fastmpcConfig.GetSignGroupsHealth(withEnodes)
+	}
+	return result
+}
+
 // GetStatusInfo api
 func GetStatusInfo(status string) (map[string]interface{}, error) {
 	return mongodb.GetStatusInfo(status)
@@ -103,10 +117,9 @@ func ReportOracleInfo(oracle string, info *OracleInfo) error {
 
 // RegisterRouterSwap register router swap
 // if logIndex is 0 then check all logs, otherwise only check the specified log
-//nolint:funlen,gocyclo // allow long method
+// the tx is checked with all the enabled swap types of this router
 func RegisterRouterSwap(fromChainID, txid, logIndexStr string) (*MapIntResult, error) {
-	swapType := tokens.GetRouterSwapType()
-	log.Debug("[api] register swap", "chainid", fromChainID, "txid", txid, "logIndex", logIndexStr, "swapType", swapType.String())
+	log.Debug("[api] register swap", "chainid", fromChainID, "txid", txid, "logIndex", logIndexStr, "swapTypes", tokens.GetRouterSwapTypes())
 	chainID, err := common.GetBigIntFromStr(fromChainID)
 	if err != nil {
 		return nil, newRPCInternalError(err)
@@ -124,11 +137,20 @@ func RegisterRouterSwap(fromChainID, txid, logIndexStr string) (*MapIntResult, e
 		return nil, errAlreadyRegistered
 	}
 	result := MapIntResult(make(map[int]string))
+	for _, swapType := range tokens.GetRouterSwapTypes() {
+		registerRouterSwap(bridge, swapType, fromChainID, txid, logIndex, result)
+	}
+	return &result, nil
+}
+
+//nolint:funlen,gocyclo // allow long method
+func registerRouterSwap(bridge tokens.IBridge, swapType tokens.SwapType, fromChainID, txid string, logIndex int, result MapIntResult) {
+	var err error
 	registerArgs := &tokens.RegisterArgs{
 		SwapType: swapType,
 		LogIndex: logIndex,
 	}
-	log.Debug("[api] register swap start", "chainid", fromChainID, "txid", txid, "logIndex", logIndexStr, "swapType", swapType.String())
+	log.Debug("[api] register swap start", "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "swapType", swapType.String())
 	swapInfos, errs := bridge.RegisterSwap(txid, registerArgs)
 	for i, swapInfo := range swapInfos {
 		var memo string
@@ -138,7 +160,10 @@ func RegisterRouterSwap(fromChainID, txid, logIndexStr string) (*MapIntResult, e
 		}
 		logIndex = swapInfo.LogIndex
 		if !tokens.ShouldRegisterRouterSwapForError(verifyErr) {
-			result[logIndex] = "verify error: " + memo
+			// do not override the result of other swap types
+			if _, exist := result[logIndex]; !exist {
+				result[logIndex] = "verify error: " + memo
+			}
 			continue
 		}
 		oldSwap, registeredOk := mongodb.GetRegisteredRouterSwap(fromChainID, txid, logIndex)
@@ -158,7 +183,7 @@ func RegisterRouterSwap(fromChainID, txid, logIndexStr string) (*MapIntResult, e
 			case router.IsBlacklistSwap(swapInfo):
 				result[-1-logIndex] = "verify error: blacklist"
 			}
-			err = addMgoSwap(swapInfo, newStatus, memo)
+			err = worker.AddInitialSwap(swapInfo, newStatus, verifyErr)
 		case verifyErr == nil:
 			switch {
 			case oldSwap.Status == mongodb.TxWithBigValue && router.IsBigValueSwap(swapInfo):
@@ -167,48 +192,18 @@ func RegisterRouterSwap(fromChainID, txid, logIndexStr string) (*MapIntResult, e
 				result[logIndex] = "already registered: blacklist"
 			case newStatus != oldSwap.Status:
 				mgoSwapInfo := mongodb.ConvertToSwapInfo(&swapInfo.SwapInfo)
-				log.Info("[register] update swap info and status", "chainid", fromChainID, "txid", txid, "logIndex", logIndexStr, "oldStatus", oldSwap.Status, "newStatus", newStatus, "swapinfo", mgoSwapInfo)
-				err = mongodb.UpdateRouterSwapInfoAndStatus(fromChainID, txid, logIndex, &mgoSwapInfo, newStatus, time.Now().Unix(), memo)
+				log.Info("[register] update swap info and status", "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "oldStatus", oldSwap.Status, "newStatus", newStatus, "swapinfo", mgoSwapInfo)
+				err = mongodb.UpdateRouterSwapInfoAndStatus(fromChainID, txid, logIndex, &mgoSwapInfo, newStatus, time.Now().Unix(), verifyErr)
 				worker.DeleteCachedVerifyingSwap(oldSwap.Key)
 			}
 		default:
 			result[logIndex] = "already registered: " + memo
 		}
 		if err != nil {
-			log.Info("register swap db error", "chainid", fromChainID, "txid", txid, "logIndex", logIndexStr, "err", err)
+			log.Info("register swap db error", "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "err", err)
 		}
 	}
-	log.Debug("[api] register swap finished", "chainid", fromChainID, "txid", txid, "logIndex", logIndexStr, "swapType", swapType.String())
-	return &result, nil
-}
-
-func addMgoSwap(swapInfo *tokens.SwapTxInfo, status mongodb.SwapStatus, memo string) (err error) {
-	valueStr := "0"
-	if swapInfo.Value != nil {
-		valueStr = swapInfo.Value.String()
-	}
-	swap := &mongodb.MgoSwap{
-		SwapType:    uint32(swapInfo.SwapType),
-		TxID:        swapInfo.Hash,
-		TxTo:        swapInfo.TxTo,
-		From:        swapInfo.From,
-		Bind:        swapInfo.Bind,
-		Value:       valueStr,
-		LogIndex:    swapInfo.LogIndex,
-		FromChainID: swapInfo.FromChainID.String(),
-		ToChainID:   swapInfo.ToChainID.String(),
-		Status:      status,
-		Timestamp:   time.Now().Unix(),
-		Memo:        memo,
-	}
-	swap.SwapInfo = mongodb.ConvertToSwapInfo(&swapInfo.SwapInfo)
-	err = mongodb.AddRouterSwap(swap)
-	if err != nil {
-		log.Warn("[api] add router swap", "swap", swap, "err", err)
-	} else {
-		log.Info("[api] add router swap", "swap", swap)
-	}
-	return err
+	log.Debug("[api] register swap finished", "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "swapType", swapType.String())
 }
 
 func getLogIndex(logindexStr string) (int, error) {
@@ -233,7 +228,13 @@ func GetRouterSwap(fromChainID, txid, logindexStr string) (*SwapInfo, error) {
 	}
 	result, err := mongodb.FindRouterSwapResultAuto(fromChainID, txid, logindex)
 	if err == nil {
-		return ConvertMgoSwapResultToSwapInfo(result), nil
+		swapInfo := ConvertMgoSwapResultToSwapInfo(result)
+		if result.SwapType == uint32(tokens.AnyCallSwapType) {
+			if parent, errf := mongodb.FindAnyCallParentSwap(result.Key); errf == nil {
+				swapInfo.ParentSwap = parent.Key
+			}
+		}
+		return swapInfo, nil
 	}
 	register, err := mongodb.FindRouterSwapAuto(fromChainID, txid, logindex)
 	if err == nil {
@@ -242,6 +243,101 @@ func GetRouterSwap(fromChainID, txid, logindexStr string) (*SwapInfo, error) {
 	return nil, mongodb.ErrSwapNotFound
 }
 
+// GetRouterSwapSignedTxs impl (raw txs are only used internally to rebroadcast)
+func GetRouterSwapSignedTxs(fromChainID, txid, logindexStr string) ([]*SignedTxInfo, error) {
+	logindex, err := getLogIndex(logindexStr)
+	if err != nil {
+		return nil, err
+	}
+	res, err := mongodb.FindSignedTxs(fromChainID, txid, logindex)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertMgoSignedTxsToSignedTxInfos(res), nil
+}
+
+// GetRouterSwapReplaceDecisions impl
+func GetRouterSwapReplaceDecisions(fromChainID, txid, logindexStr string) ([]*mongodb.MgoReplaceDecision, error) {
+	logindex, err := getLogIndex(logindexStr)
+	if err != nil {
+		return nil, err
+	}
+	return mongodb.FindReplaceDecisions(fromChainID, txid, logindex)
+}
+
+// GetDestTag impl
+func GetDestTag(chainID, tagStr string) (*mongodb.MgoDestTag, error) {
+	tag, err := common.GetUint64FromStr(tagStr)
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	return mongodb.FindDestTag(chainID, tag)
+}
+
+// GetAnyCallBudget impl
+func GetAnyCallBudget(chainID, callFrom string) (*mongodb.MgoAnyCallLedger, error) {
+	return mongodb.FindAnyCallLedger(chainID, mongodb.AnyCallLedgerCallFrom, callFrom)
+}
+
+// GetAnyCallDAppUsage impl
+func GetAnyCallDAppUsage(chainID, dapp string) (*mongodb.MgoAnyCallLedger, error) {
+	return mongodb.FindAnyCallLedger(chainID, mongodb.AnyCallLedgerDApp, dapp)
+}
+
+// GetAnyCallDAppUsages impl
+func GetAnyCallDAppUsages(chainID string) ([]*mongodb.MgoAnyCallLedger, error) {
+	return mongodb.FindAnyCallLedgers(chainID, mongodb.AnyCallLedgerDApp)
+}
+
+// GetPendingBigValueSwaps impl
+func GetPendingBigValueSwaps(fromChainID string) ([]*BigValueSwapInfo, error) {
+	swaps, err := worker.FindPendingBigValueSwaps()
+	if err != nil {
+		return nil, err
+	}
+	serverCfg := params.GetRouterServerConfig()
+	isAutoPass := serverCfg != nil && serverCfg.EnablePassBigValueSwap
+	result := make([]*BigValueSwapInfo, 0, len(swaps))
+	for _, swap := range swaps {
+		if fromChainID != "" && swap.FromChainID != fromChainID {
+			continue
+		}
+		info := &BigValueSwapInfo{
+			SwapInfo: ConvertMgoSwapToSwapInfo(swap),
+			IsHeld:   swap.BigValueHeld,
+		}
+		if isAutoPass && !swap.BigValueHeld {
+			info.ReleaseTime = worker.GetBigValueReleaseTime(swap)
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// GetTokenAuditReports impl
+func GetTokenAuditReports(tokenID string, onlyDiscrepancy bool) ([]*router.TokenAuditReport, error) {
+	if !params.IsTokenAuditEnabled() {
+		return nil, errors.New("token audit is disabled")
+	}
+	return router.GetTokenAuditReports(tokenID, onlyDiscrepancy), nil
+}
+
+// GetLiquidityInfos impl
+func GetLiquidityInfos(chainID string, onlyLow bool) ([]*router.LiquidityInfo, error) {
+	if !params.IsLiquidityMonitorEnabled() {
+		return nil, errors.New("liquidity monitor is disabled")
+	}
+	return router.GetLiquidityInfos(chainID, onlyLow), nil
+}
+
+// GetSwapQueueDepths impl
+func GetSwapQueueDepths(chainID string) (map[string]map[string]int, error) {
+	if params.GetRouterServerConfig() == nil {
+		return nil, errors.New("swap queue only exist in server")
+	}
+	return worker.GetSwapQueueDepths(chainID), nil
+}
+
 // GetRouterSwapHistory impl
 func GetRouterSwapHistory(fromChainID, address string, offset, limit int, status string) ([]*SwapInfo, error) {
 	switch {
@@ -24,6 +24,8 @@ func ConvertMgoSwapToSwapInfo(ms *mongodb.MgoSwap) *SwapInfo {
 		InitTime:    ms.InitTime,
 		Timestamp:   ms.Timestamp,
 		Memo:        ms.Memo,
+		ErrCode:     tokens.GetErrorCodeInfo(ms.ErrCode),
+		TraceID:     tokens.GetTraceIDOfSwapKey(ms.Key),
 	}
 }
 
@@ -65,13 +67,19 @@ func ConvertMgoSwapResultToSwapInfo(mr *mongodb.MgoSwapResult) *SwapInfo {
 		SwapHeight:    mr.SwapHeight,
 		SwapValue:     mr.SwapValue,
 		SwapNonce:     mr.SwapNonce,
+		CancelTx:      mr.CancelTx,
 		Status:        mr.Status,
 		StatusMsg:     mr.Status.String(),
 		InitTime:      mr.InitTime,
 		Timestamp:     mr.Timestamp,
 		Memo:          mr.Memo,
+		ErrCode:       tokens.GetErrorCodeInfo(mr.ErrCode),
 		ReplaceCount:  len(mr.OldSwapTxs),
 		Confirmations: confirmations,
+		TraceID:       tokens.GetTraceIDOfSwapKey(mr.Key),
+
+		AnyCallAttempts: mr.AnyCallAttempts,
+		FallbackSwap:    mr.FallbackSwap,
 	}
 }
 
@@ -84,6 +92,36 @@ func ConvertMgoSwapResultsToSwapInfos(mrSlice []*mongodb.MgoSwapResult) []*SwapI
 	return result
 }
 
+// ConvertMgoSignedTxToSignedTxInfo convert
+func ConvertMgoSignedTxToSignedTxInfo(ms *mongodb.MgoSignedTx) *SignedTxInfo {
+	return &SignedTxInfo{
+		FromChainID: ms.FromChainID,
+		ToChainID:   ms.ToChainID,
+		TxID:        ms.TxID,
+		LogIndex:    ms.LogIndex,
+		SwapTx:      ms.SwapTx,
+		SwapNonce:   ms.SwapNonce,
+		ReplaceNum:  ms.ReplaceNum,
+		MPC:         ms.MPC,
+		Gas:         ms.Gas,
+		GasPrice:    ms.GasPrice,
+		GasTipCap:   ms.GasTipCap,
+		GasFeeCap:   ms.GasFeeCap,
+		Rebroadcast: ms.Rebroadcast,
+		InitTime:    ms.InitTime,
+		Timestamp:   ms.Timestamp,
+	}
+}
+
+// ConvertMgoSignedTxsToSignedTxInfos convert
+func ConvertMgoSignedTxsToSignedTxInfos(msSlice []*mongodb.MgoSignedTx) []*SignedTxInfo {
+	result := make([]*SignedTxInfo, len(msSlice))
+	for k, v := range msSlice {
+		result[k] = ConvertMgoSignedTxToSignedTxInfo(v)
+	}
+	return result
+}
+
 // ConvertChainConfig convert chain config
 func ConvertChainConfig(c *tokens.ChainConfig) *ChainConfig {
 	if c == nil {
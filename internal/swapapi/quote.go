@@ -0,0 +1,149 @@
+package swapapi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+const erc20TokenType = "ERC20"
+
+// quote block reasons
+const (
+	quoteBlockChainPaused     = "chain is paused"
+	quoteBlockBlacklist       = "route is in blacklist"
+	quoteBlockBelowMinimum    = "amount is below minimum swap"
+	quoteBlockAboveMaximum    = "amount is above maximum swap"
+	quoteBlockFeeExceedAmount = "swap fee exceeds amount"
+)
+
+type tokenBalanceGetter interface {
+	GetTokenBalance(tokenType, tokenAddress, accountAddress string) (*big.Int, error)
+}
+
+// GetSwapQuote preview fees, received amount and limits of swap
+// with the same calculation as the router does when swapping.
+// amount is in the smallest unit of the from token.
+//
+//nolint:funlen,gocyclo // ok
+func GetSwapQuote(tokenID, fromChainID, toChainID, amountStr, sender string) (*SwapQuote, error) {
+	if !tokens.IsSwapTypeEnabled(tokens.ERC20SwapType) {
+		return nil, tokens.ErrSwapTypeNotSupported
+	}
+	amount, err := common.GetBigIntFromStr(amountStr)
+	if err != nil || amount.Sign() <= 0 {
+		return nil, fmt.Errorf("wrong amount '%v'", amountStr)
+	}
+	srcBridge := router.GetBridgeByChainID(fromChainID)
+	dstBridge := router.GetBridgeByChainID(toChainID)
+	if srcBridge == nil || dstBridge == nil {
+		return nil, tokens.ErrNoBridgeForChainID
+	}
+	fromToken := router.GetCachedMultichainToken(tokenID, fromChainID)
+	toToken := router.GetCachedMultichainToken(tokenID, toChainID)
+	if fromToken == "" || toToken == "" {
+		return nil, tokens.ErrMissTokenConfig
+	}
+	fromTokenCfg := srcBridge.GetTokenConfig(fromToken)
+	toTokenCfg := dstBridge.GetTokenConfig(toToken)
+	if fromTokenCfg == nil || toTokenCfg == nil {
+		return nil, tokens.ErrMissTokenConfig
+	}
+	swapCfg := tokens.GetSwapConfig(tokenID, fromChainID, toChainID)
+	if swapCfg == nil {
+		return nil, errors.New("swap config not found")
+	}
+	fromDecimals := fromTokenCfg.Decimals
+	toDecimals := toTokenCfg.Decimals
+
+	quote := &SwapQuote{
+		TokenID:           tokenID,
+		FromChainID:       fromChainID,
+		ToChainID:         toChainID,
+		FromToken:         fromToken,
+		ToToken:           toToken,
+		FromDecimals:      fromDecimals,
+		ToDecimals:        toDecimals,
+		Amount:            amount.String(),
+		MinimumSwap:       tokens.ConvertTokenValue(swapCfg.MinimumSwap, 18, fromDecimals).String(),
+		MaximumSwap:       tokens.ConvertTokenValue(swapCfg.MaximumSwap, 18, fromDecimals).String(),
+		BigValueThreshold: tokens.GetBigValueThreshold(tokenID, fromChainID, toChainID, fromDecimals).String(),
+	}
+
+	if router.IsChainIDPaused(fromChainID) || router.IsChainIDPaused(toChainID) {
+		quote.BlockReasons = append(quote.BlockReasons, quoteBlockChainPaused)
+	}
+	if params.IsChainIDInBlackList(fromChainID) ||
+		params.IsChainIDInBlackList(toChainID) ||
+		params.IsTokenIDInBlackList(tokenID) ||
+		(sender != "" && params.IsAccountInBlackList(sender)) {
+		quote.BlockReasons = append(quote.BlockReasons, quoteBlockBlacklist)
+	}
+
+	swapInfo := &tokens.SwapTxInfo{
+		SwapInfo: tokens.SwapInfo{ERC20SwapInfo: &tokens.ERC20SwapInfo{
+			Token:   fromToken,
+			TokenID: tokenID,
+		}},
+		SwapType:    tokens.ERC20SwapType,
+		From:        sender,
+		Value:       amount,
+		FromChainID: srcBridge.GetChainConfig().GetChainID(),
+		ToChainID:   dstBridge.GetChainConfig().GetChainID(),
+	}
+	isWhitelisted := sender != "" && params.IsInBigValueWhitelist(tokenID, sender)
+	if amount.Cmp(tokens.ConvertTokenValue(swapCfg.MinimumSwap, 18, fromDecimals)) < 0 {
+		quote.BlockReasons = append(quote.BlockReasons, quoteBlockBelowMinimum)
+	}
+	if amount.Cmp(tokens.ConvertTokenValue(swapCfg.MaximumSwap, 18, fromDecimals)) > 0 && !isWhitelisted {
+		quote.BlockReasons = append(quote.BlockReasons, quoteBlockAboveMaximum)
+	}
+	quote.IsBigValue = router.IsBigValueSwap(swapInfo)
+
+	if fee := tokens.CalcSwapFee(tokenID, fromChainID, toChainID, amount, fromDecimals, sender, ""); fee != nil {
+		quote.Fee = convertSwapFeeDetail(fee)
+	}
+	receiveAmount := tokens.CalcSwapValue(tokenID, fromChainID, toChainID, amount, fromDecimals, toDecimals, sender, "")
+	if receiveAmount.Sign() <= 0 {
+		quote.BlockReasons = append(quote.BlockReasons, quoteBlockFeeExceedAmount)
+	}
+	quote.ReceiveAmount = receiveAmount.String()
+
+	quote.HasEnoughLiquidity = true
+	if underlying := toTokenCfg.GetUnderlying(); underlying != "" {
+		if getter, ok := dstBridge.(tokenBalanceGetter); ok {
+			liquidity, errb := getter.GetTokenBalance(erc20TokenType, underlying, toToken)
+			if errb != nil {
+				return nil, errb
+			}
+			quote.Liquidity = liquidity.String()
+			quote.HasEnoughLiquidity = liquidity.Cmp(receiveAmount) >= 0
+		}
+	}
+
+	return quote, nil
+}
+
+func convertSwapFeeDetail(fee *tokens.SwapFeeDetail) *SwapFeeInfo {
+	info := &SwapFeeInfo{
+		SwapFeeRatePerMillion: fee.SwapFeeRatePerMillion,
+		MinimumSwapFee:        fee.MinimumSwapFee.String(),
+		MaximumSwapFee:        fee.MaximumSwapFee.String(),
+		Clamp:                 fee.Clamp,
+		IsWhitelisted:         fee.IsWhitelisted,
+		BaseFeePercent:        fee.BaseFeePercent,
+		SwapFee:               fee.SwapFee.String(),
+	}
+	if fee.RateFee != nil {
+		info.RateFee = fee.RateFee.String()
+	}
+	if fee.AdjustBaseFee != nil {
+		info.AdjustBaseFee = fee.AdjustBaseFee.String()
+	}
+	return info
+}
@@ -4,7 +4,10 @@ import (
 	"math/big"
 
 	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/mpc"
 	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/worker"
 )
 
 // MapIntResult type
@@ -16,7 +19,14 @@ type ServerInfo struct {
 	Version        string
 	ExtraConfig    *params.ExtraConfig `json:",omitempty"`
 	AllChainIDs    []*big.Int
-	PausedChainIDs []*big.Int `json:",omitempty"`
+	PausedChainIDs []*big.Int        `json:",omitempty"`
+	Drain          *worker.DrainInfo `json:",omitempty"`
+}
+
+// MPCHealthInfo mpc sign groups health info
+type MPCHealthInfo struct {
+	MPC     []*mpc.InitiatorHealth `json:",omitempty"`
+	FastMPC []*mpc.InitiatorHealth `json:",omitempty"`
 }
 
 // OracleInfo oracle info
@@ -43,13 +53,39 @@ type SwapInfo struct {
 	SwapHeight    uint64             `json:"swapheight"`
 	SwapValue     string             `json:"swapvalue"`
 	SwapNonce     uint64             `json:"swapnonce"`
+	CancelTx      string             `json:"canceltx,omitempty"`
 	Status        mongodb.SwapStatus `json:"status"`
 	StatusMsg     string             `json:"statusmsg"`
 	InitTime      int64              `json:"inittime"`
 	Timestamp     int64              `json:"timestamp"`
 	Memo          string             `json:"memo,omitempty"`
+	ErrCode       *tokens.ErrorCode  `json:"errCode,omitempty"`
 	ReplaceCount  int                `json:"replaceCount,omitempty"`
 	Confirmations uint64             `json:"confirmations"`
+	TraceID       string             `json:"traceID,omitempty"`
+
+	AnyCallAttempts []*mongodb.AnyCallAttempt `json:"anycallAttempts,omitempty"`
+	ParentSwap      string                    `json:"parentSwap,omitempty"`
+	FallbackSwap    string                    `json:"fallbackSwap,omitempty"`
+}
+
+// SignedTxInfo signed swap tx info (raw tx is not exposed publicly)
+type SignedTxInfo struct {
+	FromChainID string `json:"fromChainID"`
+	ToChainID   string `json:"toChainID"`
+	TxID        string `json:"txid"`
+	LogIndex    int    `json:"logIndex"`
+	SwapTx      string `json:"swaptx"`
+	SwapNonce   uint64 `json:"swapnonce"`
+	ReplaceNum  uint64 `json:"replacenum"`
+	MPC         string `json:"mpc"`
+	Gas         uint64 `json:"gas,omitempty"`
+	GasPrice    string `json:"gasprice,omitempty"`
+	GasTipCap   string `json:"gastipcap,omitempty"`
+	GasFeeCap   string `json:"gasfeecap,omitempty"`
+	Rebroadcast int    `json:"rebroadcast"`
+	InitTime    int64  `json:"inittime"`
+	Timestamp   int64  `json:"timestamp"`
 }
 
 // ChainConfig rpc type
@@ -84,3 +120,63 @@ type FeeConfig struct {
 	MaximumSwapFee        string
 	MinimumSwapFee        string
 }
+
+// BigValueSwapInfo pending big value swap info
+type BigValueSwapInfo struct {
+	*SwapInfo
+	ReleaseTime int64 `json:"releaseTime,omitempty"`
+	IsHeld      bool  `json:"isHeld,omitempty"`
+}
+
+// SwapFeeInfo swap fee breakdown
+type SwapFeeInfo struct {
+	SwapFeeRatePerMillion uint64
+	RateFee               string `json:",omitempty"`
+	MinimumSwapFee        string
+	MaximumSwapFee        string
+	Clamp                 string `json:",omitempty"`
+	IsWhitelisted         bool   `json:",omitempty"`
+	BaseFeePercent        int64  `json:",omitempty"`
+	AdjustBaseFee         string `json:",omitempty"`
+	SwapFee               string
+}
+
+// SwapQuote swap quote (values are in the smallest unit of tokens)
+type SwapQuote struct {
+	TokenID            string
+	FromChainID        string
+	ToChainID          string
+	FromToken          string
+	ToToken            string
+	FromDecimals       uint8
+	ToDecimals         uint8
+	Amount             string
+	Fee                *SwapFeeInfo `json:",omitempty"`
+	ReceiveAmount      string
+	MinimumSwap        string
+	MaximumSwap        string
+	BigValueThreshold  string
+	IsBigValue         bool
+	Liquidity          string `json:",omitempty"`
+	HasEnoughLiquidity bool
+	BlockReasons       []string `json:",omitempty"`
+}
+
+// SwapFailureStats swap failure rates in day range
+type SwapFailureStats struct {
+	StartDay    string
+	EndDay      string
+	Total       int64
+	Failed      int64
+	FailureRate float64
+	Statuses    []*SwapStatusStat
+}
+
+// SwapStatusStat swap count and rate of status
+type SwapStatusStat struct {
+	Status   mongodb.SwapStatus
+	Name     string
+	Count    int64
+	Rate     float64
+	IsFailed bool
+}
@@ -36,6 +36,7 @@ func SetLogger(logLevel uint32, jsonFormat, colorFormat bool) {
 			DisableSorting:  false,
 		})
 	}
+	syncModuleLoggers()
 }
 
 // SetLogFile set log file path and rotation
@@ -66,10 +67,15 @@ func SetLogFile(logFile string, logRotation, logMaxAge uint64) {
 		logrus.Fatalf("Failed to Initialize Log File %s", err)
 	}
 	logrus.SetOutput(writer)
+	syncModuleLoggers()
 }
 
 // WithFields encapsulate logrus.WithFields
 func WithFields(ctx ...interface{}) *logrus.Entry {
+	return logrus.WithFields(getFields(ctx...))
+}
+
+func getFields(ctx ...interface{}) logrus.Fields {
 	length := len(ctx)
 	if length%2 != 0 {
 		Debugf("log fileds number %v is not even", length)
@@ -83,7 +89,7 @@ func WithFields(ctx ...interface{}) *logrus.Entry {
 			Debugf("log field key '%v' is not string", ctx[k])
 		}
 	}
-	return logrus.WithFields(fields)
+	return fields
 }
 
 // PrintFunc print function prototype
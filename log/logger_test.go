@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,3 +54,17 @@ func TestLogger(t *testing.T) {
 	assert.Panics(t, func() { Panicf("test Panicf, timestamp=%v err=%v", now, err) }, "not panic")
 	assert.Panics(t, func() { Panicln("test Panicln", "timestamp", now, "err", err) }, "not panic")
 }
+
+func TestModuleLogger(t *testing.T) {
+	SetLogger(4, false, true)
+
+	SetModuleLevel("doSwap", logrus.DebugLevel)
+	assert.Equal(t, "debug", GetModuleLevels()["doswap"], "module level not set")
+	assert.True(t, WithModuleFields("DOSWAP").Logger.IsLevelEnabled(logrus.DebugLevel), "module level not used")
+	assert.False(t, WithModuleFields("verify").Logger.IsLevelEnabled(logrus.DebugLevel), "global level not used")
+	WithModuleFields("doSwap", "timestamp", now, "err", err).Debug("test WithModuleFields Debug")
+
+	ResetModuleLevel("doSwap")
+	assert.Empty(t, GetModuleLevels(), "module level not reset")
+	assert.False(t, WithModuleFields("doSwap").Logger.IsLevelEnabled(logrus.DebugLevel), "module level not reset")
+}
@@ -0,0 +1,82 @@
+package log
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	moduleLoggersLock sync.RWMutex
+	moduleLoggers     = make(map[string]*logrus.Logger) // key is lower case module
+)
+
+// ParseLevel parse log level (eg. trace, debug, info, warn, error)
+func ParseLevel(level string) (logrus.Level, error) {
+	return logrus.ParseLevel(level)
+}
+
+// SetLevel set global log level
+func SetLevel(level logrus.Level) {
+	logrus.SetLevel(level)
+}
+
+// GetLevel get global log level
+func GetLevel() logrus.Level {
+	return logrus.GetLevel()
+}
+
+// SetModuleLevel set log level of module, which overrides the global log level
+func SetModuleLevel(module string, level logrus.Level) {
+	std := logrus.StandardLogger()
+	logger := logrus.New()
+	logger.SetOutput(std.Out)
+	logger.SetFormatter(std.Formatter)
+	logger.SetLevel(level)
+
+	moduleLoggersLock.Lock()
+	defer moduleLoggersLock.Unlock()
+	moduleLoggers[strings.ToLower(module)] = logger
+}
+
+// ResetModuleLevel remove log level of module, the module uses the global log level
+func ResetModuleLevel(module string) {
+	moduleLoggersLock.Lock()
+	defer moduleLoggersLock.Unlock()
+	delete(moduleLoggers, strings.ToLower(module))
+}
+
+// GetModuleLevels get log levels of modules
+func GetModuleLevels() map[string]string {
+	moduleLoggersLock.RLock()
+	defer moduleLoggersLock.RUnlock()
+	levels := make(map[string]string, len(moduleLoggers))
+	for module, logger := range moduleLoggers {
+		levels[module] = logger.GetLevel().String()
+	}
+	return levels
+}
+
+// WithModuleFields get log entry of module with fields,
+// the log level of module (if set) overrides the global log level
+func WithModuleFields(module string, ctx ...interface{}) *logrus.Entry {
+	moduleLoggersLock.RLock()
+	logger, exist := moduleLoggers[strings.ToLower(module)]
+	moduleLoggersLock.RUnlock()
+	if !exist {
+		return WithFields(ctx...)
+	}
+	return logger.WithFields(getFields(ctx...))
+}
+
+// syncModuleLoggers keep output and formatter of module loggers same as the standard logger
+func syncModuleLoggers() {
+	std := logrus.StandardLogger()
+	moduleLoggersLock.Lock()
+	defer moduleLoggersLock.Unlock()
+	for _, logger := range moduleLoggers {
+		logger.SetOutput(std.Out)
+		logger.SetFormatter(std.Formatter)
+	}
+}
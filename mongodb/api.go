@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 	"sync"
 	"time"
@@ -85,16 +86,19 @@ func PassRouterSwapVerify(fromChainID, txid string, logindex int, timestamp int6
 }
 
 // UpdateRouterSwapStatus update router swap status
-func UpdateRouterSwapStatus(fromChainID, txid string, logindex int, status SwapStatus, timestamp int64, memo string) error {
+// the memo and error code are recorded from swapErr if it is not nil
+func UpdateRouterSwapStatus(fromChainID, txid string, logindex int, status SwapStatus, timestamp int64, swapErr error) error {
 	if status == TxNotStable {
 		return errors.New("forbid update swap status to TxNotStable")
 	}
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
 	updates := bson.M{"status": status, "timestamp": timestamp}
-	if memo != "" {
-		updates["memo"] = memo
+	if swapErr != nil {
+		updates["memo"] = swapErr.Error()
+		updates["errcode"] = tokens.GetErrorCodeValue(swapErr)
 	} else if status == TxNotSwapped {
 		updates["memo"] = ""
+		updates["errcode"] = tokens.ErrCodeNone
 	}
 	_, err := collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": updates})
 	if err == nil {
@@ -107,7 +111,7 @@ func UpdateRouterSwapStatus(fromChainID, txid string, logindex int, status SwapS
 }
 
 // UpdateRouterSwapInfoAndStatus update router swap info and status
-func UpdateRouterSwapInfoAndStatus(fromChainID, txid string, logindex int, swapInfo *SwapInfo, status SwapStatus, timestamp int64, memo string) error {
+func UpdateRouterSwapInfoAndStatus(fromChainID, txid string, logindex int, swapInfo *SwapInfo, status SwapStatus, timestamp int64, swapErr error) error {
 	retryLock.Lock()
 	defer retryLock.Unlock()
 
@@ -127,12 +131,17 @@ func UpdateRouterSwapInfoAndStatus(fromChainID, txid string, logindex int, swapI
 		return fmt.Errorf("forbid update swap info if swap result exists")
 	}
 
+	var memo string
+	if swapErr != nil {
+		memo = swapErr.Error()
+	}
 	updates := bson.M{
 		"swapinfo":  *swapInfo,
 		"status":    status,
 		"timestamp": timestamp,
 		"inittime":  timestamp * 1000,
 		"memo":      memo,
+		"errcode":   tokens.GetErrorCodeValue(swapErr),
 	}
 
 	_, err = collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": updates})
@@ -257,6 +266,81 @@ func FindRouterSwapsWithChainIDAndStatus(fromChainID string, status SwapStatus,
 	return result, nil
 }
 
+// FindRouterSwapsToReverify find verify failed swaps which are due to reverify
+func FindRouterSwapsToReverify(septime, timestamp int64) ([]*MgoSwap, error) {
+	qtime := bson.M{"inittime": bson.M{"$gte": septime * 1000}}
+	qstatus := bson.M{"status": bson.M{"$in": []SwapStatus{TxVerifyFailed, MissTokenConfig, NoUnderlyingToken}}}
+	qnext := bson.M{"$or": []bson.M{
+		{"nextreverifytime": bson.M{"$exists": false}},
+		{"nextreverifytime": bson.M{"$lte": timestamp}},
+	}}
+	queries := []bson.M{qtime, qstatus, qnext}
+
+	opts := &options.FindOptions{
+		Sort:  bson.D{{Key: "inittime", Value: 1}},
+		Limit: &maxCountOfResults,
+	}
+	cur, err := collRouterSwap.Find(clientCtx, bson.M{"$and": queries}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwap, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// UpdateRouterSwapNextReverifyTime update next reverify time of router swap
+func UpdateRouterSwapNextReverifyTime(fromChainID, txid string, logindex int, nextTime int64) error {
+	key := GetRouterSwapKey(fromChainID, txid, logindex)
+	updates := bson.M{"nextreverifytime": nextTime}
+	_, err := collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	return mgoError(err)
+}
+
+// ResetNextReverifyTimeOfStatus make swaps with status due to reverify at once
+func ResetNextReverifyTimeOfStatus(statuses []SwapStatus) error {
+	qstatus := bson.M{"status": bson.M{"$in": statuses}}
+	qnext := bson.M{"nextreverifytime": bson.M{"$gt": 0}}
+	updates := bson.M{"nextreverifytime": 0}
+	_, err := collRouterSwap.UpdateMany(clientCtx, bson.M{"$and": []bson.M{qstatus, qnext}}, bson.M{"$set": updates})
+	return mgoError(err)
+}
+
+// ResetRouterSwapToReverify reset verify failed swap to TxNotStable to verify again
+func ResetRouterSwapToReverify(fromChainID, txid string, logindex int, nextTime int64) error {
+	verifyLock.Lock()
+	defer verifyLock.Unlock()
+
+	swap, err := FindRouterSwap(fromChainID, txid, logindex)
+	if err != nil {
+		return err
+	}
+	if !swap.Status.IsReverifiable() {
+		return fmt.Errorf("swap status is %v, can not reverify", swap.Status.String())
+	}
+	_, err = FindRouterSwapResult(fromChainID, txid, logindex)
+	if err == nil {
+		return fmt.Errorf("can not reverify swap with result exist")
+	}
+
+	key := GetRouterSwapKey(fromChainID, txid, logindex)
+	updates := bson.M{
+		"status":           TxNotStable,
+		"timestamp":        time.Now().Unix(),
+		"nextreverifytime": nextTime,
+	}
+	_, err = collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": updates, "$inc": bson.M{"reverifycount": 1}})
+	if err == nil {
+		log.Info("mongodb reset swap to reverify success", "chainid", fromChainID, "txid", txid, "logindex", logindex, "oldstatus", swap.Status, "reverifycount", swap.ReverifyCount+1)
+	} else {
+		log.Error("mongodb reset swap to reverify failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "err", err)
+	}
+	return mgoError(err)
+}
+
 // AddRouterSwapResult add router swap result
 func AddRouterSwapResult(mr *MgoSwapResult) error {
 	mr.Key = GetRouterSwapKey(mr.FromChainID, mr.TxID, mr.LogIndex)
@@ -297,16 +381,27 @@ func AllocateRouterSwapNonce(args *tokens.BuildTxArgs, nonceptr *uint64, isRecyc
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
 	nowTime := time.Now().Unix()
 
+	resStatus, swapStatus := MatchTxNotStable, TxProcessed
+	if swapRes.Status.IsRefundStatus() {
+		resStatus, swapStatus = RefundTxNotStable, TxRefunded
+	}
+
 	resUpdates := bson.M{
 		"mpc":       args.From,
-		"status":    MatchTxNotStable,
+		"status":    resStatus,
 		"swapnonce": swapnonce,
 		"timestamp": nowTime,
 	}
 	if args.SwapValue != nil {
 		resUpdates["swapvalue"] = args.SwapValue.String()
 	}
-	_, err = collRouterSwapResult.UpdateByID(clientCtx, key, bson.M{"$set": resUpdates})
+	setLeaseFenceUpdates(resUpdates, args.ChainLease)
+	filter := getLeaseFenceFilter(key, args.ChainLease)
+	res, err := collRouterSwapResult.UpdateOne(clientCtx, filter, bson.M{"$set": resUpdates})
+	if err == nil && res.MatchedCount == 0 {
+		log.Warn("mongodb allocate swap nonce with lost chain lease", "chainid", fromChainID, "txid", txid, "logindex", logindex, "swapnonce", swapnonce, "lease", args.ChainLease)
+		return 0, ErrChainLeaseIsLost
+	}
 	if err != nil {
 		log.Warn("mongodb allocate swap nonce failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "swapnonce", swapnonce, "err", err)
 		return 0, mgoError(err)
@@ -314,10 +409,10 @@ func AllocateRouterSwapNonce(args *tokens.BuildTxArgs, nonceptr *uint64, isRecyc
 
 	log.Info("mongodb allocate swap nonce success", "chainid", fromChainID, "txid", txid, "logindex", logindex, "swapnonce", swapnonce)
 
-	statusUpdates := bson.M{"status": TxProcessed, "timestamp": nowTime}
+	statusUpdates := bson.M{"status": swapStatus, "timestamp": nowTime}
 	_, errf := collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": statusUpdates})
 	if errf != nil {
-		log.Warn("mongodb update swap status to TxProcessed failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "swapnonce", swapnonce, "err", errf)
+		log.Warn("mongodb update swap status failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "swapnonce", swapnonce, "status", swapStatus, "err", errf)
 	}
 
 	if isRecycleNonce {
@@ -329,22 +424,37 @@ func AllocateRouterSwapNonce(args *tokens.BuildTxArgs, nonceptr *uint64, isRecyc
 }
 
 // UpdateRouterSwapResultStatus update router swap result status
-func UpdateRouterSwapResultStatus(fromChainID, txid string, logindex int, status SwapStatus, timestamp int64, memo string) error {
+// the memo and error code are recorded from swapErr if it is not nil
+func UpdateRouterSwapResultStatus(fromChainID, txid string, logindex int, status SwapStatus, timestamp int64, swapErr error) error {
 	updateResultLock.Lock()
 	defer updateResultLock.Unlock()
 
+	switch status {
+	case MatchTxNotStable, MatchTxStable, MatchTxFailed:
+		swapRes, err := FindRouterSwapResult(fromChainID, txid, logindex)
+		if err != nil {
+			return err
+		}
+		if swapRes.Status.IsRefundStatus() {
+			status = status.toRefundStatus()
+		}
+	}
+
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
 	updates := bson.M{"status": status, "timestamp": timestamp}
-	if memo != "" {
-		updates["memo"] = memo
+	if swapErr != nil {
+		updates["memo"] = swapErr.Error()
+		updates["errcode"] = tokens.GetErrorCodeValue(swapErr)
 	}
 	if status == Reswapping {
 		updates["memo"] = ""
+		updates["errcode"] = tokens.ErrCodeNone
 		updates["swaptx"] = ""
 		updates["oldswaptxs"] = nil
 		updates["swapheight"] = 0
 		updates["swaptime"] = 0
 		updates["swapnonce"] = 0
+		updates["canceltx"] = ""
 	}
 	_, err := collRouterSwapResult.UpdateByID(clientCtx, key, bson.M{"$set": updates})
 	if err == nil {
@@ -356,7 +466,7 @@ func UpdateRouterSwapResultStatus(fromChainID, txid string, logindex int, status
 }
 
 // UpdateRouterOldSwapTxs update old swaptxs by appending `swapTx`
-func UpdateRouterOldSwapTxs(fromChainID, txid string, logindex int, swapTx string) error {
+func UpdateRouterOldSwapTxs(fromChainID, txid string, logindex int, swapTx string, lease *tokens.ChainLease) error {
 	if swapTx == "" {
 		return nil
 	}
@@ -382,12 +492,14 @@ func UpdateRouterOldSwapTxs(fromChainID, txid string, logindex int, swapTx strin
 	updateSet := bson.M{
 		"timestamp": time.Now().Unix(),
 	}
-	if swapRes.Status != MatchTxStable {
+	if swapRes.Status != MatchTxStable && swapRes.Status != RefundTxStable {
 		updateSet["swaptx"] = swapTx
 	} else {
 		log.Warn("UpdateRouterOldSwapTxs ignore update swap tx with stable status", "fromChainID", fromChainID, "txid", txid, "logindex", logindex, "ignored", swapTx, "swaptx", swapRes.SwapTx, "swapnonce", swapRes.SwapNonce)
 	}
 
+	setLeaseFenceUpdates(updateSet, lease)
+
 	var updates bson.M
 
 	if len(swapRes.OldSwapTxs) == 0 {
@@ -401,7 +513,12 @@ func UpdateRouterOldSwapTxs(fromChainID, txid string, logindex int, swapTx strin
 	}
 
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
-	_, err = collRouterSwapResult.UpdateByID(clientCtx, key, updates)
+	filter := getLeaseFenceFilter(key, lease)
+	res, err := collRouterSwapResult.UpdateOne(clientCtx, filter, updates)
+	if err == nil && res.MatchedCount == 0 {
+		log.Warn("UpdateRouterOldSwapTxs with lost chain lease", "fromChainID", fromChainID, "txid", txid, "logIndex", logindex, "swaptx", swapTx, "nonce", swapRes.SwapNonce)
+		return ErrChainLeaseIsLost
+	}
 	if err == nil {
 		log.Info("UpdateRouterOldSwapTxs success", "fromChainID", fromChainID, "txid", txid, "logIndex", logindex, "swaptx", swapTx, "nonce", swapRes.SwapNonce)
 	} else {
@@ -410,6 +527,33 @@ func UpdateRouterOldSwapTxs(fromChainID, txid string, logindex int, swapTx strin
 	return mgoError(err)
 }
 
+// UpdateRouterSwapResultCanceled record the sent cancel tx and mark the pending swap result canceled
+func UpdateRouterSwapResultCanceled(fromChainID, txid string, logindex int, cancelTx string, lease *tokens.ChainLease) error {
+	updateResultLock.Lock()
+	defer updateResultLock.Unlock()
+
+	key := GetRouterSwapKey(fromChainID, txid, logindex)
+	updates := bson.M{
+		"status":    MatchTxCanceled,
+		"canceltx":  cancelTx,
+		"timestamp": time.Now().Unix(),
+	}
+	setLeaseFenceUpdates(updates, lease)
+	filter := getLeaseFenceFilter(key, lease)
+	filter["status"] = MatchTxNotStable
+	res, err := collRouterSwapResult.UpdateOne(clientCtx, filter, bson.M{"$set": updates})
+	if err != nil {
+		log.Error("UpdateRouterSwapResultCanceled failed", "fromChainID", fromChainID, "txid", txid, "logIndex", logindex, "canceltx", cancelTx, "err", err)
+		return mgoError(err)
+	}
+	if res.MatchedCount == 0 {
+		log.Warn("UpdateRouterSwapResultCanceled mismatch", "fromChainID", fromChainID, "txid", txid, "logIndex", logindex, "canceltx", cancelTx)
+		return ErrItemNotFound
+	}
+	log.Info("UpdateRouterSwapResultCanceled success", "fromChainID", fromChainID, "txid", txid, "logIndex", logindex, "canceltx", cancelTx)
+	return nil
+}
+
 // FindRouterSwapResult find router swap result
 func FindRouterSwapResult(fromChainID, txid string, logindex int) (*MgoSwapResult, error) {
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
@@ -439,6 +583,39 @@ func findFirstRouterSwapResult(fromChainID, txid string) (*MgoSwapResult, error)
 	return result, nil
 }
 
+// FindRouterSwapResultsOfTx find router swap results of all log indexes of tx
+func FindRouterSwapResultsOfTx(fromChainID, txid string) ([]*MgoSwapResult, error) {
+	query := getChainAndTxIDQuery(fromChainID, txid)
+	cur, err := collRouterSwapResult.Find(clientCtx, query)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapResult, 0, 1)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindRouterSwapResultsOfTxByKey find router swap results of all log indexes of tx
+// by the key range 'fromChainID:txid:*', which is an exact primary key lookup
+func FindRouterSwapResultsOfTxByKey(fromChainID, txid string) ([]*MgoSwapResult, error) {
+	keyPrefix := strings.ToLower(fmt.Sprintf("%v:%v:", fromChainID, txid))
+	keyEnd := keyPrefix[:len(keyPrefix)-1] + ";" // next character of ':'
+	query := bson.M{"_id": bson.M{"$gte": keyPrefix, "$lt": keyEnd}}
+	cur, err := collRouterSwapResult.Find(clientCtx, query)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapResult, 0, 1)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
 // FindRouterSwapResultsWithStatus find router swap result with status
 func FindRouterSwapResultsWithStatus(status SwapStatus, septime int64) ([]*MgoSwapResult, error) {
 	query := getStatusQuery(status, septime)
@@ -502,7 +679,7 @@ func FindNextSwapNonce(chainID, mpc string) (uint64, error) {
 // FindRouterSwapResultsToStable find swap results to stable
 func FindRouterSwapResultsToStable(chainID string, septime int64) ([]*MgoSwapResult, error) {
 	qtime := bson.M{"inittime": bson.M{"$gte": septime}}
-	qstatus := bson.M{"status": MatchTxNotStable}
+	qstatus := bson.M{"status": bson.M{"$in": []SwapStatus{MatchTxNotStable, RefundTxNotStable}}}
 	qchainid := bson.M{"toChainID": chainID}
 	queries := []bson.M{qtime, qstatus, qchainid}
 
@@ -526,7 +703,7 @@ func FindRouterSwapResultsToStable(chainID string, septime int64) ([]*MgoSwapRes
 // FindRouterSwapResultsToReplace find router swap result with status
 func FindRouterSwapResultsToReplace(chainID string, septime int64) ([]*MgoSwapResult, error) {
 	qtime := bson.M{"inittime": bson.M{"$gte": septime}}
-	qstatus := bson.M{"status": MatchTxNotStable}
+	qstatus := bson.M{"status": bson.M{"$in": []SwapStatus{MatchTxNotStable, RefundTxNotStable}}}
 	qchainid := bson.M{"toChainID": chainID}
 	qheight := bson.M{"swapheight": 0}
 	queries := []bson.M{qtime, qstatus, qchainid, qheight}
@@ -548,6 +725,50 @@ func FindRouterSwapResultsToReplace(chainID string, septime int64) ([]*MgoSwapRe
 	return result, nil
 }
 
+// FindRouterSwapResultsPending find swap results which are not sent to chain yet
+func FindRouterSwapResultsPending(toChainID string) ([]*MgoSwapResult, error) {
+	qstatus := bson.M{"status": bson.M{"$in": []SwapStatus{MatchTxEmpty, Reswapping}}}
+	qchainid := bson.M{"toChainID": toChainID}
+	queries := []bson.M{qstatus, qchainid}
+
+	opts := &options.FindOptions{
+		Sort:  bson.D{{Key: "inittime", Value: 1}},
+		Limit: &maxCountOfResults,
+	}
+	cur, err := collRouterSwapResult.Find(clientCtx, bson.M{"$and": queries}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapResult, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindRouterSwapsPendingBigValue find big value swaps which are waiting to pass
+func FindRouterSwapsPendingBigValue(toChainID string) ([]*MgoSwap, error) {
+	qstatus := bson.M{"status": TxWithBigValue}
+	qchainid := bson.M{"toChainID": toChainID}
+	queries := []bson.M{qstatus, qchainid}
+
+	opts := &options.FindOptions{
+		Sort:  bson.D{{Key: "inittime", Value: 1}},
+		Limit: &maxCountOfResults,
+	}
+	cur, err := collRouterSwap.Find(clientCtx, bson.M{"$and": queries}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwap, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
 func getStatusesFromStr(status string) (registerStatuses, resultStatuses []SwapStatus) {
 	parts := strings.Split(status, ",")
 	registerStatuses = make([]SwapStatus, 0, 5)
@@ -654,7 +875,7 @@ func UpdateRouterSwapResult(fromChainID, txid string, logindex int, items *SwapR
 		return err
 	}
 
-	if swapRes.Status == MatchTxStable {
+	if swapRes.Status == MatchTxStable || swapRes.Status == RefundTxStable {
 		log.Warn("ignore update swap result with stable status", "chainid", fromChainID, "txid", txid, "logindex", logindex, "updates", items, "swaptx", swapRes.SwapTx, "swapnonce", swapRes.SwapNonce)
 		return nil
 	}
@@ -664,7 +885,11 @@ func UpdateRouterSwapResult(fromChainID, txid string, logindex int, items *SwapR
 		"timestamp": items.Timestamp,
 	}
 	if items.Status != KeepStatus {
-		updates["status"] = items.Status
+		if swapRes.Status.IsRefundStatus() {
+			updates["status"] = items.Status.toRefundStatus()
+		} else {
+			updates["status"] = items.Status
+		}
 	}
 	if items.MPC != "" {
 		updates["mpc"] = items.MPC
@@ -683,8 +908,10 @@ func UpdateRouterSwapResult(fromChainID, txid string, logindex int, items *SwapR
 	}
 	if items.Memo != "" {
 		updates["memo"] = items.Memo
+		updates["errcode"] = items.ErrCode
 	} else if items.Status == MatchTxNotStable {
 		updates["memo"] = ""
+		updates["errcode"] = tokens.ErrCodeNone
 	}
 	if items.SwapNonce != 0 || items.Status == MatchTxNotStable {
 		err = checkRouterSwapResultUpdate(swapRes, items.SwapNonce)
@@ -695,7 +922,13 @@ func UpdateRouterSwapResult(fromChainID, txid string, logindex int, items *SwapR
 			updates["swapnonce"] = items.SwapNonce
 		}
 	}
-	_, err = collRouterSwapResult.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	setLeaseFenceUpdates(updates, items.Lease)
+	filter := getLeaseFenceFilter(key, items.Lease)
+	res, err := collRouterSwapResult.UpdateOne(clientCtx, filter, bson.M{"$set": updates})
+	if err == nil && res.MatchedCount == 0 {
+		log.Warn("mongodb update router swap result with lost chain lease", "chainid", fromChainID, "txid", txid, "logindex", logindex, "updates", updates)
+		return ErrChainLeaseIsLost
+	}
 	if err == nil {
 		log.Info("mongodb update router swap result success", "chainid", fromChainID, "txid", txid, "logindex", logindex, "updates", updates)
 	} else {
@@ -704,6 +937,26 @@ func UpdateRouterSwapResult(fromChainID, txid string, logindex int, items *SwapR
 	return mgoError(err)
 }
 
+// getLeaseFenceFilter fence the swap result write by chain lease,
+// the write is rejected if the swap result is written by a newer chain lease.
+func getLeaseFenceFilter(key string, lease *tokens.ChainLease) bson.M {
+	if lease == nil {
+		return bson.M{"_id": key}
+	}
+	return bson.M{"_id": key, "$or": []bson.M{
+		{"leasetoken": bson.M{"$exists": false}},
+		{"leasetoken": bson.M{"$lt": lease.Token}},
+		{"leasetoken": lease.Token, "leaseowner": lease.Owner},
+	}}
+}
+
+func setLeaseFenceUpdates(updates bson.M, lease *tokens.ChainLease) {
+	if lease != nil {
+		updates["leaseowner"] = lease.Owner
+		updates["leasetoken"] = lease.Token
+	}
+}
+
 func checkRouterSwapResultUpdate(swapRes *MgoSwapResult, swapnonce uint64) error {
 	if swapRes.SwapNonce != 0 {
 		log.Error("forbid update swap nonce again", "old", swapRes.SwapNonce, "new", swapnonce)
@@ -747,120 +1000,933 @@ func AddUsedRValue(pubkey, r string) error {
 	}
 }
 
-// ----------------------------- admin functions -------------------------------------
-
-// RouterAdminPassBigValue pass big value
-func RouterAdminPassBigValue(fromChainID, txid string, logIndex int) error {
-	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
-	if err != nil {
-		return err
-	}
-	if swap.Status != TxWithBigValue {
-		return fmt.Errorf("swap status is %v, not big value status %v", swap.Status.String(), TxWithBigValue.String())
-	}
+// GetSignedTxKey get signed tx key
+func GetSignedTxKey(toChainID, swapTx string) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v", toChainID, swapTx))
+}
 
-	_, err = FindRouterSwapResult(fromChainID, txid, logIndex)
+// AddSignedTx add signed tx
+func AddSignedTx(mt *MgoSignedTx) error {
+	mt.Key = GetSignedTxKey(mt.ToChainID, mt.SwapTx)
+	mt.SwapKey = GetRouterSwapKey(mt.FromChainID, mt.TxID, mt.LogIndex)
+	mt.InitTime = common.NowMilli()
+	_, err := collSignedTx.InsertOne(clientCtx, mt)
 	if err == nil {
-		return fmt.Errorf("can not pass big value swap with result exist")
+		log.Info("mongodb add signed tx success", "chainid", mt.FromChainID, "txid", mt.TxID, "logindex", mt.LogIndex, "swaptx", mt.SwapTx, "swapnonce", mt.SwapNonce, "keyID", mt.KeyID)
+	} else if !mongo.IsDuplicateKeyError(err) {
+		log.Error("mongodb add signed tx failed", "chainid", mt.FromChainID, "txid", mt.TxID, "logindex", mt.LogIndex, "swaptx", mt.SwapTx, "err", err)
 	}
-	return UpdateRouterSwapStatus(fromChainID, txid, logIndex, TxNotSwapped, time.Now().Unix(), "")
+	return mgoError(err)
 }
 
-// RouterAdminReswap reswap
-func RouterAdminReswap(fromChainID, txid string, logIndex int) error {
-	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+// FindSignedTx find signed tx by swaptx
+func FindSignedTx(toChainID, swapTx string) (*MgoSignedTx, error) {
+	result := &MgoSignedTx{}
+	err := collSignedTx.FindOne(clientCtx, bson.M{"_id": GetSignedTxKey(toChainID, swapTx)}).Decode(result)
 	if err != nil {
-		return err
-	}
-	if swap.Status != TxProcessed {
-		return fmt.Errorf("swap status is %v, can not reswap", swap.Status.String())
+		return nil, mgoError(err)
 	}
+	return result, nil
+}
 
-	res, err := FindRouterSwapResult(fromChainID, txid, logIndex)
+// FindSignedTxs find all signed txs of swap
+func FindSignedTxs(fromChainID, txid string, logindex int) ([]*MgoSignedTx, error) {
+	query := bson.M{"swapkey": GetRouterSwapKey(fromChainID, txid, logindex)}
+	opts := &options.FindOptions{
+		Sort: bson.D{{Key: "inittime", Value: 1}},
+	}
+	cur, err := collSignedTx.Find(clientCtx, query, opts)
 	if err != nil {
-		return err
+		return nil, mgoError(err)
 	}
-	if res.Status != MatchTxFailed {
-		return fmt.Errorf("swap result status is %v, can not reswap", res.Status.String())
+	result := make([]*MgoSignedTx, 0, 2)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
 	}
+	return result, nil
+}
 
-	if res.SwapTx == "" {
-		return errors.New("swap without swaptx")
+// UpdateSignedTxRebroadcast increase rebroadcast count of signed tx
+func UpdateSignedTxRebroadcast(key string) error {
+	updates := bson.M{
+		"$set": bson.M{"timestamp": time.Now().Unix()},
+		"$inc": bson.M{"rebroadcast": 1},
 	}
-
-	resBridge := router.GetBridgeByChainID(swap.ToChainID)
-	if resBridge == nil {
-		return tokens.ErrNoBridgeForChainID
+	_, err := collSignedTx.UpdateByID(clientCtx, key, updates)
+	if err != nil {
+		log.Error("mongodb update signed tx rebroadcast failed", "key", key, "err", err)
 	}
+	return mgoError(err)
+}
 
-	txStatus, txHash := getSwapResultsTxStatus(resBridge, res)
-	if txStatus != nil && txStatus.BlockHeight > 0 && !txStatus.IsSwapTxOnChainAndFailed() {
-		_ = UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, MatchTxNotStable, time.Now().Unix(), "")
-		return fmt.Errorf("swap succeed with swaptx %v", txHash)
+// AddReplaceDecision add replace decision
+func AddReplaceDecision(md *MgoReplaceDecision) error {
+	md.SwapKey = GetRouterSwapKey(md.FromChainID, md.TxID, md.LogIndex)
+	_, err := collReplaceDecision.InsertOne(clientCtx, md)
+	if err == nil {
+		log.Info("mongodb add replace decision success", "chainid", md.FromChainID, "txid", md.TxID, "logindex", md.LogIndex, "action", md.Action, "replacenum", md.ReplaceNum, "newswaptx", md.NewSwapTx)
+	} else {
+		log.Error("mongodb add replace decision failed", "chainid", md.FromChainID, "txid", md.TxID, "logindex", md.LogIndex, "action", md.Action, "err", err)
 	}
+	return mgoError(err)
+}
 
-	nonceSetter, ok := resBridge.(tokens.NonceSetter)
-	if ok {
-		mpcAddress := res.MPC
-		nonce, errf := nonceSetter.GetPoolNonce(mpcAddress, "latest")
-		if errf != nil {
-			log.Warn("get router mpc nonce failed", "address", mpcAddress)
-			return errf
-		}
-		if nonce <= res.SwapNonce {
-			return errors.New("can not retry swap with lower nonce")
-		}
+// FindReplaceDecisions find all replace decisions of swap
+func FindReplaceDecisions(fromChainID, txid string, logindex int) ([]*MgoReplaceDecision, error) {
+	query := bson.M{"swapkey": GetRouterSwapKey(fromChainID, txid, logindex)}
+	opts := &options.FindOptions{
+		Sort: bson.D{{Key: "timestamp", Value: 1}},
 	}
-
-	log.Info("[reswap] update status to TxNotSwapped", "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "swaptx", res.SwapTx)
-
-	err = UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, Reswapping, time.Now().Unix(), "")
+	cur, err := collReplaceDecision.Find(clientCtx, query, opts)
 	if err != nil {
-		return err
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoReplaceDecision, 0, 2)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
 	}
+	return result, nil
+}
 
-	return UpdateRouterSwapStatus(fromChainID, txid, logIndex, TxNotSwapped, time.Now().Unix(), "")
+// GetSyncedCursorKey get synced cursor key
+func GetSyncedCursorKey(chainID, name string) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v", chainID, name))
 }
 
-func getSwapResultsTxStatus(bridge tokens.IBridge, res *MgoSwapResult) (status *tokens.TxStatus, txHash string) {
-	var err error
-	if status, err = bridge.GetTransactionStatus(res.SwapTx); err == nil {
-		return status, res.SwapTx
+// UpdateSyncedCursor update or insert synced cursor
+func UpdateSyncedCursor(chainID, name string, cursor uint64) error {
+	key := GetSyncedCursorKey(chainID, name)
+	updates := bson.M{
+		"chainID":   chainID,
+		"name":      name,
+		"cursor":    cursor,
+		"timestamp": time.Now().Unix(),
 	}
-	for _, tx := range res.OldSwapTxs {
-		if status, err = bridge.GetTransactionStatus(tx); err == nil {
-			return status, tx
-		}
+	opts := options.Update().SetUpsert(true)
+	_, err := collSyncedCursor.UpdateByID(clientCtx, key, bson.M{"$set": updates}, opts)
+	if err != nil {
+		log.Error("mongodb update synced cursor failed", "chainid", chainID, "name", name, "cursor", cursor, "err", err)
 	}
-	return nil, ""
+	return mgoError(err)
 }
 
-var defaultGetStatusInfoRegisterFilter = []SwapStatus{
-	TxNotStable,    // 0
-	TxWithBigValue, // 12
+// FindSyncedCursor find synced cursor
+func FindSyncedCursor(chainID, name string) (*MgoSyncedCursor, error) {
+	result := &MgoSyncedCursor{}
+	err := collSyncedCursor.FindOne(clientCtx, bson.M{"_id": GetSyncedCursorKey(chainID, name)}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
 }
 
-var defaultGetStatusInfoResultFilter = []SwapStatus{
-	MatchTxEmpty,     // 8
-	MatchTxNotStable, // 9
-	MatchTxFailed,    // 14
+// getDBTime get the current time (unix seconds) of database server,
+// so the lease expiry is not affected by the clock skew of server nodes.
+func getDBTime() (int64, error) {
+	var result struct {
+		LocalTime time.Time `bson:"localTime"`
+	}
+	err := client.Database("admin").RunCommand(clientCtx, bson.D{{Key: "isMaster", Value: 1}}).Decode(&result)
+	if err != nil {
+		return 0, mgoError(err)
+	}
+	return result.LocalTime.Unix(), nil
 }
 
-// GetStatusInfo get status info
-func GetStatusInfo(statuses string) (statusInfo map[string]interface{}, err error) {
-	registerStatuses, resultStatuses := getStatusesFromStr(statuses)
-	if len(registerStatuses) == 0 && len(resultStatuses) == 0 {
-		registerStatuses = defaultGetStatusInfoRegisterFilter
-		resultStatuses = defaultGetStatusInfoResultFilter
+// AcquireChainLease acquire or renew chain lease.
+// returns ErrItemIsDup if the lease is held by others and not expired.
+func AcquireChainLease(chainID, owner string, ttl int64) (*MgoChainLease, error) {
+	key := strings.ToLower(chainID)
+	now, err := getDBTime()
+	if err != nil {
+		return nil, err
 	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
-	var registerInfo, resusltInfo []bson.M
-
-	if len(registerStatuses) > 0 {
-		registerInfo, err = getStatusInfo(collRouterSwap, registerStatuses)
-		if err != nil {
-			return nil, mgoError(err)
-		}
+	// renew lease held by owner
+	result := &MgoChainLease{}
+	filter := bson.M{"_id": key, "owner": owner}
+	updates := bson.M{"expireAt": now + ttl, "timestamp": now}
+	err = collChainLease.FindOneAndUpdate(clientCtx, filter, bson.M{"$set": updates}, opts).Decode(result)
+	if err == nil {
+		return result, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, mgoError(err)
+	}
+
+	// take over expired lease, or create new one
+	filter = bson.M{"_id": key, "expireAt": bson.M{"$lt": now}}
+	updates = bson.M{"chainID": chainID, "owner": owner, "expireAt": now + ttl, "timestamp": now}
+	opts.SetUpsert(true)
+	err = collChainLease.FindOneAndUpdate(clientCtx, filter, bson.M{"$set": updates, "$inc": bson.M{"token": 1}}, opts).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	log.Info("mongodb acquire chain lease success", "chainid", chainID, "owner", owner, "token", result.Token, "expireAt", result.ExpireAt)
+	return result, nil
+}
+
+// ReleaseChainLease release chain lease held by owner
+func ReleaseChainLease(chainID, owner string) error {
+	now, err := getDBTime()
+	if err != nil {
+		return err
+	}
+	filter := bson.M{"_id": strings.ToLower(chainID), "owner": owner}
+	updates := bson.M{"expireAt": 0, "timestamp": now}
+	_, err = collChainLease.UpdateOne(clientCtx, filter, bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb release chain lease success", "chainid", chainID, "owner", owner)
+	}
+	return mgoError(err)
+}
+
+// CheckChainLease check chain lease is held by owner with the fencing token
+func CheckChainLease(chainID, owner string, token uint64) error {
+	now, err := getDBTime()
+	if err != nil {
+		return err
+	}
+	result := &MgoChainLease{}
+	err = collChainLease.FindOne(clientCtx, bson.M{"_id": strings.ToLower(chainID)}).Decode(result)
+	if err != nil {
+		return mgoError(err)
+	}
+	if result.Owner != owner || result.Token != token || result.ExpireAt < now {
+		return fmt.Errorf("chain lease of %v is lost, owner %v token %v", chainID, result.Owner, result.Token)
+	}
+	return nil
+}
+
+// FindChainLeases find all chain leases
+func FindChainLeases() ([]*MgoChainLease, error) {
+	cur, err := collChainLease.Find(clientCtx, bson.M{})
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoChainLease, 0, 10)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// UpdateClusterNodeHeartbeat update heartbeat of cluster node
+func UpdateClusterNodeHeartbeat(nodeID string, ttl int64) error {
+	now, err := getDBTime()
+	if err != nil {
+		return err
+	}
+	updates := bson.M{"expireAt": now + ttl, "timestamp": now}
+	opts := options.Update().SetUpsert(true)
+	_, err = collClusterNode.UpdateByID(clientCtx, nodeID, bson.M{"$set": updates}, opts)
+	return mgoError(err)
+}
+
+// FindActiveClusterNodes find active cluster nodes
+func FindActiveClusterNodes() ([]*MgoClusterNode, error) {
+	now, err := getDBTime()
+	if err != nil {
+		return nil, err
+	}
+	query := bson.M{"expireAt": bson.M{"$gte": now}}
+	cur, err := collClusterNode.Find(clientCtx, query)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoClusterNode, 0, 3)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// UpdateDrainState update or insert drain state
+func UpdateDrainState(state *MgoDrainState) error {
+	state.Timestamp = time.Now().Unix()
+	opts := options.Replace().SetUpsert(true)
+	_, err := collDrainState.ReplaceOne(clientCtx, bson.M{"_id": state.Key}, state, opts)
+	if err == nil {
+		log.Info("mongodb update drain state success", "key", state.Key, "global", state.Global, "chainIDs", state.ChainIDs)
+	} else {
+		log.Error("mongodb update drain state failed", "key", state.Key, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindDrainState find drain state
+func FindDrainState(key string) (*MgoDrainState, error) {
+	result := &MgoDrainState{}
+	err := collDrainState.FindOne(clientCtx, bson.M{"_id": key}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// ForEachRouterSwapResultInTimeRange call fn with each swap result initialized in [startTime, endTime) (milliseconds)
+func ForEachRouterSwapResultInTimeRange(startTime, endTime int64, fn func(*MgoSwapResult) error) error {
+	query := bson.M{"inittime": bson.M{"$gte": startTime, "$lt": endTime}}
+	cur, err := collRouterSwapResult.Find(clientCtx, query)
+	if err != nil {
+		return mgoError(err)
+	}
+	defer cur.Close(clientCtx)
+	for cur.Next(clientCtx) {
+		res := &MgoSwapResult{}
+		if err = cur.Decode(res); err != nil {
+			return mgoError(err)
+		}
+		if err = fn(res); err != nil {
+			return err
+		}
+	}
+	return mgoError(cur.Err())
+}
+
+// GetSwapRollupKey get swap rollup key
+func GetSwapRollupKey(day string, fields ...string) string {
+	return strings.ToLower(strings.Join(append([]string{day}, fields...), ":"))
+}
+
+// UpdateSwapVolumeRollups replace swap volume rollups of the day
+func UpdateSwapVolumeRollups(day string, items []*MgoSwapVolumeRollup) error {
+	keys := make([]string, 0, len(items))
+	models := make([]mongo.WriteModel, 0, len(items)+1)
+	for _, item := range items {
+		item.Timestamp = time.Now().Unix()
+		keys = append(keys, item.Key)
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": item.Key}).SetReplacement(item).SetUpsert(true))
+	}
+	return bulkWriteRollups(collSwapVolumeRollup, day, keys, models)
+}
+
+// UpdateSwapStatusRollups replace swap status rollups of the day
+func UpdateSwapStatusRollups(day string, items []*MgoSwapStatusRollup) error {
+	keys := make([]string, 0, len(items))
+	models := make([]mongo.WriteModel, 0, len(items)+1)
+	for _, item := range items {
+		item.Timestamp = time.Now().Unix()
+		keys = append(keys, item.Key)
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": item.Key}).SetReplacement(item).SetUpsert(true))
+	}
+	return bulkWriteRollups(collSwapStatusRollup, day, keys, models)
+}
+
+// UpdateSwapSenderRollups replace swap sender rollups of the day
+func UpdateSwapSenderRollups(day string, items []*MgoSwapSenderRollup) error {
+	keys := make([]string, 0, len(items))
+	models := make([]mongo.WriteModel, 0, len(items)+1)
+	for _, item := range items {
+		item.Timestamp = time.Now().Unix()
+		keys = append(keys, item.Key)
+		models = append(models, mongo.NewReplaceOneModel().SetFilter(bson.M{"_id": item.Key}).SetReplacement(item).SetUpsert(true))
+	}
+	return bulkWriteRollups(collSwapSenderRollup, day, keys, models)
+}
+
+// bulkWriteRollups delete the stale rollups of the day which are not in the keys
+// (eg. status changed since the last computing) and upsert the new rollups in one bulk write
+func bulkWriteRollups(coll *mongo.Collection, day string, keys []string, models []mongo.WriteModel) error {
+	deleteStale := mongo.NewDeleteManyModel().SetFilter(bson.M{"day": day, "_id": bson.M{"$nin": keys}})
+	models = append([]mongo.WriteModel{deleteStale}, models...)
+	_, err := coll.BulkWrite(clientCtx, models, options.BulkWrite().SetOrdered(true))
+	if err != nil {
+		log.Error("mongodb update swap rollups failed", "collection", coll.Name(), "day", day, "count", len(keys), "err", err)
+	}
+	return mgoError(err)
+}
+
+func getDayRangeQuery(startDay, endDay string) bson.M {
+	return bson.M{"day": bson.M{"$gte": startDay, "$lte": endDay}}
+}
+
+// FindSwapVolumeRollups find swap volume rollups in day range (inclusive)
+func FindSwapVolumeRollups(startDay, endDay, tokenID, fromChainID, toChainID string) ([]*MgoSwapVolumeRollup, error) {
+	queries := []bson.M{getDayRangeQuery(startDay, endDay)}
+	if tokenID != "" {
+		queries = append(queries, bson.M{"tokenID": tokenID})
+	}
+	if fromChainID != "" {
+		queries = append(queries, bson.M{"fromChainID": fromChainID})
+	}
+	if toChainID != "" {
+		queries = append(queries, bson.M{"toChainID": toChainID})
+	}
+	opts := &options.FindOptions{
+		Sort: bson.D{{Key: "day", Value: 1}, {Key: "_id", Value: 1}},
+	}
+	cur, err := collSwapVolumeRollup.Find(clientCtx, bson.M{"$and": queries}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapVolumeRollup, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindSwapStatusRollups find swap status rollups in day range (inclusive)
+func FindSwapStatusRollups(startDay, endDay string) ([]*MgoSwapStatusRollup, error) {
+	opts := &options.FindOptions{
+		Sort: bson.D{{Key: "day", Value: 1}, {Key: "status", Value: 1}},
+	}
+	cur, err := collSwapStatusRollup.Find(clientCtx, getDayRangeQuery(startDay, endDay), opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapStatusRollup, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindTopSwapSenders find top senders by swap count in day range (inclusive).
+// the day field of the returned items is empty as they are summed over days.
+func FindTopSwapSenders(startDay, endDay, tokenID string, limit int64) ([]*MgoSwapSenderRollup, error) {
+	queries := []bson.M{getDayRangeQuery(startDay, endDay)}
+	if tokenID != "" {
+		queries = append(queries, bson.M{"tokenID": tokenID})
+	}
+	pipeOption := []bson.M{
+		{"$match": bson.M{"$and": queries}},
+		{"$group": bson.M{
+			"_id":       bson.M{"$concat": []string{"$tokenID", ":", "$from"}},
+			"tokenID":   bson.M{"$first": "$tokenID"},
+			"from":      bson.M{"$first": "$from"},
+			"swapCount": bson.M{"$sum": "$swapCount"},
+			"volume":    bson.M{"$sum": "$volume"},
+		}},
+		{"$sort": bson.D{{Key: "swapCount", Value: -1}, {Key: "volume", Value: -1}}},
+		{"$limit": limit},
+	}
+
+	ctx, cancel := context.WithDeadline(clientCtx, time.Now().Add(60*time.Second))
+	defer cancel()
+
+	cur, err := collSwapSenderRollup.Aggregate(ctx, pipeOption)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapSenderRollup, 0, limit)
+	err = cur.All(ctx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// GetDestTagKey get dest tag key
+func GetDestTagKey(chainID string, tag uint64) string {
+	return fmt.Sprintf("%v:%v", chainID, tag)
+}
+
+// FindDestTag find dest tag route
+func FindDestTag(chainID string, tag uint64) (*MgoDestTag, error) {
+	result := &MgoDestTag{}
+	err := collDestTag.FindOne(clientCtx, bson.M{"_id": GetDestTagKey(chainID, tag)}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// MarkDestTagUsed mark dest tag route is used by deposit tx
+// after that the route is immutable
+func MarkDestTagUsed(chainID string, tag uint64, txid string) error {
+	filter := bson.M{"_id": GetDestTagKey(chainID, tag), "used": bson.M{"$ne": true}}
+	updates := bson.M{"used": true, "usedTx": txid}
+	res, err := collDestTag.UpdateOne(clientCtx, filter, bson.M{"$set": updates})
+	if err != nil {
+		return mgoError(err)
+	}
+	if res.ModifiedCount > 0 {
+		log.Info("mongodb mark dest tag used success", "chainid", chainID, "tag", tag, "txid", txid)
+	}
+	return nil
+}
+
+// AddAnyCallAttempt add failed anycall attempt to swap result
+func AddAnyCallAttempt(fromChainID, txid string, logindex int, attempt *AnyCallAttempt) error {
+	updateResultLock.Lock()
+	defer updateResultLock.Unlock()
+
+	key := GetRouterSwapKey(fromChainID, txid, logindex)
+	attempt.Timestamp = time.Now().Unix()
+	_, err := collRouterSwapResult.UpdateByID(clientCtx, key, bson.M{"$push": bson.M{"anycallattempts": attempt}})
+	if err == nil {
+		log.Info("mongodb add anycall attempt success", "chainid", fromChainID, "txid", txid, "logindex", logindex, "attempt", attempt)
+	} else {
+		log.Error("mongodb add anycall attempt failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "attempt", attempt, "err", err)
+	}
+	return mgoError(err)
+}
+
+// UpdateAnyCallFallbackSwap link the fallback swap to the failed anycall swap
+func UpdateAnyCallFallbackSwap(fromChainID, txid string, logindex int, fallbackSwap string) error {
+	updateResultLock.Lock()
+	defer updateResultLock.Unlock()
+
+	key := GetRouterSwapKey(fromChainID, txid, logindex)
+	_, err := collRouterSwapResult.UpdateByID(clientCtx, key, bson.M{"$set": bson.M{"fallbackswap": fallbackSwap}})
+	if err == nil {
+		log.Info("mongodb update anycall fallback swap success", "chainid", fromChainID, "txid", txid, "logindex", logindex, "fallbackSwap", fallbackSwap)
+	} else {
+		log.Error("mongodb update anycall fallback swap failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "fallbackSwap", fallbackSwap, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindAnyCallParentSwap find the failed anycall swap which triggers the fallback swap
+func FindAnyCallParentSwap(fallbackSwap string) (*MgoSwapResult, error) {
+	result := &MgoSwapResult{}
+	err := collRouterSwapResult.FindOne(clientCtx, bson.M{"fallbackswap": fallbackSwap}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindAnyCallParentSwapOfTx find the anycall swap which sent the swap tx on chain `chainID`
+func FindAnyCallParentSwapOfTx(chainID, swapTx string) (*MgoSwapResult, error) {
+	query := bson.M{
+		"toChainID": chainID,
+		"$or": []bson.M{
+			{"swaptx": swapTx},
+			{"anycallattempts.swaptx": swapTx},
+		},
+	}
+	result := &MgoSwapResult{}
+	err := collRouterSwapResult.FindOne(clientCtx, query).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// GetAnyCallLedgerKey get anycall ledger key
+func GetAnyCallLedgerKey(chainID, kind, address string) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v:%v", chainID, kind, address))
+}
+
+// AddAnyCallFee add anycall fee and update the usage of call from and dapp
+// the fee of a swap tx is only counted once
+func AddAnyCallFee(mf *MgoAnyCallFee) error {
+	mf.Key = GetSignedTxKey(mf.ToChainID, mf.SwapTx)
+	mf.SwapKey = GetRouterSwapKey(mf.FromChainID, mf.TxID, mf.LogIndex)
+	mf.Timestamp = time.Now().Unix()
+	_, err := collAnyCallFee.InsertOne(clientCtx, mf)
+	if err != nil {
+		if !mongo.IsDuplicateKeyError(err) {
+			log.Error("mongodb add anycall fee failed", "chainid", mf.ToChainID, "swaptx", mf.SwapTx, "err", err)
+		}
+		return mgoError(err)
+	}
+	log.Info("mongodb add anycall fee success", "chainid", mf.ToChainID, "swaptx", mf.SwapTx, "callFrom", mf.CallFrom, "dapp", mf.DApp, "gasUsed", mf.GasUsed, "fee", mf.Fee)
+	fee, _ := new(big.Int).SetString(mf.Fee, 0)
+	err = updateAnyCallLedger(mf.ToChainID, AnyCallLedgerCallFrom, mf.CallFrom, nil, fee, mf.GasUsed)
+	if err != nil {
+		return err
+	}
+	if mf.DApp != "" {
+		err = updateAnyCallLedger(mf.ToChainID, AnyCallLedgerDApp, mf.DApp, nil, fee, mf.GasUsed)
+	}
+	return err
+}
+
+func updateAnyCallLedger(chainID, kind, address string, deposit, used *big.Int, gasUsed uint64) error {
+	key := GetAnyCallLedgerKey(chainID, kind, address)
+	if deposit == nil {
+		deposit = big.NewInt(0)
+	}
+	var txCount uint64
+	if used == nil {
+		used = big.NewInt(0)
+	} else {
+		txCount = 1
+	}
+	depositDec, ok1 := primitive.ParseDecimal128FromBigInt(deposit, 0)
+	usedDec, ok2 := primitive.ParseDecimal128FromBigInt(used, 0)
+	if !ok1 || !ok2 {
+		return fmt.Errorf("anycall ledger %v amount overflow, deposit %v, used %v", key, deposit, used)
+	}
+	// increase atomically, so concurrent updates from multiple servers are not lost
+	updates := bson.M{
+		"$set": bson.M{
+			"chainID":   chainID,
+			"kind":      kind,
+			"address":   strings.ToLower(address),
+			"timestamp": time.Now().Unix(),
+		},
+		"$inc": bson.M{
+			"deposited": depositDec,
+			"used":      usedDec,
+			"gasUsed":   gasUsed,
+			"txCount":   txCount,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := collAnyCallLedger.FindOneAndUpdate(clientCtx, bson.M{"_id": key}, updates, opts).Err()
+	if err != nil {
+		log.Error("mongodb update anycall ledger failed", "key", key, "err", err)
+	}
+	return mgoError(err)
+}
+
+// FindAnyCallLedger find anycall ledger
+func FindAnyCallLedger(chainID, kind, address string) (*MgoAnyCallLedger, error) {
+	result := &MgoAnyCallLedger{}
+	err := collAnyCallLedger.FindOne(clientCtx, bson.M{"_id": GetAnyCallLedgerKey(chainID, kind, address)}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindAnyCallLedgers find anycall ledgers of kind on chain (sorted by used desc)
+func FindAnyCallLedgers(chainID, kind string) ([]*MgoAnyCallLedger, error) {
+	query := bson.M{"chainID": chainID, "kind": kind}
+	opts := &options.FindOptions{
+		Sort:  bson.D{{Key: "gasUsed", Value: -1}},
+		Limit: &maxCountOfResults,
+	}
+	cur, err := collAnyCallLedger.Find(clientCtx, query, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoAnyCallLedger, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// ----------------------------- admin functions -------------------------------------
+
+// RouterAdminPassBigValue pass big value
+func RouterAdminPassBigValue(fromChainID, txid string, logIndex int) error {
+	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if swap.Status != TxWithBigValue {
+		return fmt.Errorf("swap status is %v, not big value status %v", swap.Status.String(), TxWithBigValue.String())
+	}
+
+	if swap.BigValueHeld {
+		return ErrBigValueIsHeld
+	}
+
+	_, err = FindRouterSwapResult(fromChainID, txid, logIndex)
+	if err == nil {
+		return fmt.Errorf("can not pass big value swap with result exist")
+	}
+
+	// the swap may be held after the above checking
+	key := GetRouterSwapKey(fromChainID, txid, logIndex)
+	filter := bson.M{"_id": key, "status": TxWithBigValue, "bigvalueheld": bson.M{"$ne": true}}
+	updates := bson.M{"status": TxNotSwapped, "timestamp": time.Now().Unix(), "memo": "", "errcode": tokens.ErrCodeNone}
+	res, err := collRouterSwap.UpdateOne(clientCtx, filter, bson.M{"$set": updates})
+	if err == nil && res.MatchedCount == 0 {
+		return ErrBigValueIsHeld
+	}
+	if err == nil {
+		log.Info("mongodb pass big value swap success", "chainid", fromChainID, "txid", txid, "logindex", logIndex)
+	} else {
+		log.Error("mongodb pass big value swap failed", "chainid", fromChainID, "txid", txid, "logindex", logIndex, "err", err)
+	}
+	return mgoError(err)
+}
+
+// RouterAdminHoldBigValue hold big value swap from passing automatically
+func RouterAdminHoldBigValue(fromChainID, txid string, logIndex int) error {
+	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if swap.Status != TxWithBigValue {
+		return fmt.Errorf("swap status is %v, not big value status %v", swap.Status.String(), TxWithBigValue.String())
+	}
+	key := GetRouterSwapKey(fromChainID, txid, logIndex)
+	_, err = collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": bson.M{"bigvalueheld": true}})
+	if err == nil {
+		log.Info("mongodb hold big value swap success", "chainid", fromChainID, "txid", txid, "logindex", logIndex)
+	} else {
+		log.Error("mongodb hold big value swap failed", "chainid", fromChainID, "txid", txid, "logindex", logIndex, "err", err)
+	}
+	return mgoError(err)
+}
+
+// RouterAdminUnholdBigValue unhold big value swap, then it can be passed again
+func RouterAdminUnholdBigValue(fromChainID, txid string, logIndex int) error {
+	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if swap.Status != TxWithBigValue {
+		return fmt.Errorf("swap status is %v, not big value status %v", swap.Status.String(), TxWithBigValue.String())
+	}
+	if !swap.BigValueHeld {
+		return fmt.Errorf("big value swap is not held")
+	}
+	key := GetRouterSwapKey(fromChainID, txid, logIndex)
+	_, err = collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$unset": bson.M{"bigvalueheld": ""}})
+	if err == nil {
+		log.Info("mongodb unhold big value swap success", "chainid", fromChainID, "txid", txid, "logindex", logIndex)
+	} else {
+		log.Error("mongodb unhold big value swap failed", "chainid", fromChainID, "txid", txid, "logindex", logIndex, "err", err)
+	}
+	return mgoError(err)
+}
+
+// RouterAdminCancelBigValue cancel big value swap
+func RouterAdminCancelBigValue(fromChainID, txid string, logIndex int, reason error) error {
+	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if swap.Status != TxWithBigValue {
+		return fmt.Errorf("swap status is %v, not big value status %v", swap.Status.String(), TxWithBigValue.String())
+	}
+
+	_, err = FindRouterSwapResult(fromChainID, txid, logIndex)
+	if err == nil {
+		return fmt.Errorf("can not cancel big value swap with result exist")
+	}
+	return UpdateRouterSwapStatus(fromChainID, txid, logIndex, BigValueCanceled, time.Now().Unix(), reason)
+}
+
+// RouterAdminApproveScreening approve swap flagged by address screening
+func RouterAdminApproveScreening(fromChainID, txid string, logIndex int) error {
+	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if swap.Status != SwapInScreeningReview {
+		return fmt.Errorf("swap status is %v, not screening review status %v", swap.Status.String(), SwapInScreeningReview.String())
+	}
+
+	status := TxNotStable
+	if _, err = FindRouterSwapResult(fromChainID, txid, logIndex); err == nil {
+		status = TxNotSwapped
+	}
+	key := GetRouterSwapKey(fromChainID, txid, logIndex)
+	updates := bson.M{
+		"status":            status,
+		"timestamp":         time.Now().Unix(),
+		"memo":              "",
+		"errcode":           tokens.ErrCodeNone,
+		"screeningapproved": true,
+	}
+	_, err = collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	if err == nil {
+		log.Info("mongodb approve screening swap success", "chainid", fromChainID, "txid", txid, "logindex", logIndex, "status", status)
+	} else {
+		log.Error("mongodb approve screening swap failed", "chainid", fromChainID, "txid", txid, "logindex", logIndex, "err", err)
+	}
+	return mgoError(err)
+}
+
+// RouterAdminRejectScreening reject swap flagged by address screening
+// the memo of screening is kept if reason is nil
+func RouterAdminRejectScreening(fromChainID, txid string, logIndex int, reason error) error {
+	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if swap.Status != SwapInScreeningReview {
+		return fmt.Errorf("swap status is %v, not screening review status %v", swap.Status.String(), SwapInScreeningReview.String())
+	}
+	return UpdateRouterSwapStatus(fromChainID, txid, logIndex, SwapInBlacklist, time.Now().Unix(), reason)
+}
+
+// RouterAdminReswap reswap
+func RouterAdminReswap(fromChainID, txid string, logIndex int) error {
+	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if swap.Status != TxProcessed {
+		return fmt.Errorf("swap status is %v, can not reswap", swap.Status.String())
+	}
+
+	res, err := FindRouterSwapResult(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if res.Status != MatchTxFailed && res.Status != MatchTxCanceled {
+		return fmt.Errorf("swap result status is %v, can not reswap", res.Status.String())
+	}
+
+	if res.SwapTx == "" {
+		return errors.New("swap without swaptx")
+	}
+
+	resBridge := router.GetBridgeByChainID(swap.ToChainID)
+	if resBridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+
+	txStatus, txHash := getSwapResultsTxStatus(resBridge, res)
+	if txStatus != nil && txStatus.BlockHeight > 0 && !txStatus.IsSwapTxOnChainAndFailed() {
+		_ = UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, MatchTxNotStable, time.Now().Unix(), nil)
+		return fmt.Errorf("swap succeed with swaptx %v", txHash)
+	}
+
+	nonceSetter, ok := resBridge.(tokens.NonceSetter)
+	if ok {
+		mpcAddress := res.MPC
+		nonce, errf := nonceSetter.GetPoolNonce(mpcAddress, "latest")
+		if errf != nil {
+			log.Warn("get router mpc nonce failed", "address", mpcAddress)
+			return errf
+		}
+		if nonce <= res.SwapNonce {
+			return errors.New("can not retry swap with lower nonce")
+		}
+	}
+
+	log.Info("[reswap] update status to TxNotSwapped", "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "swaptx", res.SwapTx)
+
+	err = UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, Reswapping, time.Now().Unix(), nil)
+	if err != nil {
+		return err
+	}
+
+	return UpdateRouterSwapStatus(fromChainID, txid, logIndex, TxNotSwapped, time.Now().Unix(), nil)
+}
+
+// RouterAdminApproveRefund approve refund of unswappable swap
+// back to its sender on the source chain
+func RouterAdminApproveRefund(fromChainID, txid string, logIndex int) error {
+	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if tokens.SwapType(swap.SwapType) != tokens.ERC20SwapType {
+		return fmt.Errorf("swap type %v does not support refund", tokens.SwapType(swap.SwapType).String())
+	}
+
+	res, err := FindRouterSwapResult(fromChainID, txid, logIndex)
+	switch {
+	case errors.Is(err, ErrItemNotFound):
+		if !swap.Status.IsRefundable() {
+			return fmt.Errorf("swap status is %v, can not refund", swap.Status.String())
+		}
+		res = swap.ToSwapResult()
+		res.ToChainID = swap.FromChainID
+		res.Bind = swap.From
+		res.SwapValue = "0"
+		res.Status = RefundTxEmpty
+		res.Timestamp = time.Now().Unix()
+		res.Memo = ""
+		res.ErrCode = tokens.ErrCodeNone
+		err = AddRouterSwapResult(res)
+	case err != nil:
+		return err
+	case swap.Status.IsRefundable() && res.Status == MatchTxEmpty && res.SwapTx == "" && res.SwapNonce == 0:
+		err = resetRouterSwapResultToRefund(fromChainID, txid, logIndex)
+	case swap.Status == TxRefunded && res.Status == RefundTxFailed:
+		// retry the onchain failed refund
+		resBridge := router.GetBridgeByChainID(res.ToChainID)
+		if resBridge == nil {
+			return tokens.ErrNoBridgeForChainID
+		}
+		txStatus, txHash := getSwapResultsTxStatus(resBridge, res)
+		if txStatus != nil && txStatus.BlockHeight > 0 && !txStatus.IsSwapTxOnChainAndFailed() {
+			_ = UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, RefundTxNotStable, time.Now().Unix(), nil)
+			return fmt.Errorf("refund succeed with swaptx %v", txHash)
+		}
+		err = resetRouterSwapResultToRefund(fromChainID, txid, logIndex)
+	default:
+		return fmt.Errorf("swap status is %v and result status is %v, can not refund", swap.Status.String(), res.Status.String())
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Info("[refund] update status to TxRefundApproved", "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "from", swap.From)
+
+	return UpdateRouterSwapStatus(fromChainID, txid, logIndex, TxRefundApproved, time.Now().Unix(), nil)
+}
+
+func resetRouterSwapResultToRefund(fromChainID, txid string, logIndex int) error {
+	swap, err := FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+
+	updateResultLock.Lock()
+	defer updateResultLock.Unlock()
+
+	key := GetRouterSwapKey(fromChainID, txid, logIndex)
+	updates := bson.M{
+		"toChainID":  swap.FromChainID,
+		"bind":       swap.From,
+		"status":     RefundTxEmpty,
+		"timestamp":  time.Now().Unix(),
+		"memo":       "",
+		"errcode":    tokens.ErrCodeNone,
+		"swaptx":     "",
+		"oldswaptxs": nil,
+		"swapheight": 0,
+		"swaptime":   0,
+		"swapvalue":  "0",
+		"swapnonce":  0,
+	}
+	_, err = collRouterSwapResult.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	return mgoError(err)
+}
+
+func getSwapResultsTxStatus(bridge tokens.IBridge, res *MgoSwapResult) (status *tokens.TxStatus, txHash string) {
+	var err error
+	if status, err = bridge.GetTransactionStatus(res.SwapTx); err == nil {
+		return status, res.SwapTx
+	}
+	for _, tx := range res.OldSwapTxs {
+		if status, err = bridge.GetTransactionStatus(tx); err == nil {
+			return status, tx
+		}
+	}
+	return nil, ""
+}
+
+var defaultGetStatusInfoRegisterFilter = []SwapStatus{
+	TxNotStable,    // 0
+	TxWithBigValue, // 12
+}
+
+var defaultGetStatusInfoResultFilter = []SwapStatus{
+	MatchTxEmpty,     // 8
+	MatchTxNotStable, // 9
+	MatchTxFailed,    // 14
+}
+
+// GetStatusInfo get status info
+func GetStatusInfo(statuses string) (statusInfo map[string]interface{}, err error) {
+	registerStatuses, resultStatuses := getStatusesFromStr(statuses)
+	if len(registerStatuses) == 0 && len(resultStatuses) == 0 {
+		registerStatuses = defaultGetStatusInfoRegisterFilter
+		resultStatuses = defaultGetStatusInfoResultFilter
+	}
+
+	var registerInfo, resusltInfo []bson.M
+
+	if len(registerStatuses) > 0 {
+		registerInfo, err = getStatusInfo(collRouterSwap, registerStatuses)
+		if err != nil {
+			return nil, mgoError(err)
+		}
 	}
 
 	if len(resultStatuses) > 0 {
@@ -903,6 +1969,59 @@ func getStatusInfo(coll *mongo.Collection, filterStatuses []SwapStatus) (result
 	return result, nil
 }
 
+// RouterAdminAddDestTag add or update dest tag route
+// the route can not be updated once it is used by a deposit
+func RouterAdminAddDestTag(chainID string, tag uint64, bind, toChainID string) error {
+	key := GetDestTagKey(chainID, tag)
+	updates := bson.M{
+		"chainID":   chainID,
+		"tag":       tag,
+		"bind":      bind,
+		"toChainID": toChainID,
+		"timestamp": time.Now().Unix(),
+	}
+	filter := bson.M{"_id": key, "used": bson.M{"$ne": true}}
+	opts := options.Update().SetUpsert(true)
+	_, err := collDestTag.UpdateOne(clientCtx, filter, bson.M{"$set": updates}, opts)
+	if err == nil {
+		log.Info("mongodb add dest tag success", "chainid", chainID, "tag", tag, "bind", bind, "toChainID", toChainID)
+		return nil
+	}
+	log.Error("mongodb add dest tag failed", "chainid", chainID, "tag", tag, "bind", bind, "toChainID", toChainID, "err", err)
+	if mongo.IsDuplicateKeyError(err) {
+		// upsert conflicts with the existing used route
+		return ErrDestTagIsUsed
+	}
+	return mgoError(err)
+}
+
+// RouterAdminRemoveDestTag remove dest tag route
+// the route can not be removed once it is used by a deposit
+func RouterAdminRemoveDestTag(chainID string, tag uint64) error {
+	key := GetDestTagKey(chainID, tag)
+	res, err := collDestTag.DeleteOne(clientCtx, bson.M{"_id": key, "used": bson.M{"$ne": true}})
+	if err != nil {
+		return mgoError(err)
+	}
+	if res.DeletedCount == 0 {
+		if _, errf := FindDestTag(chainID, tag); errf == nil {
+			return ErrDestTagIsUsed
+		}
+		return ErrItemNotFound
+	}
+	log.Info("mongodb remove dest tag success", "chainid", chainID, "tag", tag)
+	return nil
+}
+
+// RouterAdminDepositAnyCallBudget top up prepaid anycall budget of call from
+func RouterAdminDepositAnyCallBudget(chainID, callFrom string, amount *big.Int) error {
+	err := updateAnyCallLedger(chainID, AnyCallLedgerCallFrom, callFrom, amount, nil, 0)
+	if err == nil {
+		log.Info("mongodb deposit anycall budget success", "chainid", chainID, "callFrom", callFrom, "amount", amount)
+	}
+	return err
+}
+
 // ----------------------------- helper functions -------------------------------------
 
 // GetRegisteredRouterSwap get registered router swap
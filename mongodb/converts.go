@@ -31,10 +31,15 @@ func ConvertToSwapInfo(info *tokens.SwapInfo) SwapInfo {
 				ForNative:     erc20SwapInfo.ForNative,
 				ForUnderlying: erc20SwapInfo.ForUnderlying,
 				Path:          erc20SwapInfo.Path,
+				PoolFees:      erc20SwapInfo.PoolFees,
+				Executor:      erc20SwapInfo.Executor,
 			}
 			if erc20SwapInfo.AmountOutMin != nil {
 				swapinfo.ERC20SwapInfo.AmountOutMin = erc20SwapInfo.AmountOutMin.String()
 			}
+			if erc20SwapInfo.CallData != nil {
+				swapinfo.ERC20SwapInfo.CallData = erc20SwapInfo.CallData.String()
+			}
 		case erc20SwapInfo.CallProxy != "":
 			swapinfo.ERC20SwapInfo = &ERC20SwapInfo{
 				Token:     erc20SwapInfo.Token,
@@ -59,6 +64,8 @@ func ConvertToSwapInfo(info *tokens.SwapInfo) SwapInfo {
 			Amounts: fromBigIntSlice(nftSwapInfo.Amounts),
 			Batch:   nftSwapInfo.Batch,
 			Data:    nftSwapInfo.Data.String(),
+
+			Metadatas: fromNFTMetadatas(nftSwapInfo.Metadatas),
 		}
 	case info.AnyCallSwapInfo != nil:
 		anycallSwapInfo := info.AnyCallSwapInfo
@@ -100,6 +107,11 @@ func ConvertFromSwapInfo(swapinfo *SwapInfo) (tokens.SwapInfo, error) {
 				ForUnderlying: erc20SwapInfo.ForUnderlying,
 				Path:          erc20SwapInfo.Path,
 				AmountOutMin:  amountOutMin,
+				PoolFees:      erc20SwapInfo.PoolFees,
+				Executor:      erc20SwapInfo.Executor,
+			}
+			if erc20SwapInfo.CallData != "" {
+				info.ERC20SwapInfo.CallData = common.FromHex(erc20SwapInfo.CallData)
 			}
 		case erc20SwapInfo.CallProxy != "":
 			info.ERC20SwapInfo = &tokens.ERC20SwapInfo{
@@ -131,6 +143,8 @@ func ConvertFromSwapInfo(swapinfo *SwapInfo) (tokens.SwapInfo, error) {
 			Amounts: amounts,
 			Batch:   nftSwapInfo.Batch,
 			Data:    hexutil.Bytes(nftSwapInfo.Data),
+
+			Metadatas: toNFTMetadatas(nftSwapInfo.Metadatas),
 		}
 	case swapinfo.AnyCallSwapInfo != nil:
 		anyCallSwapInfo := swapinfo.AnyCallSwapInfo
@@ -192,3 +206,33 @@ func toBigIntSlice(slice []string) ([]*big.Int, error) {
 	}
 	return result, nil
 }
+
+func fromNFTMetadatas(metadatas []*tokens.NFTMetadata) []*NFTMetadata {
+	if len(metadatas) == 0 {
+		return nil
+	}
+	result := make([]*NFTMetadata, len(metadatas))
+	for i, m := range metadatas {
+		result[i] = &NFTMetadata{
+			URI:             m.URI,
+			RoyaltyReceiver: m.RoyaltyReceiver,
+			RoyaltyBps:      m.RoyaltyBps,
+		}
+	}
+	return result
+}
+
+func toNFTMetadatas(metadatas []*NFTMetadata) []*tokens.NFTMetadata {
+	if len(metadatas) == 0 {
+		return nil
+	}
+	result := make([]*tokens.NFTMetadata, len(metadatas))
+	for i, m := range metadatas {
+		result[i] = &tokens.NFTMetadata{
+			URI:             m.URI,
+			RoyaltyReceiver: m.RoyaltyReceiver,
+			RoyaltyBps:      m.RoyaltyBps,
+		}
+	}
+	return result
+}
@@ -35,4 +35,7 @@ var (
 	ErrWrongKey           = newError(-32012, "mgoError: Wrong key")
 	ErrForbidUpdateNonce  = newError(-32013, "mgoError: Forbid update swap nonce")
 	ErrForbidUpdateSwapTx = newError(-32014, "mgoError: Forbid update swap tx")
+	ErrDestTagIsUsed      = newError(-32015, "mgoError: Dest tag is used by deposit")
+	ErrBigValueIsHeld     = newError(-32016, "mgoError: Big value swap is held")
+	ErrChainLeaseIsLost   = newError(-32017, "mgoError: Chain lease is lost")
 )
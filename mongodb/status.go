@@ -17,14 +17,33 @@ import (
 // TxNotStable -> |- TxVerifyFailed    -> manual
 //                |- TxWithWrongValue  -> manual
 //                |- SwapInBlacklist   -> manual
+//                |- SwapInScreeningReview ---> TxNotStable (approve)
+//                                         ---> SwapInBlacklist (reject)
 //                |- TxWithBigValue    ---> TxNotSwapped
+//                                     ---> BigValueCanceled -> manual
 //                |- TxNotSwapped -> |- TxProcessed (->MatchTxNotStable)
+//                                   |- SwapInScreeningReview ---> TxNotSwapped (approve)
+//
+// TxVerifyFailed, MissTokenConfig, NoUnderlyingToken
+//                ---> TxNotStable (reverify)
+//
+// TxWithWrongValue, TxWithWrongPath, MissTokenConfig,
+// NoUnderlyingToken, SwapInBlacklist
+//                ---> TxRefundApproved -> TxRefunded (->RefundTxNotStable)
 // -----------------------------------------------
 // 2. swap result status change graph
 //
 // TxWithBigValue ---> MatchTxEmpty
 // MatchTxEmpty   -> | MatchTxNotStable -> |- MatchTxStable
 //                                         |- MatchTxFailed -> manual
+//                                         |- MatchTxCanceled -> manual
+//                                            (-> MatchTxNotStable if swap tx is on chain)
+// -----------------------------------------------
+// 3. swap refund result status change graph
+//
+// TxRefundApproved -> RefundTxEmpty
+// RefundTxEmpty    -> | RefundTxNotStable -> |- RefundTxStable
+//                                            |- RefundTxFailed ---> RefundTxEmpty
 // -----------------------------------------------
 
 // SwapStatus swap status
@@ -32,21 +51,30 @@ type SwapStatus uint16
 
 // swap status values
 const (
-	TxNotStable       SwapStatus = 0
-	TxVerifyFailed    SwapStatus = 1
-	TxWithWrongValue  SwapStatus = 3
-	TxNotSwapped      SwapStatus = 5
-	TxProcessed       SwapStatus = 7
-	MatchTxEmpty      SwapStatus = 8
-	MatchTxNotStable  SwapStatus = 9
-	MatchTxStable     SwapStatus = 10
-	TxWithBigValue    SwapStatus = 12
-	MatchTxFailed     SwapStatus = 14
-	SwapInBlacklist   SwapStatus = 15
-	ManualMakeFail    SwapStatus = 16
-	TxWithWrongPath   SwapStatus = 19
-	MissTokenConfig   SwapStatus = 20
-	NoUnderlyingToken SwapStatus = 21
+	TxNotStable           SwapStatus = 0
+	TxVerifyFailed        SwapStatus = 1
+	TxWithWrongValue      SwapStatus = 3
+	TxNotSwapped          SwapStatus = 5
+	TxProcessed           SwapStatus = 7
+	MatchTxEmpty          SwapStatus = 8
+	MatchTxNotStable      SwapStatus = 9
+	MatchTxStable         SwapStatus = 10
+	TxWithBigValue        SwapStatus = 12
+	MatchTxFailed         SwapStatus = 14
+	SwapInBlacklist       SwapStatus = 15
+	ManualMakeFail        SwapStatus = 16
+	TxWithWrongPath       SwapStatus = 19
+	MissTokenConfig       SwapStatus = 20
+	NoUnderlyingToken     SwapStatus = 21
+	BigValueCanceled      SwapStatus = 22
+	SwapInScreeningReview SwapStatus = 23
+	MatchTxCanceled       SwapStatus = 24
+	TxRefundApproved      SwapStatus = 30
+	TxRefunded            SwapStatus = 31
+	RefundTxEmpty         SwapStatus = 32
+	RefundTxNotStable     SwapStatus = 33
+	RefundTxStable        SwapStatus = 34
+	RefundTxFailed        SwapStatus = 35
 
 	KeepStatus SwapStatus = 255
 	Reswapping SwapStatus = 256
@@ -55,7 +83,64 @@ const (
 // IsResultStatus is swap result status
 func (status SwapStatus) IsResultStatus() bool {
 	switch status {
-	case MatchTxEmpty, MatchTxNotStable, MatchTxStable, MatchTxFailed, Reswapping:
+	case MatchTxEmpty, MatchTxNotStable, MatchTxStable, MatchTxFailed, MatchTxCanceled, Reswapping,
+		RefundTxEmpty, RefundTxNotStable, RefundTxStable, RefundTxFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRefundStatus is swap refund result status
+func (status SwapStatus) IsRefundStatus() bool {
+	switch status {
+	case RefundTxEmpty, RefundTxNotStable, RefundTxStable, RefundTxFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRefundable is unswappable status which can be refunded
+func (status SwapStatus) IsRefundable() bool {
+	switch status {
+	case TxWithWrongValue, TxWithWrongPath, MissTokenConfig, NoUnderlyingToken, SwapInBlacklist:
+		return true
+	default:
+		return false
+	}
+}
+
+// toRefundStatus convert swap result status to the refund one
+func (status SwapStatus) toRefundStatus() SwapStatus {
+	switch status {
+	case MatchTxEmpty:
+		return RefundTxEmpty
+	case MatchTxNotStable:
+		return RefundTxNotStable
+	case MatchTxStable:
+		return RefundTxStable
+	case MatchTxFailed:
+		return RefundTxFailed
+	default:
+		return status
+	}
+}
+
+// IsFailedStatus is swap or refund tx failed on chain
+func (status SwapStatus) IsFailedStatus() bool {
+	switch status {
+	case MatchTxFailed, RefundTxFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsReverifiable is verify failed status which can be verified again
+func (status SwapStatus) IsReverifiable() bool {
+	switch status {
+	case TxVerifyFailed, MissTokenConfig, NoUnderlyingToken:
 		return true
 	default:
 		return false
@@ -105,6 +190,24 @@ func (status SwapStatus) String() string {
 		return "MissTokenConfig"
 	case NoUnderlyingToken:
 		return "NoUnderlyingToken"
+	case BigValueCanceled:
+		return "BigValueCanceled"
+	case SwapInScreeningReview:
+		return "SwapInScreeningReview"
+	case MatchTxCanceled:
+		return "MatchTxCanceled"
+	case TxRefundApproved:
+		return "TxRefundApproved"
+	case TxRefunded:
+		return "TxRefunded"
+	case RefundTxEmpty:
+		return "RefundTxEmpty"
+	case RefundTxNotStable:
+		return "RefundTxNotStable"
+	case RefundTxStable:
+		return "RefundTxStable"
+	case RefundTxFailed:
+		return "RefundTxFailed"
 
 	case KeepStatus:
 		return "KeepStatus"
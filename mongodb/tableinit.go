@@ -8,12 +8,36 @@ const (
 	tbRouterSwaps       string = "RouterSwaps"
 	tbRouterSwapResults string = "RouterSwapResults"
 	tbUsedRValues       string = "UsedRValues"
+	tbSignedTxs         string = "SignedTxs"
+	tbReplaceDecisions  string = "ReplaceDecisions"
+	tbSyncedCursors     string = "SyncedCursors"
+	tbDestTags          string = "DestTags"
+	tbAnyCallFees       string = "AnyCallFees"
+	tbAnyCallLedgers    string = "AnyCallLedgers"
+	tbChainLeases       string = "ChainLeases"
+	tbClusterNodes      string = "ClusterNodes"
+	tbDrainStates       string = "DrainStates"
+	tbSwapVolumeRollups string = "SwapVolumeRollups"
+	tbSwapStatusRollups string = "SwapStatusRollups"
+	tbSwapSenderRollups string = "SwapSenderRollups"
 )
 
 var (
 	collRouterSwap       *mongo.Collection
 	collRouterSwapResult *mongo.Collection
 	collUsedRValue       *mongo.Collection
+	collSignedTx         *mongo.Collection
+	collReplaceDecision  *mongo.Collection
+	collSyncedCursor     *mongo.Collection
+	collDestTag          *mongo.Collection
+	collAnyCallFee       *mongo.Collection
+	collAnyCallLedger    *mongo.Collection
+	collChainLease       *mongo.Collection
+	collClusterNode      *mongo.Collection
+	collDrainState       *mongo.Collection
+	collSwapVolumeRollup *mongo.Collection
+	collSwapStatusRollup *mongo.Collection
+	collSwapSenderRollup *mongo.Collection
 )
 
 func initCollections() {
@@ -22,4 +46,16 @@ func initCollections() {
 	collRouterSwap = database.Collection(tbRouterSwaps)
 	collRouterSwapResult = database.Collection(tbRouterSwapResults)
 	collUsedRValue = database.Collection(tbUsedRValues)
+	collSignedTx = database.Collection(tbSignedTxs)
+	collReplaceDecision = database.Collection(tbReplaceDecisions)
+	collSyncedCursor = database.Collection(tbSyncedCursors)
+	collDestTag = database.Collection(tbDestTags)
+	collAnyCallFee = database.Collection(tbAnyCallFees)
+	collAnyCallLedger = database.Collection(tbAnyCallLedgers)
+	collChainLease = database.Collection(tbChainLeases)
+	collClusterNode = database.Collection(tbClusterNodes)
+	collDrainState = database.Collection(tbDrainStates)
+	collSwapVolumeRollup = database.Collection(tbSwapVolumeRollups)
+	collSwapStatusRollup = database.Collection(tbSwapStatusRollups)
+	collSwapSenderRollup = database.Collection(tbSwapSenderRollups)
 }
@@ -1,5 +1,13 @@
 package mongodb
 
+import (
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
 // MgoSwap registered swap
 type MgoSwap struct {
 	Key         string `bson:"_id"` // fromChainID + txid + logindex
@@ -17,6 +25,14 @@ type MgoSwap struct {
 	InitTime    int64      `bson:"inittime"`
 	Timestamp   int64      `bson:"timestamp"`
 	Memo        string     `bson:"memo"`
+	ErrCode     int        `bson:"errcode,omitempty"`
+
+	ReverifyCount    int   `bson:"reverifycount,omitempty"`
+	NextReverifyTime int64 `bson:"nextreverifytime,omitempty"`
+
+	BigValueHeld bool `bson:"bigvalueheld,omitempty"`
+
+	ScreeningApproved bool `bson:"screeningapproved,omitempty"`
 }
 
 // ToSwapResult converts
@@ -37,6 +53,7 @@ func (swap *MgoSwap) ToSwapResult() *MgoSwapResult {
 		InitTime:    swap.InitTime,
 		Timestamp:   swap.Timestamp,
 		Memo:        swap.Memo,
+		ErrCode:     swap.ErrCode,
 	}
 }
 
@@ -66,7 +83,34 @@ type MgoSwapResult struct {
 	InitTime    int64      `bson:"inittime"`
 	Timestamp   int64      `bson:"timestamp"`
 	Memo        string     `bson:"memo"`
+	ErrCode     int        `bson:"errcode,omitempty"`
 	MPC         string     `bson:"mpc"`
+	CancelTx    string     `bson:"canceltx,omitempty" json:"canceltx,omitempty"`
+
+	LeaseOwner string `bson:"leaseowner,omitempty" json:"-"` // chain lease owner of the last swap write
+	LeaseToken uint64 `bson:"leasetoken,omitempty" json:"-"` // chain lease token of the last swap write
+
+	AnyCallAttempts []*AnyCallAttempt `bson:"anycallattempts,omitempty"`
+	FallbackSwap    string            `bson:"fallbackswap,omitempty"`
+}
+
+// anycall failure actions
+const (
+	AnyCallActionRetry    = "retry"
+	AnyCallActionFallback = "fallback"
+	AnyCallActionGiveUp   = "giveup"
+)
+
+// AnyCallAttempt onchain failed anycall execution attempt
+type AnyCallAttempt struct {
+	SwapTx       string `bson:"swaptx"                 json:"swaptx"`
+	SwapNonce    uint64 `bson:"swapnonce"              json:"swapnonce"`
+	GasLimit     uint64 `bson:"gasLimit"               json:"gasLimit"`
+	GasUsed      uint64 `bson:"gasUsed"                json:"gasUsed"`
+	Reason       string `bson:"reason,omitempty"       json:"reason,omitempty"`
+	Action       string `bson:"action"                 json:"action"`
+	NextGasLimit uint64 `bson:"nextGasLimit,omitempty" json:"nextGasLimit,omitempty"`
+	Timestamp    int64  `bson:"timestamp"              json:"timestamp"`
 }
 
 // MgoUsedRValue security enhancement
@@ -75,6 +119,59 @@ type MgoUsedRValue struct {
 	Timestamp int64  `bson:"timestamp"`
 }
 
+// MgoSignedTx signed swap tx (used to rebroadcast and trace)
+type MgoSignedTx struct {
+	Key         string `bson:"_id"     json:"-"` // toChainID + swaptx
+	SwapKey     string `bson:"swapkey" json:"-"` // fromChainID + txid + logindex
+	FromChainID string `bson:"fromChainID" json:"fromChainID"`
+	ToChainID   string `bson:"toChainID"   json:"toChainID"`
+	TxID        string `bson:"txid"        json:"txid"`
+	LogIndex    int    `bson:"logIndex"    json:"logIndex"`
+	SwapTx      string `bson:"swaptx"      json:"swaptx"`
+	SwapNonce   uint64 `bson:"swapnonce"   json:"swapnonce"`
+	ReplaceNum  uint64 `bson:"replacenum"  json:"replacenum"`
+	MPC         string `bson:"mpc"         json:"mpc"`
+	KeyID       string `bson:"keyid"       json:"keyid"`
+	RawTx       string `bson:"rawtx"       json:"rawtx"`
+	Gas         uint64 `bson:"gas,omitempty"       json:"gas,omitempty"`
+	GasPrice    string `bson:"gasprice,omitempty"  json:"gasprice,omitempty"`
+	GasTipCap   string `bson:"gastipcap,omitempty" json:"gastipcap,omitempty"`
+	GasFeeCap   string `bson:"gasfeecap,omitempty" json:"gasfeecap,omitempty"`
+	Rebroadcast int    `bson:"rebroadcast" json:"rebroadcast"`
+	InitTime    int64  `bson:"inittime"    json:"inittime"`
+	Timestamp   int64  `bson:"timestamp"   json:"timestamp"`
+}
+
+// replace decision actions
+const (
+	ReplaceActionReplace = "replace"
+	ReplaceActionCancel  = "cancel"
+	ReplaceActionGiveUp  = "giveup"
+)
+
+// MgoReplaceDecision replace decision record
+type MgoReplaceDecision struct {
+	SwapKey     string `bson:"swapkey"     json:"-"` // fromChainID + txid + logindex
+	FromChainID string `bson:"fromChainID" json:"fromChainID"`
+	ToChainID   string `bson:"toChainID"   json:"toChainID"`
+	TxID        string `bson:"txid"        json:"txid"`
+	LogIndex    int    `bson:"logIndex"    json:"logIndex"`
+	Action      string `bson:"action"      json:"action"`
+	Mode        string `bson:"mode"        json:"mode"`
+	IsManual    bool   `bson:"isManual"    json:"isManual"`
+	SwapNonce   uint64 `bson:"swapnonce"   json:"swapnonce"`
+	ReplaceNum  uint64 `bson:"replacenum"  json:"replacenum"`
+	PlusPercent uint64 `bson:"pluspercent" json:"pluspercent"`
+	OldSwapTx   string `bson:"oldswaptx"   json:"oldswaptx"`
+	NewSwapTx   string `bson:"newswaptx,omitempty" json:"newswaptx,omitempty"`
+	GasPrice    string `bson:"gasprice,omitempty"  json:"gasprice,omitempty"`
+	GasTipCap   string `bson:"gastipcap,omitempty" json:"gastipcap,omitempty"`
+	GasFeeCap   string `bson:"gasfeecap,omitempty" json:"gasfeecap,omitempty"`
+	Reason      string `bson:"reason,omitempty"    json:"reason,omitempty"`
+	Error       string `bson:"error,omitempty"     json:"error,omitempty"`
+	Timestamp   int64  `bson:"timestamp"   json:"timestamp"`
+}
+
 // SwapResultUpdateItems swap update items
 type SwapResultUpdateItems struct {
 	MPC        string
@@ -86,6 +183,8 @@ type SwapResultUpdateItems struct {
 	Status     SwapStatus
 	Timestamp  int64
 	Memo       string
+	ErrCode    int                // error code of memo
+	Lease      *tokens.ChainLease // fence the update by chain lease if not nil
 }
 
 // SwapInfo struct
@@ -104,6 +203,8 @@ type ERC20SwapInfo struct {
 	ForUnderlying bool     `bson:"forUnderlying,omitempty" json:"forUnderlying,omitempty"`
 	Path          []string `bson:"path,omitempty"          json:"path,omitempty"`
 	AmountOutMin  string   `bson:"amountOutMin,omitempty"  json:"amountOutMin,omitempty"`
+	PoolFees      []uint32 `bson:"poolFees,omitempty" json:"poolFees,omitempty"`
+	Executor      string   `bson:"executor,omitempty" json:"executor,omitempty"`
 	CallProxy     string   `bson:"callProxy,omitempty"     json:"callProxy,omitempty"`
 	CallData      string   `bson:"callData,omitempty"     json:"callData,omitempty"`
 }
@@ -116,6 +217,15 @@ type NFTSwapInfo struct {
 	Amounts []string `bson:"amounts"        json:"amounts"`
 	Batch   bool     `bson:"batch"          json:"batch"`
 	Data    string   `bson:"data,omitempty" json:"data,omitempty"`
+
+	Metadatas []*NFTMetadata `bson:"metadatas,omitempty" json:"metadatas,omitempty"`
+}
+
+// NFTMetadata struct
+type NFTMetadata struct {
+	URI             string `bson:"uri"                       json:"uri"`
+	RoyaltyReceiver string `bson:"royaltyReceiver,omitempty" json:"royaltyReceiver,omitempty"`
+	RoyaltyBps      uint64 `bson:"royaltyBps,omitempty"      json:"royaltyBps,omitempty"`
 }
 
 // AnyCallSwapInfo struct
@@ -156,3 +266,146 @@ func (s *SwapInfo) GetTokenID() string {
 	}
 	return ""
 }
+
+// MgoSyncedCursor synced cursor (eg. ledger index of subscribed txs)
+type MgoSyncedCursor struct {
+	Key       string `bson:"_id"` // chainID + name
+	ChainID   string `bson:"chainID"   json:"chainID"`
+	Name      string `bson:"name"      json:"name"`
+	Cursor    uint64 `bson:"cursor"    json:"cursor"`
+	Timestamp int64  `bson:"timestamp" json:"timestamp"`
+}
+
+// MgoChainLease lease of processing swaps of chain (for running multiple servers)
+type MgoChainLease struct {
+	Key       string `bson:"_id"       json:"-"` // chainID
+	ChainID   string `bson:"chainID"   json:"chainID"`
+	Owner     string `bson:"owner"     json:"owner"`
+	Token     uint64 `bson:"token"     json:"token"` // fencing token, increased when owner changed
+	ExpireAt  int64  `bson:"expireAt"  json:"expireAt"`
+	Timestamp int64  `bson:"timestamp" json:"timestamp"`
+}
+
+// MgoClusterNode heartbeat of server node in cluster
+type MgoClusterNode struct {
+	Key       string `bson:"_id"       json:"nodeID"` // node ID
+	ExpireAt  int64  `bson:"expireAt"  json:"expireAt"`
+	Timestamp int64  `bson:"timestamp" json:"timestamp"`
+}
+
+// MgoDrainState persisted drain state of server
+type MgoDrainState struct {
+	Key       string   `bson:"_id"       json:"-"` // server identifier (and cluster node ID)
+	Global    bool     `bson:"global"    json:"global"`
+	ChainIDs  []string `bson:"chainIDs"  json:"chainIDs"`
+	StartTime int64    `bson:"startTime" json:"startTime"`
+	Deadline  int64    `bson:"deadline"  json:"deadline"`
+	Timestamp int64    `bson:"timestamp" json:"timestamp"`
+}
+
+// SwapRollupDayLayout day layout of swap rollups (in UTC)
+const SwapRollupDayLayout = "2006-01-02"
+
+// MgoSwapVolumeRollup daily swap rollup of token and chain pair
+type MgoSwapVolumeRollup struct {
+	Key           string  `bson:"_id"           json:"-"`   // day + tokenID + fromChainID + toChainID
+	Day           string  `bson:"day"           json:"day"` // yyyy-mm-dd in UTC
+	TokenID       string  `bson:"tokenID"       json:"tokenID"`
+	FromChainID   string  `bson:"fromChainID"   json:"fromChainID"`
+	ToChainID     string  `bson:"toChainID"     json:"toChainID"`
+	SwapCount     int64   `bson:"swapCount"     json:"swapCount"`
+	SuccessCount  int64   `bson:"successCount"  json:"successCount"`
+	FailedCount   int64   `bson:"failedCount"   json:"failedCount"`
+	Volume        float64 `bson:"volume"        json:"volume"` // normalized by decimals
+	ReplaceCount  int64   `bson:"replaceCount"  json:"replaceCount"`
+	LatencyMedian int64   `bson:"latencyMedian" json:"latencyMedian"` // seconds from init to swap
+	LatencyP95    int64   `bson:"latencyP95"    json:"latencyP95"`    // seconds from init to swap
+	Timestamp     int64   `bson:"timestamp"     json:"timestamp"`
+}
+
+// MgoSwapStatusRollup daily swap rollup of status
+type MgoSwapStatusRollup struct {
+	Key       string     `bson:"_id"       json:"-"` // day + status
+	Day       string     `bson:"day"       json:"day"`
+	Status    SwapStatus `bson:"status"    json:"status"`
+	Count     int64      `bson:"count"     json:"count"`
+	Timestamp int64      `bson:"timestamp" json:"timestamp"`
+}
+
+// MgoSwapSenderRollup daily swap rollup of token and sender
+type MgoSwapSenderRollup struct {
+	Key       string  `bson:"_id"       json:"-"` // day + tokenID + from
+	Day       string  `bson:"day"       json:"day,omitempty"`
+	TokenID   string  `bson:"tokenID"   json:"tokenID"`
+	From      string  `bson:"from"      json:"from"`
+	SwapCount int64   `bson:"swapCount" json:"swapCount"`
+	Volume    float64 `bson:"volume"    json:"volume"`
+	Timestamp int64   `bson:"timestamp" json:"timestamp"`
+}
+
+// MgoDestTag destination tag route (eg. ripple destination tag)
+type MgoDestTag struct {
+	Key       string `bson:"_id"       json:"-"` // chainID + tag
+	ChainID   string `bson:"chainID"   json:"chainID"`
+	Tag       uint64 `bson:"tag"       json:"tag"`
+	Bind      string `bson:"bind"      json:"bind"`
+	ToChainID string `bson:"toChainID" json:"toChainID"`
+	Timestamp int64  `bson:"timestamp" json:"timestamp"`
+	Used      bool   `bson:"used"      json:"used"`             // immutable once used
+	UsedTx    string `bson:"usedTx"    json:"usedTx,omitempty"` // first deposit tx
+}
+
+// MgoAnyCallFee execution fee of anycall swap tx
+type MgoAnyCallFee struct {
+	Key         string `bson:"_id"         json:"-"` // toChainID + swaptx
+	SwapKey     string `bson:"swapkey"     json:"-"` // fromChainID + txid + logindex
+	FromChainID string `bson:"fromChainID" json:"fromChainID"`
+	ToChainID   string `bson:"toChainID"   json:"toChainID"`
+	TxID        string `bson:"txid"        json:"txid"`
+	LogIndex    int    `bson:"logIndex"    json:"logIndex"`
+	SwapTx      string `bson:"swaptx"      json:"swaptx"`
+	CallFrom    string `bson:"callFrom"    json:"callFrom"`
+	DApp        string `bson:"dapp"        json:"dapp"`
+	GasUsed     uint64 `bson:"gasUsed"     json:"gasUsed"`
+	GasPrice    string `bson:"gasPrice"    json:"gasPrice"`
+	Fee         string `bson:"fee"         json:"fee"`
+	Timestamp   int64  `bson:"timestamp"   json:"timestamp"`
+}
+
+// anycall ledger kinds
+const (
+	AnyCallLedgerCallFrom = "callfrom"
+	AnyCallLedgerDApp     = "dapp"
+)
+
+// MgoAnyCallLedger anycall prepaid budget and usage of call from or dapp on chain
+type MgoAnyCallLedger struct {
+	Key       string               `bson:"_id"       json:"-"` // chainID + kind + address
+	ChainID   string               `bson:"chainID"   json:"chainID"`
+	Kind      string               `bson:"kind"      json:"kind"`
+	Address   string               `bson:"address"   json:"address"`
+	Deposited primitive.Decimal128 `bson:"deposited" json:"deposited"`
+	Used      primitive.Decimal128 `bson:"used"      json:"used"`
+	GasUsed   uint64               `bson:"gasUsed"   json:"gasUsed"`
+	TxCount   uint64               `bson:"txCount"   json:"txCount"`
+	Timestamp int64                `bson:"timestamp" json:"timestamp"`
+}
+
+// GetRemaining get remaining budget (deposited minus used)
+func (l *MgoAnyCallLedger) GetRemaining() *big.Int {
+	deposited := decimal128ToBigInt(l.Deposited)
+	return deposited.Sub(deposited, decimal128ToBigInt(l.Used))
+}
+
+func decimal128ToBigInt(d primitive.Decimal128) *big.Int {
+	bi, exp, err := d.BigInt()
+	if err != nil {
+		return big.NewInt(0)
+	}
+	if exp > 0 {
+		bi.Mul(bi, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+	} else if exp < 0 {
+		bi.Quo(bi, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil))
+	}
+	return bi
+}
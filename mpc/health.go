@@ -0,0 +1,174 @@
+package mpc
+
+import (
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+)
+
+// sign group enodes are queried from mpc node in background, so that
+// health queries never trigger rpc calls to mpc node.
+const signGroupEnodesRefreshInterval = 10 * time.Minute
+
+// SignGroupHealth sign group health info
+type SignGroupHealth struct {
+	SignGroup           string
+	Usable              bool
+	SuccessCount        uint64
+	FailureCount        uint64
+	ConsecutiveFailures int
+	LastSuccessTime     int64    `json:",omitempty"`
+	LastFailureTime     int64    `json:",omitempty"`
+	LastFailureReason   string   `json:",omitempty"`
+	RemovedTime         int64    `json:",omitempty"`
+	ReaddedTime         int64    `json:",omitempty"`
+	AverageLatency      int64    // milliseconds
+	Enodes              []string `json:",omitempty"`
+}
+
+// InitiatorHealth initiator node health info
+type InitiatorHealth struct {
+	MPCUser    string
+	SignGroups []*SignGroupHealth
+}
+
+type signGroupStat struct {
+	successCount      uint64
+	failureCount      uint64
+	consecutiveFails  int
+	lastSuccessTime   int64
+	lastFailureTime   int64
+	lastFailureReason string
+	removedTime       int64
+	readdedTime       int64
+	totalLatency      time.Duration
+	enodes            []string
+}
+
+type signGroupStats struct {
+	stats map[string]*signGroupStat // key is sign group
+	lock  sync.RWMutex
+}
+
+func (s *signGroupStats) get(signGroup string) *signGroupStat {
+	if s.stats == nil {
+		s.stats = make(map[string]*signGroupStat)
+	}
+	stat, exist := s.stats[signGroup]
+	if !exist {
+		stat = &signGroupStat{}
+		s.stats[signGroup] = stat
+	}
+	return stat
+}
+
+func (s *signGroupStats) addSuccess(signGroup string, latency time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stat := s.get(signGroup)
+	stat.successCount++
+	stat.consecutiveFails = 0
+	stat.lastSuccessTime = time.Now().Unix()
+	stat.totalLatency += latency
+}
+
+func (s *signGroupStats) addFailure(signGroup string, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stat := s.get(signGroup)
+	stat.failureCount++
+	stat.consecutiveFails++
+	stat.lastFailureTime = time.Now().Unix()
+	if err != nil {
+		stat.lastFailureReason = err.Error()
+	}
+}
+
+func (s *signGroupStats) markRemoved(signGroup string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.get(signGroup).removedTime = time.Now().Unix()
+}
+
+func (s *signGroupStats) markReadded(signGroup string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	stat := s.get(signGroup)
+	stat.consecutiveFails = 0
+	stat.readdedTime = time.Now().Unix()
+}
+
+func (s *signGroupStats) setEnodes(signGroup string, enodes []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.get(signGroup).enodes = enodes
+}
+
+// GetSignGroupsHealth get sign groups health of all initiator nodes
+func (c *Config) GetSignGroupsHealth(withEnodes bool) []*InitiatorHealth {
+	result := make([]*InitiatorHealth, 0, len(c.allInitiatorNodes))
+	for _, node := range c.allInitiatorNodes {
+		result = append(result, node.getSignGroupsHealth(withEnodes))
+	}
+	return result
+}
+
+func (ni *NodeInfo) getSignGroupsHealth(withEnodes bool) *InitiatorHealth {
+	usableIndexes := ni.getUsableSignGroupIndexes()
+	isUsable := func(index int) bool {
+		for _, groupInd := range usableIndexes {
+			if groupInd == index {
+				return true
+			}
+		}
+		return false
+	}
+
+	health := &InitiatorHealth{
+		MPCUser:    ni.mpcUser.String(),
+		SignGroups: make([]*SignGroupHealth, 0, len(ni.originSignGroups)),
+	}
+
+	ni.groupStats.lock.RLock()
+	for i, signGroup := range ni.originSignGroups {
+		item := &SignGroupHealth{
+			SignGroup: signGroup,
+			Usable:    isUsable(i),
+		}
+		if stat, exist := ni.groupStats.stats[signGroup]; exist {
+			item.SuccessCount = stat.successCount
+			item.FailureCount = stat.failureCount
+			item.ConsecutiveFailures = stat.consecutiveFails
+			item.LastSuccessTime = stat.lastSuccessTime
+			item.LastFailureTime = stat.lastFailureTime
+			item.LastFailureReason = stat.lastFailureReason
+			item.RemovedTime = stat.removedTime
+			item.ReaddedTime = stat.readdedTime
+			if stat.successCount > 0 {
+				item.AverageLatency = stat.totalLatency.Milliseconds() / int64(stat.successCount)
+			}
+			if withEnodes {
+				item.Enodes = stat.enodes
+			}
+		}
+		health.SignGroups = append(health.SignGroups, item)
+	}
+	ni.groupStats.lock.RUnlock()
+
+	return health
+}
+
+func (ni *NodeInfo) loopRefreshSignGroupEnodes() {
+	for {
+		for _, signGroup := range ni.originSignGroups {
+			groupInfo, err := ni.parent.GetGroupByID(signGroup, ni.mpcRPCAddress)
+			if err != nil || groupInfo == nil {
+				log.Warn("refresh sign group enodes failed", "signGroup", signGroup, "err", err)
+				continue
+			}
+			ni.groupStats.setEnodes(signGroup, groupInfo.Enodes)
+		}
+		time.Sleep(signGroupEnodesRefreshInterval)
+	}
+}
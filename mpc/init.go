@@ -112,6 +112,7 @@ type NodeInfo struct {
 	usableSignGroupIndexes []int    // usable sign groups indexes
 
 	signGroupsLock sync.RWMutex
+	groupStats     signGroupStats
 
 	parent *Config
 }
@@ -219,6 +220,7 @@ func (c *Config) addInitiatorNode(nodeInfo *NodeInfo) {
 		}
 	}
 	c.allInitiatorNodes = append(c.allInitiatorNodes, nodeInfo)
+	go nodeInfo.loopRefreshSignGroupEnodes()
 }
 
 // IsSwapServer returns if this mpc user is the swap server
@@ -299,6 +301,7 @@ func (ni *NodeInfo) deleteSignGroup(groupIndex int) {
 	for i, groupInd := range ni.usableSignGroupIndexes {
 		if groupInd == groupIndex {
 			ni.usableSignGroupIndexes = append(ni.usableSignGroupIndexes[:i], ni.usableSignGroupIndexes[i+1:]...)
+			ni.groupStats.markRemoved(ni.originSignGroups[groupIndex])
 			return
 		}
 	}
@@ -333,6 +336,7 @@ func (ni *NodeInfo) checkAndAddSignGroups() {
 			}
 			log.Info("check and add sign group", "signGroup", signGroup)
 			ni.usableSignGroupIndexes = append(ni.usableSignGroupIndexes, i)
+			ni.groupStats.markReadded(signGroup)
 			// reset when readd
 			ni.parent.signGroupFailuresMap[signGroup] = signFailures{
 				count:    0,
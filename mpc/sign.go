@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -135,14 +136,17 @@ func (c *Config) doSignImpl(mpcNode *NodeInfo, signGroupIndex int, signType, sig
 		return "", nil, err
 	}
 
+	startTime := time.Now()
 	rpcAddr := mpcNode.mpcRPCAddress
 	keyID, err = c.Sign(rawTX, rpcAddr)
 	if err != nil {
+		mpcNode.groupStats.addFailure(signGroup, err)
 		return "", nil, err
 	}
 
 	rsvs, err = c.getSignResult(keyID, rpcAddr)
 	if err != nil {
+		mpcNode.groupStats.addFailure(signGroup, err)
 		if c.maxSignGroupFailures > 0 {
 			old := c.signGroupFailuresMap[signGroup]
 			c.signGroupFailuresMap[signGroup] = signFailures{
@@ -156,6 +160,7 @@ func (c *Config) doSignImpl(mpcNode *NodeInfo, signGroupIndex int, signType, sig
 		}
 		return "", nil, err
 	}
+	mpcNode.groupStats.addSuccess(signGroup, time.Since(startTime))
 	if c.maxSignGroupFailures > 0 {
 		// reset when succeed
 		c.signGroupFailuresMap[signGroup] = signFailures{
@@ -216,6 +221,9 @@ LOOP_GET_SIGN_STATUS:
 	}
 	if len(rsvs) == 0 || err != nil {
 		log.Info("get sign status failed", "keyID", keyID, "retryCount", i, "err", err)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errGetSignResultFailed, err)
+		}
 		return nil, errGetSignResultFailed
 	}
 	log.Info("get sign status success", "keyID", keyID, "retryCount", i)
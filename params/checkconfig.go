@@ -4,7 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -43,6 +45,11 @@ func (config *RouterConfig) CheckConfig(isServer bool) (err error) {
 	}
 	log.Info("check identifier pass", "identifier", config.Identifier, "swaptype", config.SwapType, "isServer", isServer)
 
+	err = config.CheckSwapTypesConfig()
+	if err != nil {
+		return err
+	}
+
 	err = config.CheckBlacklistConfig()
 	if err != nil {
 		return err
@@ -65,6 +72,18 @@ func (config *RouterConfig) CheckConfig(isServer bool) (err error) {
 		return err
 	}
 
+	if config.Tracing != nil {
+		err = config.Tracing.CheckConfig()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = config.CheckLogModuleLevels()
+	if err != nil {
+		return err
+	}
+
 	if config.MPC == nil {
 		return errors.New("server must config 'MPC'")
 	}
@@ -91,6 +110,82 @@ func (config *RouterConfig) CheckConfig(isServer bool) (err error) {
 	return nil
 }
 
+// CheckConfig check tracing config
+func (c *TracingConfig) CheckConfig() error {
+	if !c.Enable {
+		return nil
+	}
+	if c.Endpoint == "" {
+		return errors.New("tracing is enabled but has empty endpoint")
+	}
+	log.Info("check tracing config success", "endpoint", c.Endpoint, "serviceName", c.GetServiceName())
+	return nil
+}
+
+// CheckLogModuleLevels check and set log levels of modules
+func (config *RouterConfig) CheckLogModuleLevels() error {
+	for module, levelStr := range config.LogModuleLevels {
+		level, err := log.ParseLevel(levelStr)
+		if err != nil {
+			return fmt.Errorf("wrong log level '%v' of module '%v'", levelStr, module)
+		}
+		log.SetModuleLevel(module, level)
+	}
+	return nil
+}
+
+// CheckSwapTypesConfig check additional swap types config
+func (config *RouterConfig) CheckSwapTypesConfig() error {
+	if len(config.SwapTypes) == 0 {
+		return nil
+	}
+	swapTypes := make(map[string]*SwapTypeConfig, len(config.SwapTypes))
+	identifiers := map[string]string{config.Identifier: config.SwapType}
+	tokenIDs := make(map[string]string)
+	for swapType, c := range config.SwapTypes {
+		swapType = strings.ToLower(swapType)
+		switch swapType {
+		case "erc20swap", "nftswap", "anycallswap":
+		default:
+			return fmt.Errorf("invalid swap type '%v' in 'SwapTypes'", swapType)
+		}
+		if strings.EqualFold(swapType, config.SwapType) {
+			return fmt.Errorf("swap type '%v' in 'SwapTypes' is the main swap type", swapType)
+		}
+		if _, exist := swapTypes[swapType]; exist {
+			return fmt.Errorf("duplicate swap type '%v' in 'SwapTypes'", swapType)
+		}
+		if c == nil {
+			return fmt.Errorf("swap type '%v' has empty config", swapType)
+		}
+		if !strings.HasPrefix(c.Identifier, RouterSwapPrefixID) || c.Identifier == RouterSwapPrefixID {
+			return fmt.Errorf("swap type '%v' has wrong identifier '%v', missing prefix '%v'", swapType, c.Identifier, RouterSwapPrefixID)
+		}
+		if other, exist := identifiers[c.Identifier]; exist {
+			return fmt.Errorf("swap type '%v' has same identifier '%v' with swap type '%v'", swapType, c.Identifier, other)
+		}
+		identifiers[c.Identifier] = swapType
+		for chainID := range c.RouterContracts {
+			if _, err := common.GetBigIntFromStr(chainID); err != nil {
+				return fmt.Errorf("swap type '%v' has wrong chain id '%v' in 'RouterContracts'", swapType, chainID)
+			}
+		}
+		c.tokenIDs = make(map[string]struct{}, len(c.TokenIDs))
+		for _, tokenID := range c.TokenIDs {
+			key := strings.ToLower(tokenID)
+			if other, exist := tokenIDs[key]; exist {
+				return fmt.Errorf("token id '%v' is listed in both swap type '%v' and '%v'", tokenID, swapType, other)
+			}
+			tokenIDs[key] = swapType
+			c.tokenIDs[key] = struct{}{}
+		}
+		swapTypes[swapType] = c
+		log.Info("check swap type config pass", "swaptype", swapType, "identifier", c.Identifier, "subtype", c.SwapSubType, "tokenIDs", c.TokenIDs)
+	}
+	config.SwapTypes = swapTypes
+	return nil
+}
+
 // CheckBlacklistConfig check black list config
 func (config *RouterConfig) CheckBlacklistConfig() (err error) {
 	for _, chainID := range config.ChainIDBlackList {
@@ -219,10 +314,46 @@ func (s *RouterServerConfig) CheckConfig() error {
 	if err != nil {
 		return err
 	}
+	err = s.CheckLowWaterMarks()
+	if err != nil {
+		return err
+	}
+	err = s.CheckBigValueTimelocks()
+	if err != nil {
+		return err
+	}
+	if s.Screening != nil {
+		err = s.Screening.CheckConfig()
+		if err != nil {
+			return err
+		}
+	}
+	if s.Cluster != nil {
+		err = s.Cluster.CheckConfig()
+		if err != nil {
+			return err
+		}
+	}
+	if s.SwapPriority != nil {
+		err = s.SwapPriority.CheckConfig()
+		if err != nil {
+			return err
+		}
+	}
+	if s.TokenAudit != nil {
+		err = s.TokenAudit.CheckConfig()
+		if err != nil {
+			return err
+		}
+	}
 	err = s.CheckExtra()
 	if err != nil {
 		return err
 	}
+	err = s.CheckReplaceStrategy()
+	if err != nil {
+		return err
+	}
 	log.Info("check server config success",
 		"defaultGasLimit", s.DefaultGasLimit,
 		"maxGasLimit", s.MaxGasLimit,
@@ -234,6 +365,135 @@ func (s *RouterServerConfig) CheckConfig() error {
 	return nil
 }
 
+// CheckConfig check screening config
+func (c *ScreeningConfig) CheckConfig() error {
+	switch c.Provider {
+	case ScreeningProviderHTTP:
+		if c.APIAddress == "" {
+			return errors.New("screening: empty 'APIAddress' of http provider")
+		}
+	case ScreeningProviderFile:
+		if c.FilePath == "" {
+			return errors.New("screening: empty 'FilePath' of file provider")
+		}
+	default:
+		return fmt.Errorf("screening: unknown provider '%v'", c.Provider)
+	}
+	if c.Timeout < 0 || c.CacheTTL < 0 {
+		return errors.New("screening: negative 'Timeout' or 'CacheTTL'")
+	}
+	return nil
+}
+
+// CheckConfig check swap priority config
+func (c *SwapPriorityConfig) CheckConfig() error {
+	if c.HighValueUSD < 0 || c.LowValueUSD < 0 {
+		return errors.New("swap priority: negative 'HighValueUSD' or 'LowValueUSD'")
+	}
+	if c.HighValueUSD > 0 && c.LowValueUSD > c.HighValueUSD {
+		return errors.New("swap priority: 'LowValueUSD' is greater than 'HighValueUSD'")
+	}
+	for tokenID, price := range c.TokenPriceUSD {
+		if price < 0 {
+			return fmt.Errorf("swap priority: negative price of token '%v'", tokenID)
+		}
+	}
+	for swapType, priority := range c.SwapTypePriority {
+		switch priority {
+		case SwapPriorityHigh, SwapPriorityNormal, SwapPriorityLow:
+		default:
+			return fmt.Errorf("swap priority: unknown priority '%v' of swap type '%v'", priority, swapType)
+		}
+	}
+	if c.HighPriorityAge < 0 || c.StarvationLimit < 0 || c.MaxWaitTime < 0 {
+		return errors.New("swap priority: negative 'HighPriorityAge', 'StarvationLimit' or 'MaxWaitTime'")
+	}
+	c.whitelistSenders = make(map[string]struct{}, len(c.WhitelistSenders))
+	for _, sender := range c.WhitelistSenders {
+		c.whitelistSenders[strings.ToLower(sender)] = struct{}{}
+	}
+	return nil
+}
+
+// CheckConfig check cluster config
+func (c *ClusterConfig) CheckConfig() error {
+	if c.LeaseTTL < 0 {
+		return errors.New("cluster: negative 'LeaseTTL'")
+	}
+	if c.NodeID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return fmt.Errorf("cluster: get hostname failed: %w", err)
+		}
+		c.NodeID = fmt.Sprintf("%v:%v", hostname, os.Getpid())
+	}
+	return nil
+}
+
+// CheckConfig check token audit config
+func (c *TokenAuditConfig) CheckConfig() error {
+	if c.Interval < 0 {
+		return errors.New("token audit: negative 'Interval'")
+	}
+	if c.SupplyTolerancePercent < 0 || c.SupplyTolerancePercent >= 100 {
+		return errors.New("token audit: 'SupplyTolerancePercent' is not in range [0, 100)")
+	}
+	c.ignoreTokenIDs = make(map[string]struct{}, len(c.IgnoreTokenIDs))
+	for _, tokenID := range c.IgnoreTokenIDs {
+		c.ignoreTokenIDs[strings.ToLower(tokenID)] = struct{}{}
+	}
+	return nil
+}
+
+// CheckBigValueTimelocks check big value timelocks
+func (s *RouterServerConfig) CheckBigValueTimelocks() error {
+	percents := make(map[uint64]struct{}, len(s.BigValueTimelocks))
+	for _, c := range s.BigValueTimelocks {
+		if c == nil || c.ThresholdPercent < 100 {
+			return errors.New("'ThresholdPercent' of big value timelock must be at least 100")
+		}
+		if c.Delay < 0 {
+			return errors.New("negative 'Delay' of big value timelock")
+		}
+		if _, exist := percents[c.ThresholdPercent]; exist {
+			return fmt.Errorf("duplicate 'ThresholdPercent' %v of big value timelock", c.ThresholdPercent)
+		}
+		percents[c.ThresholdPercent] = struct{}{}
+	}
+	sort.Slice(s.BigValueTimelocks, func(i, j int) bool {
+		return s.BigValueTimelocks[i].ThresholdPercent > s.BigValueTimelocks[j].ThresholdPercent
+	})
+	return nil
+}
+
+// CheckLowWaterMarks check low water marks of liquidity monitor
+func (s *RouterServerConfig) CheckLowWaterMarks() error {
+	if s.LiquidityMonitorInterval < 0 {
+		return errors.New("negative 'LiquidityMonitorInterval'")
+	}
+	for chainID, markStr := range s.NativeLowWaterMark {
+		mark, err := common.GetBigIntFromStr(markStr)
+		if err != nil {
+			return fmt.Errorf("wrong low water mark '%v' in 'NativeLowWaterMark'", markStr)
+		}
+		nativeLowWaterMarkMap[chainID] = mark
+	}
+	for tokenID, marks := range s.TokenLowWaterMark {
+		key := strings.ToLower(tokenID)
+		if _, exist := tokenLowWaterMarkMap[key]; !exist {
+			tokenLowWaterMarkMap[key] = make(map[string]*big.Int)
+		}
+		for chainID, markStr := range marks {
+			mark, err := common.GetBigIntFromStr(markStr)
+			if err != nil {
+				return fmt.Errorf("wrong low water mark '%v' of token '%v' in 'TokenLowWaterMark'", markStr, tokenID)
+			}
+			tokenLowWaterMarkMap[key][chainID] = mark
+		}
+	}
+	return nil
+}
+
 // CheckDynamicFeeTxConfig check dynamic fee tx config
 func (s *RouterServerConfig) CheckDynamicFeeTxConfig() error {
 	for _, c := range s.DynamicFeeTx {
@@ -294,6 +554,42 @@ func (s *RouterServerConfig) CheckExtra() error {
 	return nil
 }
 
+// CheckReplaceStrategy check replace strategy config
+func (s *RouterServerConfig) CheckReplaceStrategy() error {
+	for chainID, c := range s.ReplaceStrategy {
+		if _, ok := new(big.Int).SetString(chainID, 0); !ok {
+			return fmt.Errorf("wrong chain id '%v' in 'ReplaceStrategy'", chainID)
+		}
+		switch c.Mode {
+		case "":
+			c.Mode = ReplaceModeLinear
+		case ReplaceModeLinear, ReplaceModeExponential:
+		case ReplaceModeStepped:
+			if len(c.SteppedPlusPercents) == 0 {
+				return fmt.Errorf("chain %v stepped replace strategy without 'SteppedPlusPercents'", chainID)
+			}
+			for i := 1; i < len(c.SteppedPlusPercents); i++ {
+				if c.SteppedPlusPercents[i] < c.SteppedPlusPercents[i-1] {
+					return fmt.Errorf("chain %v 'SteppedPlusPercents' is not ascending", chainID)
+				}
+			}
+		default:
+			return fmt.Errorf("chain %v has unknown replace strategy mode '%v'", chainID, c.Mode)
+		}
+		if c.MaxPlusGasPricePercentage == 0 {
+			c.MaxPlusGasPricePercentage = s.MaxPlusGasPricePercentage
+		}
+		if c.WaitTimeToReplace == 0 {
+			c.WaitTimeToReplace = s.WaitTimeToReplace
+		}
+		if c.MaxReplaceCount == 0 {
+			c.MaxReplaceCount = s.MaxReplaceCount
+		}
+	}
+	log.Info("check server replace strategy config success")
+	return nil
+}
+
 // CheckConfig check mongodb config
 func (c *MongoDBConfig) CheckConfig() error {
 	if c.DBName == "" {
@@ -401,6 +697,7 @@ func (c *ExtraConfig) CheckConfig() (err error) {
 	initCallByContractWhitelist()
 	initCallByContractCodeHashWhitelist()
 	initBigValueWhitelist()
+	initSwapTradeExecutorWhitelist()
 	initDynamicFeeTxEnabledChains()
 	initEnableCheckTxBlockHashChains()
 	initEnableCheckTxBlockIndexChains()
@@ -412,6 +709,15 @@ func (c *ExtraConfig) CheckConfig() (err error) {
 		GetBalanceBlockNumberOpt = "pending"
 	}
 
+	for chainID, factory := range c.SwapTradeV3Factory {
+		if _, ok := new(big.Int).SetString(chainID, 0); !ok {
+			return fmt.Errorf("wrong chain id '%v' in 'SwapTradeV3Factory'", chainID)
+		}
+		if !common.IsHexAddress(factory) {
+			return fmt.Errorf("wrong factory '%v' in 'SwapTradeV3Factory'", factory)
+		}
+	}
+
 	for chainID, baseFeePercent := range c.BaseFeePercent {
 		if _, ok := new(big.Int).SetString(chainID, 0); !ok {
 			return fmt.Errorf("wrong chain id '%v' in 'BaseFeePercent'", chainID)
@@ -2,6 +2,7 @@ package params
 
 import (
 	"encoding/json"
+	"math"
 	"math/big"
 	"strings"
 
@@ -33,8 +34,12 @@ var (
 	fixedGasPriceMap    = make(map[string]*big.Int) // key is chainID
 	maxGasPriceMap      = make(map[string]*big.Int) // key is chainID
 
+	nativeLowWaterMarkMap = make(map[string]*big.Int)            // key is chainID
+	tokenLowWaterMarkMap  = make(map[string]map[string]*big.Int) // key is tokenID,chainID
+
 	callByContractWhitelist         map[string]map[string]struct{} // chainID -> caller
 	callByContractCodeHashWhitelist map[string]map[string]struct{} // chainID -> codehash
+	swapTradeExecutorWhitelist      map[string]map[string]struct{} // chainID -> executor
 	bigValueWhitelist               map[string]map[string]struct{} // tokenID -> caller
 
 	autoSwapNonceEnabledChains map[string]struct{}
@@ -84,11 +89,46 @@ type RouterServerConfig struct {
 	SendTxLoopCount            map[string]int    `toml:",omitempty" json:",omitempty"` // key is chain ID
 	SendTxLoopInterval         map[string]int    `toml:",omitempty" json:",omitempty"` // key is chain ID
 
+	EnableRebroadcastSwap bool
+	WaitTimeToRebroadcast int64 `toml:",omitempty" json:",omitempty"` // seconds
+	MaxRebroadcastCount   int   `toml:",omitempty" json:",omitempty"`
+
+	EnableAnyCallBudget bool
+
+	MaxAnyCallRetryCount   int    `toml:",omitempty" json:",omitempty"`
+	AnyCallRetryGasPercent uint64 `toml:",omitempty" json:",omitempty"`
+
+	BigValueTimelocks []*BigValueTimelockConfig `toml:",omitempty" json:",omitempty"`
+
+	Screening *ScreeningConfig `toml:",omitempty" json:",omitempty"`
+
+	SwapInDedup *SwapInDedupConfig `toml:",omitempty" json:",omitempty"`
+
+	Cluster *ClusterConfig `toml:",omitempty" json:",omitempty"`
+
+	SwapPriority *SwapPriorityConfig `toml:",omitempty" json:",omitempty"`
+
+	Analytics *AnalyticsConfig `toml:",omitempty" json:",omitempty"`
+
+	TokenAudit *TokenAuditConfig `toml:",omitempty" json:",omitempty"`
+
+	EnableReverifySwap   bool
+	ReverifyHorizon      int64 `toml:",omitempty" json:",omitempty"` // seconds
+	ReverifyBaseInterval int64 `toml:",omitempty" json:",omitempty"` // seconds
+	ReverifyMaxInterval  int64 `toml:",omitempty" json:",omitempty"` // seconds
+
+	EnableLiquidityMonitor   bool
+	LiquidityMonitorInterval int64                        `toml:",omitempty" json:",omitempty"` // seconds
+	NativeLowWaterMark       map[string]string            `toml:",omitempty" json:",omitempty"` // key is chain ID
+	TokenLowWaterMark        map[string]map[string]string `toml:",omitempty" json:",omitempty"` // key is tokenID,chainID
+
 	DefaultGasLimit  map[string]uint64            `toml:",omitempty" json:",omitempty"` // key is chain ID
 	MaxGasLimit      map[string]uint64            `toml:",omitempty" json:",omitempty"` // key is chain ID
 	MaxTokenGasLimit map[string]map[string]uint64 `toml:",omitempty" json:",omitempty"` // key is tokenID,chainID
 
 	DynamicFeeTx map[string]*DynamicFeeTxConfig `toml:",omitempty" json:",omitempty"` // key is chain ID
+
+	ReplaceStrategy map[string]*ReplaceStrategyConfig `toml:",omitempty" json:",omitempty"` // key is chain ID
 }
 
 // RouterOracleConfig only for oracle
@@ -105,6 +145,7 @@ type RouterConfig struct {
 	Identifier  string
 	SwapType    string
 	SwapSubType string
+	SwapTypes   map[string]*SwapTypeConfig `toml:",omitempty" json:",omitempty"` // key is swap type
 	Onchain     *OnchainConfig
 	Gateways    map[string][]string // key is chain ID
 	GatewaysExt map[string][]string `toml:",omitempty" json:",omitempty"` // key is chain ID
@@ -112,11 +153,82 @@ type RouterConfig struct {
 	FastMPC     *MPCConfig   `toml:",omitempty" json:",omitempty"`
 	Extra       *ExtraConfig `toml:",omitempty" json:",omitempty"`
 
+	Tracing         *TracingConfig    `toml:",omitempty" json:",omitempty"`
+	LogModuleLevels map[string]string `toml:",omitempty" json:",omitempty"` // key is module (eg. swap, doSwap)
+
 	ChainIDBlackList []string `toml:",omitempty" json:",omitempty"`
 	TokenIDBlackList []string `toml:",omitempty" json:",omitempty"`
 	AccountBlackList []string `toml:",omitempty" json:",omitempty"`
 }
 
+// TracingConfig export spans of swaps to opentelemetry collector (OTLP/HTTP with json encoding)
+type TracingConfig struct {
+	Enable        bool
+	Endpoint      string            // eg. http://127.0.0.1:4318/v1/traces
+	ServiceName   string            `toml:",omitempty" json:",omitempty"` // defaults to identifier
+	Headers       map[string]string `toml:",omitempty" json:",omitempty"`
+	BatchSize     int               `toml:",omitempty" json:",omitempty"`
+	MaxQueueSize  int               `toml:",omitempty" json:",omitempty"`
+	FlushInterval int64             `toml:",omitempty" json:",omitempty"` // seconds
+}
+
+// tracing default values
+var (
+	defTracingBatchSize     = 100
+	defTracingMaxQueueSize  = 10000
+	defTracingFlushInterval = int64(5)
+)
+
+// GetTracingConfig get tracing config (nil if not enabled)
+func GetTracingConfig() *TracingConfig {
+	if routerConfig == nil || routerConfig.Tracing == nil || !routerConfig.Tracing.Enable {
+		return nil
+	}
+	return routerConfig.Tracing
+}
+
+// GetServiceName get service name of spans
+func (c *TracingConfig) GetServiceName() string {
+	if c.ServiceName != "" {
+		return c.ServiceName
+	}
+	return GetIdentifier()
+}
+
+// GetBatchSize get max count of spans in one export request
+func (c *TracingConfig) GetBatchSize() int {
+	if c.BatchSize > 0 {
+		return c.BatchSize
+	}
+	return defTracingBatchSize
+}
+
+// GetMaxQueueSize get max count of spans waiting to export
+func (c *TracingConfig) GetMaxQueueSize() int {
+	if c.MaxQueueSize > 0 {
+		return c.MaxQueueSize
+	}
+	return defTracingMaxQueueSize
+}
+
+// GetFlushInterval get interval of exporting spans in seconds
+func (c *TracingConfig) GetFlushInterval() int64 {
+	if c.FlushInterval > 0 {
+		return c.FlushInterval
+	}
+	return defTracingFlushInterval
+}
+
+// SwapTypeConfig config of additional swap type served by the same router
+type SwapTypeConfig struct {
+	Identifier      string
+	SwapSubType     string            `toml:",omitempty" json:",omitempty"`
+	RouterContracts map[string]string `toml:",omitempty" json:",omitempty"` // key is chain ID
+	TokenIDs        []string          `toml:",omitempty" json:",omitempty"` // tokens of this swap type
+
+	tokenIDs map[string]struct{}
+}
+
 // ExtraConfig extra config
 type ExtraConfig struct {
 	IsDebugMode           bool `toml:",omitempty" json:",omitempty"`
@@ -131,6 +243,7 @@ type ExtraConfig struct {
 	MinReserveFee    map[string]uint64 `toml:",omitempty" json:",omitempty"`
 	BaseFeePercent   map[string]int64  `toml:",omitempty" json:",omitempty"` // key is chain ID
 	MinReserveBudget map[string]uint64 `toml:",omitempty" json:",omitempty"`
+	RefundFeeRate    map[string]uint64 `toml:",omitempty" json:",omitempty"` // key is chain ID, per million
 
 	AllowCallByConstructor          bool                `toml:",omitempty" json:",omitempty"`
 	AllowCallByContract             bool                `toml:",omitempty" json:",omitempty"`
@@ -139,6 +252,9 @@ type ExtraConfig struct {
 	CallByContractCodeHashWhitelist map[string][]string `toml:",omitempty" json:",omitempty"` // chainID -> whitelist
 	BigValueWhitelist               map[string][]string `toml:",omitempty" json:",omitempty"` // tokenID -> whitelist
 
+	SwapTradeV3Factory         map[string]string   `toml:",omitempty" json:",omitempty"` // chainID -> concentrated liquidity pool factory
+	SwapTradeExecutorWhitelist map[string][]string `toml:",omitempty" json:",omitempty"` // chainID -> aggregator executors
+
 	DynamicFeeTxEnabledChains            []string `toml:",omitempty" json:",omitempty"`
 	EnableCheckTxBlockHashChains         []string `toml:",omitempty" json:",omitempty"`
 	EnableCheckTxBlockIndexChains        []string `toml:",omitempty" json:",omitempty"`
@@ -236,6 +352,368 @@ func (c *DynamicFeeTxConfig) GetMaxGasFeeCap() *big.Int {
 	return c.maxGasFeeCap
 }
 
+// replace strategy modes
+const (
+	ReplaceModeLinear      = "linear"
+	ReplaceModeExponential = "exponential"
+	ReplaceModeStepped     = "stepped"
+)
+
+// ScreeningConfig address screening config
+type ScreeningConfig struct {
+	Provider   string            // http, file
+	APIAddress string            `toml:",omitempty" json:",omitempty"` // http provider
+	Headers    map[string]string `toml:",omitempty" json:"-"`          // http provider (eg. api key)
+	Timeout    int               `toml:",omitempty" json:",omitempty"` // seconds, http provider
+	FilePath   string            `toml:",omitempty" json:",omitempty"` // file provider
+	CacheTTL   int64             `toml:",omitempty" json:",omitempty"` // seconds
+	FailClosed bool              `toml:",omitempty" json:",omitempty"` // hold swap if screening failed
+}
+
+// screening providers
+const (
+	ScreeningProviderHTTP = "http"
+	ScreeningProviderFile = "file"
+)
+
+// GetScreeningConfig get screening config
+func GetScreeningConfig() *ScreeningConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.Screening
+}
+
+// SwapPriorityConfig priority rules of swap dispatcher.
+// swaps are dispatched into high, normal and low priority classes.
+type SwapPriorityConfig struct {
+	Enable bool
+
+	// value rules, the USD value is calculated by token decimals and price
+	TokenPriceUSD map[string]float64 `toml:",omitempty" json:",omitempty"` // key is tokenID
+	HighValueUSD  float64            `toml:",omitempty" json:",omitempty"` // not less than is high priority
+	LowValueUSD   float64            `toml:",omitempty" json:",omitempty"` // less than is low priority
+
+	// age rule, swaps registered earlier than this seconds are high priority
+	HighPriorityAge int64 `toml:",omitempty" json:",omitempty"`
+
+	// sender rule, swaps from whitelisted senders are high priority
+	WhitelistSenders []string `toml:",omitempty" json:",omitempty"`
+
+	// swap type rule, value is one of high, normal, low
+	SwapTypePriority map[string]string `toml:",omitempty" json:",omitempty"` // key is swap type
+
+	// take one lower priority swap after taking this count of higher priority swaps
+	StarvationLimit int `toml:",omitempty" json:",omitempty"`
+	// take the swap first if it has waited longer than this seconds in queue
+	MaxWaitTime int64 `toml:",omitempty" json:",omitempty"`
+
+	whitelistSenders map[string]struct{}
+}
+
+// swap priority classes
+const (
+	SwapPriorityHigh   = "high"
+	SwapPriorityNormal = "normal"
+	SwapPriorityLow    = "low"
+)
+
+// GetSwapPriorityConfig get swap priority config (nil if disabled)
+func GetSwapPriorityConfig() *SwapPriorityConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil || serverCfg.SwapPriority == nil || !serverCfg.SwapPriority.Enable {
+		return nil
+	}
+	return serverCfg.SwapPriority
+}
+
+// IsWhitelistSender is sender in swap priority whitelist
+func (c *SwapPriorityConfig) IsWhitelistSender(sender string) bool {
+	_, exist := c.whitelistSenders[strings.ToLower(sender)]
+	return exist
+}
+
+// ClusterConfig run multiple servers hot-hot with per chain leases.
+// only the lease owner of a chain processes the swaps of this chain.
+type ClusterConfig struct {
+	Enable   bool
+	NodeID   string `toml:",omitempty" json:",omitempty"` // defaults to hostname:pid
+	LeaseTTL int64  `toml:",omitempty" json:",omitempty"` // seconds
+}
+
+var defClusterLeaseTTL = int64(30)
+
+// IsClusterEnabled is cluster mode enabled
+func IsClusterEnabled() bool {
+	serverCfg := GetRouterServerConfig()
+	return serverCfg != nil && serverCfg.Cluster != nil && serverCfg.Cluster.Enable
+}
+
+// GetClusterNodeID get node id of cluster
+func GetClusterNodeID() string {
+	if serverCfg := GetRouterServerConfig(); serverCfg != nil && serverCfg.Cluster != nil {
+		return serverCfg.Cluster.NodeID
+	}
+	return ""
+}
+
+// GetClusterLeaseTTL get chain lease ttl of cluster
+func GetClusterLeaseTTL() int64 {
+	if serverCfg := GetRouterServerConfig(); serverCfg != nil && serverCfg.Cluster != nil && serverCfg.Cluster.LeaseTTL > 0 {
+		return serverCfg.Cluster.LeaseTTL
+	}
+	return defClusterLeaseTTL
+}
+
+// SwapInDedupConfig check swapins on the destination chain to prevent paying a swap twice
+// (eg. more than one router deployments are using the same mpc)
+type SwapInDedupConfig struct {
+	EnableCheck       bool              // check before signing swap tx
+	EnableAudit       bool              // audit duplicate swapins in background
+	LookbackBlocks    uint64            `toml:",omitempty" json:",omitempty"`
+	ChainLookback     map[string]uint64 `toml:",omitempty" json:",omitempty"` // key is chain ID
+	MaxBlocksPerQuery uint64            `toml:",omitempty" json:",omitempty"`
+	AuditInterval     int64             `toml:",omitempty" json:",omitempty"` // seconds
+}
+
+// swapin dedup default values
+var (
+	defSwapInDedupLookbackBlocks    = uint64(100000)
+	defSwapInDedupMaxBlocksPerQuery = uint64(5000)
+)
+
+// GetSwapInDedupConfig get swapin dedup config
+func GetSwapInDedupConfig() *SwapInDedupConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.SwapInDedup
+}
+
+// IsSwapInDedupCheckEnabled is swapin dedup check before signing enabled
+func IsSwapInDedupCheckEnabled() bool {
+	c := GetSwapInDedupConfig()
+	return c != nil && c.EnableCheck
+}
+
+// IsSwapInDedupAuditEnabled is swapin dedup audit enabled
+func IsSwapInDedupAuditEnabled() bool {
+	c := GetSwapInDedupConfig()
+	return c != nil && c.EnableAudit
+}
+
+// GetSwapInDedupLookbackBlocks get block range to look back for swapins
+func GetSwapInDedupLookbackBlocks(chainID string) uint64 {
+	c := GetSwapInDedupConfig()
+	if c == nil {
+		return defSwapInDedupLookbackBlocks
+	}
+	if lookback, exist := c.ChainLookback[chainID]; exist && lookback > 0 {
+		return lookback
+	}
+	if c.LookbackBlocks > 0 {
+		return c.LookbackBlocks
+	}
+	return defSwapInDedupLookbackBlocks
+}
+
+// GetSwapInDedupMaxBlocksPerQuery get max block range of one logs query
+func GetSwapInDedupMaxBlocksPerQuery() uint64 {
+	if c := GetSwapInDedupConfig(); c != nil && c.MaxBlocksPerQuery > 0 {
+		return c.MaxBlocksPerQuery
+	}
+	return defSwapInDedupMaxBlocksPerQuery
+}
+
+// AnalyticsConfig precompute swap analytics into daily rollups
+type AnalyticsConfig struct {
+	Enable         bool
+	RollupInterval int64 `toml:",omitempty" json:",omitempty"` // seconds
+	RecomputeDays  int   `toml:",omitempty" json:",omitempty"` // recompute rollups of recent days (including today)
+	MaxQueryDays   int   `toml:",omitempty" json:",omitempty"` // max day range of one query
+}
+
+// analytics default values
+var (
+	defAnalyticsRollupInterval = int64(600)
+	defAnalyticsRecomputeDays  = 2
+	defAnalyticsMaxQueryDays   = 366
+)
+
+// GetAnalyticsConfig get analytics config
+func GetAnalyticsConfig() *AnalyticsConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.Analytics
+}
+
+// IsAnalyticsEnabled is analytics rollup enabled
+func IsAnalyticsEnabled() bool {
+	c := GetAnalyticsConfig()
+	return c != nil && c.Enable
+}
+
+// GetAnalyticsRollupInterval get interval of computing rollups in seconds
+func GetAnalyticsRollupInterval() int64 {
+	if c := GetAnalyticsConfig(); c != nil && c.RollupInterval > 0 {
+		return c.RollupInterval
+	}
+	return defAnalyticsRollupInterval
+}
+
+// GetAnalyticsRecomputeDays get count of recent days to recompute rollups
+func GetAnalyticsRecomputeDays() int {
+	if c := GetAnalyticsConfig(); c != nil && c.RecomputeDays > 0 {
+		return c.RecomputeDays
+	}
+	return defAnalyticsRecomputeDays
+}
+
+// GetAnalyticsMaxQueryDays get max day range of one analytics query
+func GetAnalyticsMaxQueryDays() int {
+	if c := GetAnalyticsConfig(); c != nil && c.MaxQueryDays > 0 {
+		return c.MaxQueryDays
+	}
+	return defAnalyticsMaxQueryDays
+}
+
+// TokenAuditConfig audit token decimals and reconcile bridged supply with locked underlying
+type TokenAuditConfig struct {
+	Enable                 bool
+	Interval               int64    `toml:",omitempty" json:",omitempty"` // seconds
+	AutoPause              bool     // add token id to blacklist if discrepancy is found
+	SupplyTolerancePercent float64  `toml:",omitempty" json:",omitempty"` // allowed percent of supply exceeding locked underlying
+	IgnoreTokenIDs         []string `toml:",omitempty" json:",omitempty"`
+
+	ignoreTokenIDs map[string]struct{}
+}
+
+// token audit default values
+var (
+	defTokenAuditInterval = int64(3600)
+)
+
+// GetTokenAuditConfig get token audit config
+func GetTokenAuditConfig() *TokenAuditConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.TokenAudit
+}
+
+// IsTokenAuditEnabled is token audit enabled
+func IsTokenAuditEnabled() bool {
+	c := GetTokenAuditConfig()
+	return c != nil && c.Enable
+}
+
+// GetTokenAuditInterval get interval of auditing tokens in seconds
+func GetTokenAuditInterval() int64 {
+	if c := GetTokenAuditConfig(); c != nil && c.Interval > 0 {
+		return c.Interval
+	}
+	return defTokenAuditInterval
+}
+
+// IsTokenAuditAutoPause is auto pause token if discrepancy is found
+func IsTokenAuditAutoPause() bool {
+	c := GetTokenAuditConfig()
+	return c != nil && c.AutoPause
+}
+
+// GetTokenAuditSupplyTolerancePercent get allowed percent of supply exceeding locked underlying
+func GetTokenAuditSupplyTolerancePercent() float64 {
+	if c := GetTokenAuditConfig(); c != nil {
+		return c.SupplyTolerancePercent
+	}
+	return 0
+}
+
+// IsTokenAuditIgnored is token id ignored in token audit
+func IsTokenAuditIgnored(tokenID string) bool {
+	c := GetTokenAuditConfig()
+	if c == nil {
+		return false
+	}
+	_, exist := c.ignoreTokenIDs[strings.ToLower(tokenID)]
+	return exist
+}
+
+// BigValueTimelockConfig delay of passing big value swap.
+// it applies when the swap value exceeds ThresholdPercent of the big value threshold.
+type BigValueTimelockConfig struct {
+	ThresholdPercent uint64
+	Delay            int64 // seconds
+}
+
+// ReplaceStrategyConfig replace swap strategy config
+type ReplaceStrategyConfig struct {
+	Mode                      string   // linear (default), exponential, stepped
+	PlusGasPricePercent       uint64   `toml:",omitempty" json:",omitempty"` // linear and exponential
+	SteppedPlusPercents       []uint64 `toml:",omitempty" json:",omitempty"` // stepped, the plus percent of each replace
+	MaxPlusGasPricePercentage uint64   `toml:",omitempty" json:",omitempty"`
+	WaitTimeToReplace         int64    `toml:",omitempty" json:",omitempty"` // seconds
+	MaxReplaceCount           int      `toml:",omitempty" json:",omitempty"`
+	EnableCancelTx            bool     `toml:",omitempty" json:",omitempty"`
+}
+
+// GetReplacePlusPercent get plus percent of the specified replace num
+func (c *ReplaceStrategyConfig) GetReplacePlusPercent(replaceNum uint64) uint64 {
+	if replaceNum == 0 {
+		return 0
+	}
+	switch c.Mode {
+	case ReplaceModeExponential:
+		// (1 + p)^n - 1, rounded to the nearest percent
+		rate := 1 + float64(c.PlusGasPricePercent)/100
+		factor := float64(1)
+		percent := uint64(0)
+		for i := uint64(0); i < replaceNum; i++ {
+			factor *= rate
+			percent = uint64(math.Round((factor - 1) * 100))
+			if percent >= c.MaxPlusGasPricePercentage {
+				break
+			}
+		}
+		return percent
+	case ReplaceModeStepped:
+		steps := c.SteppedPlusPercents
+		if len(steps) == 0 {
+			return 0
+		}
+		if uint64(len(steps)) >= replaceNum {
+			return steps[replaceNum-1]
+		}
+		return steps[len(steps)-1]
+	default:
+		return replaceNum * c.PlusGasPricePercent
+	}
+}
+
+// GetReplaceStrategy get replace strategy of specified chain
+// if not configed, then use the default linear strategy
+func GetReplaceStrategy(chainID string) *ReplaceStrategyConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	if strategy, exist := serverCfg.ReplaceStrategy[chainID]; exist {
+		return strategy
+	}
+	return &ReplaceStrategyConfig{
+		Mode:                      ReplaceModeLinear,
+		PlusGasPricePercent:       serverCfg.ReplacePlusGasPricePercent,
+		MaxPlusGasPricePercentage: serverCfg.MaxPlusGasPricePercentage,
+		WaitTimeToReplace:         serverCfg.WaitTimeToReplace,
+		MaxReplaceCount:           serverCfg.MaxReplaceCount,
+	}
+}
+
 // GetIdentifier get identifier (to distiguish in mpc accept)
 func GetIdentifier() string {
 	return GetRouterConfig().Identifier
@@ -251,11 +729,72 @@ func GetSwapSubType() string {
 	return GetRouterConfig().SwapSubType
 }
 
+// GetSwapTypeConfig get config of additional swap type
+func GetSwapTypeConfig(swapType string) *SwapTypeConfig {
+	return GetRouterConfig().SwapTypes[strings.ToLower(swapType)]
+}
+
+// GetSwapTypeIdentifier get identifier of swap type (default to the router identifier)
+func GetSwapTypeIdentifier(swapType string) string {
+	if c := GetSwapTypeConfig(swapType); c != nil && c.Identifier != "" {
+		return c.Identifier
+	}
+	return GetIdentifier()
+}
+
+// GetSwapTypeSubType get sub type of swap type (default to the router sub type)
+func GetSwapTypeSubType(swapType string) string {
+	if c := GetSwapTypeConfig(swapType); c != nil {
+		return c.SwapSubType
+	}
+	return GetSwapSubType()
+}
+
+// GetSwapTypeRouterContract get router contract of swap type on the chain
+// return empty if not configed, then use the router contract of the chain
+func GetSwapTypeRouterContract(swapType, chainID string) string {
+	if c := GetSwapTypeConfig(swapType); c != nil {
+		return c.RouterContracts[chainID]
+	}
+	return ""
+}
+
+// GetTokenIDSwapType get swap type of token ID
+// return the additional swap type which lists the token ID, otherwise the main swap type
+func GetTokenIDSwapType(tokenID string) string {
+	key := strings.ToLower(tokenID)
+	for swapType, c := range GetRouterConfig().SwapTypes {
+		if _, exist := c.tokenIDs[key]; exist {
+			return swapType
+		}
+	}
+	return GetRouterConfig().SwapType
+}
+
+// GetAllSwapTypeRouterContracts get router contracts of all additional swap types on the chain
+func GetAllSwapTypeRouterContracts(chainID string) []string {
+	var contracts []string
+	for _, c := range GetRouterConfig().SwapTypes {
+		if contract := c.RouterContracts[chainID]; contract != "" {
+			contracts = append(contracts, contract)
+		}
+	}
+	return contracts
+}
+
 // IsSwapTradeEnabled is swap trade enabled
 func IsSwapTradeEnabled() bool {
 	return GetExtraConfig() != nil && GetExtraConfig().EnableSwapTrade
 }
 
+// GetSwapTradeV3Factory get concentrated liquidity pool factory of swap trade
+func GetSwapTradeV3Factory(chainID string) string {
+	if GetExtraConfig() == nil {
+		return ""
+	}
+	return GetExtraConfig().SwapTradeV3Factory[chainID]
+}
+
 // IsSwapWithPermitEnabled is swap with permit enabled
 func IsSwapWithPermitEnabled() bool {
 	return GetExtraConfig() != nil && GetExtraConfig().EnableSwapWithPermit
@@ -271,6 +810,41 @@ func IsParallelSwapEnabled() bool {
 	return GetExtraConfig() != nil && GetExtraConfig().EnableParallelSwap
 }
 
+// IsAnyCallBudgetEnabled is anycall prepaid budget enabled
+func IsAnyCallBudgetEnabled() bool {
+	return GetRouterServerConfig() != nil && GetRouterServerConfig().EnableAnyCallBudget
+}
+
+// GetBigValueTimelocks get big value timelocks (sorted by threshold percent desc)
+func GetBigValueTimelocks() []*BigValueTimelockConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.BigValueTimelocks
+}
+
+// IsLiquidityMonitorEnabled is liquidity monitor enabled
+func IsLiquidityMonitorEnabled() bool {
+	return GetRouterServerConfig() != nil && GetRouterServerConfig().EnableLiquidityMonitor
+}
+
+// GetNativeLowWaterMark get low water mark of mpc native balance of specified chain
+func GetNativeLowWaterMark(chainID string) *big.Int {
+	if mark, ok := nativeLowWaterMarkMap[chainID]; ok {
+		return new(big.Int).Set(mark)
+	}
+	return nil
+}
+
+// GetTokenLowWaterMark get low water mark of token liquidity of specified tokenID and chainID
+func GetTokenLowWaterMark(tokenID, chainID string) *big.Int {
+	if mark, ok := tokenLowWaterMarkMap[strings.ToLower(tokenID)][chainID]; ok {
+		return new(big.Int).Set(mark)
+	}
+	return nil
+}
+
 // IsFixedGasPrice is fixed gas price of specified chain
 func IsFixedGasPrice(chainID string) bool {
 	_, exist := fixedGasPriceMap[chainID]
@@ -293,6 +867,15 @@ func GetMaxGasPrice(chainID string) *big.Int {
 	return nil
 }
 
+// GetDefaultGasLimit get default gas limit of specified chain (0 if not configed)
+func GetDefaultGasLimit(chainID string) uint64 {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return 0
+	}
+	return serverCfg.DefaultGasLimit[chainID]
+}
+
 // GetMaxGasLimit get max gas limit of specified chain
 func GetMaxGasLimit(chainID string) uint64 {
 	serverCfg := GetRouterServerConfig()
@@ -373,6 +956,15 @@ func GetBaseFeePercent(chainID string) int64 {
 	return 0
 }
 
+// GetRefundFeeRate get refund fee rate (per million) of source chain
+func GetRefundFeeRate(chainID string) uint64 {
+	extraCfg := GetExtraConfig()
+	if extraCfg == nil {
+		return 0
+	}
+	return extraCfg.RefundFeeRate[chainID]
+}
+
 // GetRPCClientTimeout get rpc client timeout
 func GetRPCClientTimeout(chainID string) int {
 	extraCfg := GetExtraConfig()
@@ -495,6 +1087,37 @@ func initCallByContractWhitelist() {
 	log.Info("initCallByContractWhitelist success")
 }
 
+func initSwapTradeExecutorWhitelist() {
+	swapTradeExecutorWhitelist = make(map[string]map[string]struct{})
+	if GetExtraConfig() == nil || len(GetExtraConfig().SwapTradeExecutorWhitelist) == 0 {
+		return
+	}
+	for cid, whitelist := range GetExtraConfig().SwapTradeExecutorWhitelist {
+		if _, err := common.GetBigIntFromStr(cid); err != nil {
+			log.Fatal("initSwapTradeExecutorWhitelist wrong chainID", "chainID", cid, "err", err)
+		}
+		whitelistMap := make(map[string]struct{}, len(whitelist))
+		for _, address := range whitelist {
+			if !common.IsHexAddress(address) {
+				log.Fatal("initSwapTradeExecutorWhitelist wrong address", "chainID", cid, "address", address)
+			}
+			whitelistMap[strings.ToLower(address)] = struct{}{}
+		}
+		swapTradeExecutorWhitelist[cid] = whitelistMap
+	}
+	log.Info("initSwapTradeExecutorWhitelist success")
+}
+
+// IsInSwapTradeExecutorWhitelist is in swap trade executor whitelist
+func IsInSwapTradeExecutorWhitelist(chainID, executor string) bool {
+	whitelist, exist := swapTradeExecutorWhitelist[chainID]
+	if !exist {
+		return false
+	}
+	_, exist = whitelist[strings.ToLower(executor)]
+	return exist
+}
+
 // IsInCallByContractWhitelist is in call by contract whitelist
 func IsInCallByContractWhitelist(chainID, caller string) bool {
 	whitelist, exist := callByContractWhitelist[chainID]
@@ -0,0 +1,34 @@
+package params
+
+import "testing"
+
+func TestGetReplacePlusPercent(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        *ReplaceStrategyConfig
+		replaceNum uint64
+		want       uint64
+	}{
+		{"linear zero", &ReplaceStrategyConfig{Mode: ReplaceModeLinear, PlusGasPricePercent: 10}, 0, 0},
+		{"linear first", &ReplaceStrategyConfig{Mode: ReplaceModeLinear, PlusGasPricePercent: 10}, 1, 10},
+		{"linear third", &ReplaceStrategyConfig{Mode: ReplaceModeLinear, PlusGasPricePercent: 10}, 3, 30},
+		{"default mode is linear", &ReplaceStrategyConfig{PlusGasPricePercent: 15}, 2, 30},
+		{"exponential zero", &ReplaceStrategyConfig{Mode: ReplaceModeExponential, PlusGasPricePercent: 10, MaxPlusGasPricePercentage: 100}, 0, 0},
+		{"exponential first", &ReplaceStrategyConfig{Mode: ReplaceModeExponential, PlusGasPricePercent: 10, MaxPlusGasPricePercentage: 100}, 1, 10},
+		{"exponential second", &ReplaceStrategyConfig{Mode: ReplaceModeExponential, PlusGasPricePercent: 10, MaxPlusGasPricePercentage: 100}, 2, 21},
+		{"exponential third", &ReplaceStrategyConfig{Mode: ReplaceModeExponential, PlusGasPricePercent: 10, MaxPlusGasPricePercentage: 100}, 3, 33},
+		{"exponential rounds", &ReplaceStrategyConfig{Mode: ReplaceModeExponential, PlusGasPricePercent: 15, MaxPlusGasPricePercentage: 100}, 3, 52},
+		{"exponential rounds small percent", &ReplaceStrategyConfig{Mode: ReplaceModeExponential, PlusGasPricePercent: 3, MaxPlusGasPricePercentage: 100}, 2, 6},
+		{"exponential stops at max", &ReplaceStrategyConfig{Mode: ReplaceModeExponential, PlusGasPricePercent: 50, MaxPlusGasPricePercentage: 100}, 10, 125},
+		{"stepped zero", &ReplaceStrategyConfig{Mode: ReplaceModeStepped, SteppedPlusPercents: []uint64{5, 20, 50}}, 0, 0},
+		{"stepped first", &ReplaceStrategyConfig{Mode: ReplaceModeStepped, SteppedPlusPercents: []uint64{5, 20, 50}}, 1, 5},
+		{"stepped last", &ReplaceStrategyConfig{Mode: ReplaceModeStepped, SteppedPlusPercents: []uint64{5, 20, 50}}, 3, 50},
+		{"stepped beyond last", &ReplaceStrategyConfig{Mode: ReplaceModeStepped, SteppedPlusPercents: []uint64{5, 20, 50}}, 6, 50},
+		{"stepped without steps", &ReplaceStrategyConfig{Mode: ReplaceModeStepped}, 2, 0},
+	}
+	for _, tt := range tests {
+		if have := tt.cfg.GetReplacePlusPercent(tt.replaceNum); have != tt.want {
+			t.Errorf("%v: plus percent of replace num %v is %v, want %v", tt.name, tt.replaceNum, have, tt.want)
+		}
+	}
+}
@@ -89,7 +89,7 @@ func InitRouterBridges(isServer bool) {
 		tokenIDs = append(tokenIDs, tokenID)
 	}
 	log.Info("get all token ids success", "tokenIDs", tokenIDs)
-	if len(tokenIDs) == 0 && !tokens.IsAnyCallRouter() {
+	if len(tokenIDs) == 0 && !tokens.IsSwapTypeEnabled(tokens.AnyCallSwapType) {
 		logErrFunc("empty token IDs")
 		return
 	}
@@ -136,7 +136,7 @@ func InitRouterBridges(isServer bool) {
 }
 
 func loadSwapAndFeeConfigs() {
-	if !tokens.IsERC20Router() {
+	if !tokens.IsSwapTypeEnabled(tokens.ERC20SwapType) {
 		return
 	}
 
@@ -388,6 +388,19 @@ func InitChainConfig(b tokens.IBridge, chainID *big.Int) {
 			return
 		}
 	}
+
+	for _, routerContract := range params.GetAllSwapTypeRouterContracts(chainID.String()) {
+		if isRouterInfoLoaded(chainID.String(), routerContract) {
+			continue
+		}
+		err = b.InitRouterInfo(routerContract)
+		if err == nil {
+			setRouterInfoLoaded(chainID.String(), routerContract)
+		} else {
+			logErrFunc("init swap type router info failed", "chainID", chainID, "routerContract", routerContract, "err", err)
+			return
+		}
+	}
 }
 
 // InitTokenConfig impl
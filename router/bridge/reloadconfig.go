@@ -14,7 +14,18 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 )
 
-var reloadRouterConfigLock sync.Mutex
+var (
+	reloadRouterConfigLock sync.Mutex
+
+	afterReloadCallbacks []func()
+)
+
+// AddAfterReloadCallback add callback which is called after reload router config successfully
+func AddAfterReloadCallback(callback func()) {
+	reloadRouterConfigLock.Lock()
+	defer reloadRouterConfigLock.Unlock()
+	afterReloadCallbacks = append(afterReloadCallbacks, callback)
+}
 
 // StartReloadRouterConfigTask start reload config
 func StartReloadRouterConfigTask() {
@@ -118,7 +129,7 @@ func ReloadRouterConfig() (success bool) {
 		tokenIDs = append(tokenIDs, tokenID)
 	}
 	log.Info("[reload] get all token ids success", "tokenIDs", tokenIDs)
-	if len(tokenIDs) == 0 && !tokens.IsAnyCallRouter() {
+	if len(tokenIDs) == 0 && !tokens.IsSwapTypeEnabled(tokens.AnyCallSwapType) {
 		log.Error("[reload] empty token IDs")
 	}
 
@@ -228,6 +239,10 @@ func ReloadRouterConfig() (success bool) {
 		router.SetBridge(chainID, nil)
 	}
 
+	for _, callback := range afterReloadCallbacks {
+		go callback()
+	}
+
 	success = true
 	return success
 }
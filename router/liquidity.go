@@ -0,0 +1,53 @@
+package router
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LiquidityInfo liquidity of mpc native balance (empty TokenID) or token on chain.
+// values are in the smallest unit of native or token.
+type LiquidityInfo struct {
+	ChainID      string
+	TokenID      string `json:",omitempty"`
+	Token        string `json:",omitempty"`
+	Holder       string
+	Balance      string
+	Pending      string `json:",omitempty"`
+	LowWaterMark string `json:",omitempty"`
+	IsLow        bool
+	Error        string `json:",omitempty"`
+	Timestamp    int64
+}
+
+var liquidityInfos = new(sync.Map) // key is chainID:tokenID:holder
+
+// SetLiquidityInfo set liquidity info
+func SetLiquidityInfo(info *LiquidityInfo) {
+	key := strings.ToLower(fmt.Sprintf("%s:%s:%s", info.ChainID, info.TokenID, info.Holder))
+	liquidityInfos.Store(key, info)
+}
+
+// GetLiquidityInfos get liquidity infos of chain (all chains if chainID is empty)
+func GetLiquidityInfos(chainID string, onlyLow bool) []*LiquidityInfo {
+	result := make([]*LiquidityInfo, 0)
+	liquidityInfos.Range(func(k, v interface{}) bool {
+		info := v.(*LiquidityInfo)
+		if (chainID == "" || info.ChainID == chainID) && (!onlyLow || info.IsLow) {
+			result = append(result, info)
+		}
+		return true
+	})
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ChainID != result[j].ChainID {
+			return result[i].ChainID < result[j].ChainID
+		}
+		if result[i].TokenID != result[j].TokenID {
+			return result[i].TokenID < result[j].TokenID
+		}
+		return result[i].Holder < result[j].Holder
+	})
+	return result
+}
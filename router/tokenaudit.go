@@ -0,0 +1,74 @@
+package router
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// TokenDecimalsAudit decimals audit of token on chain
+type TokenDecimalsAudit struct {
+	ChainID            string
+	Token              string
+	Decimals           uint8 // configed decimals used in converting value
+	OnchainDecimals    uint8
+	Underlying         string `json:",omitempty"`
+	UnderlyingDecimals uint8  `json:",omitempty"`
+	IsMismatch         bool
+	Error              string `json:",omitempty"`
+}
+
+// TokenSupplyAudit supply audit of token on chain.
+// values are in the smallest unit of token.
+type TokenSupplyAudit struct {
+	ChainID     string
+	Token       string
+	TotalSupply string
+	Underlying  string `json:",omitempty"`
+	Locked      string `json:",omitempty"` // underlying balance of token
+	Error       string `json:",omitempty"`
+}
+
+// TokenAuditReport audit report of token on all chains.
+// total values are normalized to the max decimals of token on all chains.
+type TokenAuditReport struct {
+	TokenID            string
+	Decimals           []*TokenDecimalsAudit
+	Supplies           []*TokenSupplyAudit
+	SkippedChainIDs    []string `json:",omitempty"` // chains not supporting audit
+	NormalizedDecimals uint8
+	TotalSupply        string   `json:",omitempty"`
+	TotalLocked        string   `json:",omitempty"`
+	IsReconciled       bool     // whether supply is reconciled with locked underlying
+	Discrepancies      []string `json:",omitempty"`
+	IsPaused           bool     `json:",omitempty"` // paused by audit
+	Timestamp          int64
+}
+
+// HasDiscrepancy has discrepancy
+func (r *TokenAuditReport) HasDiscrepancy() bool {
+	return len(r.Discrepancies) > 0
+}
+
+var tokenAuditReports = new(sync.Map) // key is tokenID (lower case)
+
+// SetTokenAuditReport set token audit report
+func SetTokenAuditReport(report *TokenAuditReport) {
+	tokenAuditReports.Store(strings.ToLower(report.TokenID), report)
+}
+
+// GetTokenAuditReports get token audit reports of tokenID (all tokenIDs if tokenID is empty)
+func GetTokenAuditReports(tokenID string, onlyDiscrepancy bool) []*TokenAuditReport {
+	result := make([]*TokenAuditReport, 0)
+	tokenAuditReports.Range(func(k, v interface{}) bool {
+		report := v.(*TokenAuditReport)
+		if (tokenID == "" || strings.EqualFold(report.TokenID, tokenID)) && (!onlyDiscrepancy || report.HasDiscrepancy()) {
+			result = append(result, report)
+		}
+		return true
+	})
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TokenID < result[j].TokenID
+	})
+	return result
+}
@@ -84,15 +84,22 @@ func GetTokenRouterContract(tokenID, chainID string) (string, error) {
 	if bridge == nil {
 		return "", tokens.ErrNoBridgeForChainID
 	}
-	multichainToken := ""
-	if !tokens.IsAnyCallRouter() {
-		multichainToken = GetCachedMultichainToken(tokenID, chainID)
-		if multichainToken == "" {
-			log.Warn("GetTokenRouterContract get multichain token failed", "tokenID", tokenID, "chainID", chainID)
-			return "", tokens.ErrMissTokenConfig
+	var routerContract string
+	if tokenID == "" {
+		// anycall swap has no token
+		routerContract = params.GetSwapTypeRouterContract(tokens.AnyCallSwapType.String(), chainID)
+	}
+	if routerContract == "" {
+		multichainToken := ""
+		if tokenID != "" {
+			multichainToken = GetCachedMultichainToken(tokenID, chainID)
+			if multichainToken == "" {
+				log.Warn("GetTokenRouterContract get multichain token failed", "tokenID", tokenID, "chainID", chainID)
+				return "", tokens.ErrMissTokenConfig
+			}
 		}
+		routerContract = bridge.GetRouterContract(multichainToken)
 	}
-	routerContract := bridge.GetRouterContract(multichainToken)
 	if routerContract == "" {
 		return "", tokens.ErrMissRouterInfo
 	}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -103,6 +104,15 @@ type jsonrpcResponse struct {
 	Result  json.RawMessage `json:"result,omitempty"`
 }
 
+// GetErrorData get data of json-rpc error (eg. revert data of eth_call)
+func GetErrorData(err error) interface{} {
+	var jerr *jsonError
+	if errors.As(err, &jerr) {
+		return jerr.Data
+	}
+	return nil
+}
+
 // RPCPostRequest rpc post request
 func RPCPostRequest(url string, req *Request, result interface{}) error {
 	return RPCPostRequestWithContext(httpCtx, url, req, result)
@@ -64,6 +64,14 @@ func OracleInfoHandler(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, oracleInfo, nil)
 }
 
+// MPCHealthHandler handler
+func MPCHealthHandler(w http.ResponseWriter, r *http.Request) {
+	vals := r.URL.Query()
+	withEnodes := vals.Get("enodes") == "true"
+	res := swapapi.GetMPCHealth(withEnodes)
+	writeResponse(w, res, nil)
+}
+
 // StatusInfoHandler handler
 func StatusInfoHandler(w http.ResponseWriter, r *http.Request) {
 	var status string
@@ -123,6 +131,119 @@ func GetRouterSwapHandler(w http.ResponseWriter, r *http.Request) {
 	writeResponse(w, res, err)
 }
 
+// GetRouterSwapSignedTxsHandler handler
+func GetRouterSwapSignedTxsHandler(w http.ResponseWriter, r *http.Request) {
+	chainID, txid, logIndex := getRouterSwapKeys(r)
+	res, err := swapapi.GetRouterSwapSignedTxs(chainID, txid, logIndex)
+	writeResponse(w, res, err)
+}
+
+// GetRouterSwapReplaceDecisionsHandler handler
+func GetRouterSwapReplaceDecisionsHandler(w http.ResponseWriter, r *http.Request) {
+	chainID, txid, logIndex := getRouterSwapKeys(r)
+	res, err := swapapi.GetRouterSwapReplaceDecisions(chainID, txid, logIndex)
+	writeResponse(w, res, err)
+}
+
+// GetDestTagHandler handler
+func GetDestTagHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	chainID := vars["chainid"]
+	tag := vars["tag"]
+	res, err := swapapi.GetDestTag(chainID, tag)
+	writeResponse(w, res, err)
+}
+
+// GetAnyCallBudgetHandler handler
+func GetAnyCallBudgetHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	chainID := vars["chainid"]
+	callFrom := vars["callfrom"]
+	res, err := swapapi.GetAnyCallBudget(chainID, callFrom)
+	writeResponse(w, res, err)
+}
+
+// GetAnyCallDAppUsageHandler handler
+func GetAnyCallDAppUsageHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	chainID := vars["chainid"]
+	dapp := vars["dapp"]
+	res, err := swapapi.GetAnyCallDAppUsage(chainID, dapp)
+	writeResponse(w, res, err)
+}
+
+// GetAnyCallDAppUsagesHandler handler
+func GetAnyCallDAppUsagesHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	chainID := vars["chainid"]
+	res, err := swapapi.GetAnyCallDAppUsages(chainID)
+	writeResponse(w, res, err)
+}
+
+// GetPendingBigValueSwapsHandler handler
+func GetPendingBigValueSwapsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	chainID := vars["chainid"]
+	res, err := swapapi.GetPendingBigValueSwaps(chainID)
+	writeResponse(w, res, err)
+}
+
+// GetLiquidityHandler handler
+func GetLiquidityHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	chainID := vars["chainid"]
+	onlyLow := r.URL.Query().Get("onlylow") == "true"
+	res, err := swapapi.GetLiquidityInfos(chainID, onlyLow)
+	writeResponse(w, res, err)
+}
+
+// GetTokenAuditReportsHandler handler
+func GetTokenAuditReportsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tokenID := vars["tokenid"]
+	onlyDiscrepancy := r.URL.Query().Get("onlydiscrepancy") == "true"
+	res, err := swapapi.GetTokenAuditReports(tokenID, onlyDiscrepancy)
+	writeResponse(w, res, err)
+}
+
+// GetSwapQueueDepthHandler handler
+func GetSwapQueueDepthHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	chainID := vars["chainid"]
+	res, err := swapapi.GetSwapQueueDepths(chainID)
+	writeResponse(w, res, err)
+}
+
+// GetSwapVolumeStatsHandler handler
+func GetSwapVolumeStatsHandler(w http.ResponseWriter, r *http.Request) {
+	vals := r.URL.Query()
+	res, err := swapapi.GetSwapVolumeStats(vals.Get("start"), vals.Get("end"), vals.Get("tokenid"), vals.Get("fromchainid"), vals.Get("tochainid"))
+	writeResponse(w, res, err)
+}
+
+// GetSwapFailureStatsHandler handler
+func GetSwapFailureStatsHandler(w http.ResponseWriter, r *http.Request) {
+	vals := r.URL.Query()
+	res, err := swapapi.GetSwapFailureStats(vals.Get("start"), vals.Get("end"))
+	writeResponse(w, res, err)
+}
+
+// GetTopSwapSendersHandler handler
+func GetTopSwapSendersHandler(w http.ResponseWriter, r *http.Request) {
+	vals := r.URL.Query()
+	var limit int
+	if limitStr := vals.Get("limit"); limitStr != "" {
+		var err error
+		limit, err = common.GetIntFromStr(limitStr)
+		if err != nil {
+			writeResponse(w, nil, err)
+			return
+		}
+	}
+	res, err := swapapi.GetTopSwapSenders(vals.Get("start"), vals.Get("end"), vals.Get("tokenid"), limit)
+	writeResponse(w, res, err)
+}
+
 func getHistoryRequestVaules(r *http.Request) (offset, limit int, status string, err error) {
 	vals := r.URL.Query()
 
@@ -238,3 +359,15 @@ func GetFeeConfigHandler(w http.ResponseWriter, r *http.Request) {
 		writeResponse(w, swapConfig, nil)
 	}
 }
+
+// GetSwapQuoteHandler handler
+func GetSwapQuoteHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tokenID := vars["tokenid"]
+	fromChainID := vars["fromchainid"]
+	toChainID := vars["tochainid"]
+	amount := vars["amount"]
+	sender := r.URL.Query().Get("sender")
+	res, err := swapapi.GetSwapQuote(tokenID, fromChainID, toChainID, amount, sender)
+	writeResponse(w, res, err)
+}
@@ -2,6 +2,7 @@ package rpcapi
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"net/http"
 	"strings"
@@ -17,10 +18,30 @@ import (
 )
 
 const (
-	maintainCmd     = "maintain"
-	passbigvalueCmd = "passbigvalue"
-	reswapCmd       = "reswap"
-	replaceswapCmd  = "replaceswap"
+	maintainCmd      = "maintain"
+	passbigvalueCmd  = "passbigvalue"
+	holdbigvalueCmd  = "holdbigvalue"
+	unholdbigvalCmd  = "unholdbigvalue"
+	cancelbigvalCmd  = "cancelbigvalue"
+	reswapCmd        = "reswap"
+	replaceswapCmd   = "replaceswap"
+	rebroadcastCmd   = "rebroadcast"
+	cancelswapCmd    = "cancelswap"
+	desttagCmd       = "desttag"
+	anycallbudgetCmd = "anycallbudget"
+	refundCmd        = "refund"
+	reverifyCmd      = "reverify"
+	approvescreenCmd = "approvescreening"
+	rejectscreenCmd  = "rejectscreening"
+	drainCmd         = "drain"
+	loglevelCmd      = "loglevel"
+
+	// desttag actions
+	actAdd    = "add"
+	actRemove = "remove"
+
+	// anycallbudget actions
+	actDeposit = "deposit"
 
 	// maintain actions
 	actPause       = "pause"
@@ -30,6 +51,14 @@ const (
 	actBlacklist   = "blacklist"
 	actUnblacklist = "unblacklist"
 
+	// drain actions
+	actStart = "start"
+	actStop  = "stop"
+
+	// loglevel arguments
+	globalLogModule = "global"
+	resetLogLevel   = "reset"
+
 	successReuslt = "Success"
 )
 
@@ -49,7 +78,8 @@ func (s *RouterSwapAPI) AdminCall(r *http.Request, rawTx, result *string) (err e
 	senderAddress := sender.String()
 	if !params.IsRouterAdmin(senderAddress) {
 		switch args.Method {
-		case reswapCmd:
+		case reswapCmd, cancelswapCmd, desttagCmd, anycallbudgetCmd, refundCmd, cancelbigvalCmd, unholdbigvalCmd,
+			approvescreenCmd, rejectscreenCmd, drainCmd, loglevelCmd:
 			return fmt.Errorf("sender %v is not admin", senderAddress)
 		case maintainCmd:
 			action := args.Params[0]
@@ -57,7 +87,7 @@ func (s *RouterSwapAPI) AdminCall(r *http.Request, rawTx, result *string) (err e
 			case actPause, actUnpause:
 				return fmt.Errorf("sender %v is not admin", senderAddress)
 			}
-		case passbigvalueCmd, replaceswapCmd:
+		case passbigvalueCmd, holdbigvalueCmd, replaceswapCmd, rebroadcastCmd, reverifyCmd:
 		default:
 			return fmt.Errorf("unknown admin method '%v'", args.Method)
 		}
@@ -75,10 +105,36 @@ func doRouterAdminCall(args *admin.CallArgs, result *string) error {
 		return maintain(args, result)
 	case passbigvalueCmd:
 		return routerPassBigValue(args, result)
+	case holdbigvalueCmd:
+		return routerHoldBigValue(args, result)
+	case unholdbigvalCmd:
+		return routerUnholdBigValue(args, result)
+	case cancelbigvalCmd:
+		return routerCancelBigValue(args, result)
+	case approvescreenCmd:
+		return routerApproveScreening(args, result)
+	case rejectscreenCmd:
+		return routerRejectScreening(args, result)
 	case reswapCmd:
 		return routerReswap(args, result)
 	case replaceswapCmd:
 		return routerReplaceSwap(args, result)
+	case rebroadcastCmd:
+		return routerRebroadcastSwap(args, result)
+	case cancelswapCmd:
+		return routerCancelSwap(args, result)
+	case desttagCmd:
+		return routerDestTag(args, result)
+	case anycallbudgetCmd:
+		return routerAnyCallBudget(args, result)
+	case refundCmd:
+		return routerRefund(args, result)
+	case reverifyCmd:
+		return routerReverify(args, result)
+	case drainCmd:
+		return drain(args, result)
+	case loglevelCmd:
+		return setLogLevel(args, result)
 	default:
 		return fmt.Errorf("unknown admin method '%v'", args.Method)
 	}
@@ -165,6 +221,77 @@ func maintain(args *admin.CallArgs, result *string) (err error) {
 	return nil
 }
 
+func drain(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) < 2 {
+		return fmt.Errorf("wrong number of params, have %v want at least 2", len(args.Params))
+	}
+	action := args.Params[0]
+	arguments := args.Params[1]
+
+	var chainIDs []string
+	if !strings.EqualFold(arguments, "all") {
+		for _, chainID := range strings.Split(arguments, ",") {
+			if _, err = common.GetBigIntFromStr(chainID); err != nil || chainID == "" {
+				return fmt.Errorf("wrong chain id '%v'", chainID)
+			}
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+
+	switch action {
+	case actStart:
+		var timeout uint64
+		if len(args.Params) > 2 {
+			timeout, err = common.GetUint64FromStr(args.Params[2])
+			if err != nil {
+				return fmt.Errorf("wrong timeout '%v'", args.Params[2])
+			}
+		}
+		err = worker.StartDrain(chainIDs, int64(timeout))
+	case actStop:
+		err = worker.StopDrain(chainIDs)
+	default:
+		return fmt.Errorf("unknown drain action '%v'", action)
+	}
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+func setLogLevel(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 2 {
+		return fmt.Errorf("wrong number of params, have %v want 2", len(args.Params))
+	}
+	module := args.Params[0]
+	levelStr := args.Params[1]
+	if module == "" {
+		return fmt.Errorf("empty log module")
+	}
+
+	if strings.EqualFold(levelStr, resetLogLevel) {
+		if strings.EqualFold(module, globalLogModule) {
+			return fmt.Errorf("can not reset global log level")
+		}
+		log.ResetModuleLevel(module)
+		*result = successReuslt
+		return nil
+	}
+
+	level, err := log.ParseLevel(levelStr)
+	if err != nil {
+		return fmt.Errorf("wrong log level '%v'", levelStr)
+	}
+	if strings.EqualFold(module, globalLogModule) {
+		log.SetLevel(level)
+	} else {
+		log.SetModuleLevel(module, level)
+	}
+	*result = successReuslt
+	return nil
+}
+
 func getGasPrice(args *admin.CallArgs, startPos int) (gasPrice *big.Int, err error) {
 	if len(args.Params) < startPos+1 {
 		err = fmt.Errorf("wrong number of params, have %v want at least %v", len(args.Params), startPos+3)
@@ -207,6 +334,79 @@ func routerPassBigValue(args *admin.CallArgs, result *string) (err error) {
 	return nil
 }
 
+func routerHoldBigValue(args *admin.CallArgs, result *string) (err error) {
+	chainID, txid, logIndex, err := getKeys(args, 0)
+	if err != nil {
+		return err
+	}
+	err = mongodb.RouterAdminHoldBigValue(chainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+func routerUnholdBigValue(args *admin.CallArgs, result *string) (err error) {
+	chainID, txid, logIndex, err := getKeys(args, 0)
+	if err != nil {
+		return err
+	}
+	err = mongodb.RouterAdminUnholdBigValue(chainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+func routerCancelBigValue(args *admin.CallArgs, result *string) (err error) {
+	chainID, txid, logIndex, err := getKeys(args, 0)
+	if err != nil {
+		return err
+	}
+	reason := tokens.ErrBigValueSwapCanceled
+	if len(args.Params) > 3 && args.Params[3] != "" {
+		reason = fmt.Errorf("%w: %v", reason, args.Params[3])
+	}
+	err = mongodb.RouterAdminCancelBigValue(chainID, txid, logIndex, reason)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+func routerApproveScreening(args *admin.CallArgs, result *string) (err error) {
+	chainID, txid, logIndex, err := getKeys(args, 0)
+	if err != nil {
+		return err
+	}
+	err = mongodb.RouterAdminApproveScreening(chainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+func routerRejectScreening(args *admin.CallArgs, result *string) (err error) {
+	chainID, txid, logIndex, err := getKeys(args, 0)
+	if err != nil {
+		return err
+	}
+	var reason error
+	if len(args.Params) > 3 && args.Params[3] != "" {
+		reason = fmt.Errorf("%w: %v", tokens.ErrSwapFlaggedByScreen, args.Params[3])
+	}
+	err = mongodb.RouterAdminRejectScreening(chainID, txid, logIndex, reason)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
 func routerReswap(args *admin.CallArgs, result *string) (err error) {
 	chainID, txid, logIndex, err := getKeys(args, 0)
 	if err != nil {
@@ -221,6 +421,47 @@ func routerReswap(args *admin.CallArgs, result *string) (err error) {
 	return nil
 }
 
+func routerRefund(args *admin.CallArgs, result *string) (err error) {
+	chainID, txid, logIndex, err := getKeys(args, 0)
+	if err != nil {
+		return err
+	}
+	bridge := router.GetBridgeByChainID(chainID)
+	if bridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	if _, ok := bridge.(tokens.TxRefunder); !ok {
+		return tokens.ErrNotImplemented
+	}
+	swap, err := mongodb.FindRouterSwap(chainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if worker.IsRefundBlacked(chainID, swap.From) {
+		return tokens.ErrSwapInBlacklist
+	}
+	err = mongodb.RouterAdminApproveRefund(chainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	worker.DeleteCachedSwap(chainID, txid, logIndex)
+	*result = successReuslt
+	return nil
+}
+
+func routerReverify(args *admin.CallArgs, result *string) (err error) {
+	chainID, txid, logIndex, err := getKeys(args, 0)
+	if err != nil {
+		return err
+	}
+	err = worker.ReverifyRouterSwap(chainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
 func routerReplaceSwap(args *admin.CallArgs, result *string) (err error) {
 	chainID, txid, logIndex, err := getKeys(args, 0)
 	if err != nil {
@@ -241,3 +482,106 @@ func routerReplaceSwap(args *admin.CallArgs, result *string) (err error) {
 	*result = successReuslt
 	return nil
 }
+
+func routerRebroadcastSwap(args *admin.CallArgs, result *string) (err error) {
+	chainID, txid, logIndex, err := getKeys(args, 0)
+	if err != nil {
+		return err
+	}
+	err = worker.RebroadcastRouterSwap(chainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+func routerCancelSwap(args *admin.CallArgs, result *string) (err error) {
+	chainID, txid, logIndex, err := getKeys(args, 0)
+	if err != nil {
+		return err
+	}
+	res, err := mongodb.FindRouterSwapResult(chainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	err = worker.CancelRouterSwap(res, "admin cancel", true)
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+func routerDestTag(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) < 3 {
+		return fmt.Errorf("wrong number of params, have %v want at least 3", len(args.Params))
+	}
+	action := args.Params[0]
+	chainID := args.Params[1]
+	if router.GetBridgeByChainID(chainID) == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	tagStr := args.Params[2]
+	tag, err := common.GetUint64FromStr(tagStr)
+	if err != nil || tag > math.MaxUint32 {
+		return fmt.Errorf("wrong tag '%v'", tagStr)
+	}
+	switch action {
+	case actAdd:
+		if len(args.Params) != 5 {
+			return fmt.Errorf("wrong number of params, have %v want 5", len(args.Params))
+		}
+		bind := args.Params[3]
+		toChainID := args.Params[4]
+		dstBridge := router.GetBridgeByChainID(toChainID)
+		if dstBridge == nil {
+			return tokens.ErrNoBridgeForChainID
+		}
+		if !dstBridge.IsValidAddress(bind) {
+			return fmt.Errorf("wrong bind address '%v'", bind)
+		}
+		err = mongodb.RouterAdminAddDestTag(chainID, tag, bind, toChainID)
+	case actRemove:
+		err = mongodb.RouterAdminRemoveDestTag(chainID, tag)
+	default:
+		return fmt.Errorf("unknown desttag action '%v'", action)
+	}
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
+
+func routerAnyCallBudget(args *admin.CallArgs, result *string) (err error) {
+	if len(args.Params) != 4 {
+		return fmt.Errorf("wrong number of params, have %v want 4", len(args.Params))
+	}
+	action := args.Params[0]
+	chainID := args.Params[1]
+	callFrom := args.Params[2]
+	amountStr := args.Params[3]
+	dstBridge := router.GetBridgeByChainID(chainID)
+	if dstBridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	if !dstBridge.IsValidAddress(callFrom) {
+		return fmt.Errorf("wrong call from address '%v'", callFrom)
+	}
+	amount, err := common.GetBigIntFromStr(amountStr)
+	if err != nil || amount.Sign() <= 0 {
+		return fmt.Errorf("wrong amount '%v'", amountStr)
+	}
+	switch action {
+	case actDeposit:
+		err = mongodb.RouterAdminDepositAnyCallBudget(chainID, callFrom, amount)
+	default:
+		return fmt.Errorf("unknown anycallbudget action '%v'", action)
+	}
+	if err != nil {
+		return err
+	}
+	*result = successReuslt
+	return nil
+}
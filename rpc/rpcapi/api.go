@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/anyswap/CrossChain-Router/v3/internal/swapapi"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
@@ -49,6 +50,17 @@ func (s *RouterSwapAPI) GetOracleInfo(r *http.Request, args *RPCNullArgs, result
 	return nil
 }
 
+// GetMPCHealthArgs args
+type GetMPCHealthArgs struct {
+	WithEnodes bool `json:"withEnodes"`
+}
+
+// GetMPCHealth api
+func (s *RouterSwapAPI) GetMPCHealth(r *http.Request, args *GetMPCHealthArgs, result *swapapi.MPCHealthInfo) error {
+	*result = *swapapi.GetMPCHealth(args.WithEnodes)
+	return nil
+}
+
 type getStatusInfoResult map[string]interface{}
 
 // GetStatusInfo api
@@ -101,6 +113,157 @@ func (s *RouterSwapAPI) GetRouterSwap(r *http.Request, args *RouterSwapKeyArgs,
 	return err
 }
 
+// GetRouterSwapSignedTxs api
+func (s *RouterSwapAPI) GetRouterSwapSignedTxs(r *http.Request, args *RouterSwapKeyArgs, result *[]*swapapi.SignedTxInfo) error {
+	res, err := swapapi.GetRouterSwapSignedTxs(args.ChainID, args.TxID, args.LogIndex)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetRouterSwapReplaceDecisions api
+func (s *RouterSwapAPI) GetRouterSwapReplaceDecisions(r *http.Request, args *RouterSwapKeyArgs, result *[]*mongodb.MgoReplaceDecision) error {
+	res, err := swapapi.GetRouterSwapReplaceDecisions(args.ChainID, args.TxID, args.LogIndex)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetDestTagArgs args
+type GetDestTagArgs struct {
+	ChainID string `json:"chainid"`
+	Tag     string `json:"tag"`
+}
+
+// GetDestTag api
+func (s *RouterSwapAPI) GetDestTag(r *http.Request, args *GetDestTagArgs, result *mongodb.MgoDestTag) error {
+	res, err := swapapi.GetDestTag(args.ChainID, args.Tag)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetAnyCallLedgerArgs args
+type GetAnyCallLedgerArgs struct {
+	ChainID string `json:"chainid"`
+	Address string `json:"address"`
+}
+
+// GetAnyCallBudget api
+func (s *RouterSwapAPI) GetAnyCallBudget(r *http.Request, args *GetAnyCallLedgerArgs, result *mongodb.MgoAnyCallLedger) error {
+	res, err := swapapi.GetAnyCallBudget(args.ChainID, args.Address)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetAnyCallDAppUsage api
+func (s *RouterSwapAPI) GetAnyCallDAppUsage(r *http.Request, args *GetAnyCallLedgerArgs, result *mongodb.MgoAnyCallLedger) error {
+	res, err := swapapi.GetAnyCallDAppUsage(args.ChainID, args.Address)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetAnyCallDAppUsages api
+func (s *RouterSwapAPI) GetAnyCallDAppUsages(r *http.Request, chainID *string, result *[]*mongodb.MgoAnyCallLedger) error {
+	res, err := swapapi.GetAnyCallDAppUsages(*chainID)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetPendingBigValueSwaps api
+func (s *RouterSwapAPI) GetPendingBigValueSwaps(r *http.Request, chainID *string, result *[]*swapapi.BigValueSwapInfo) error {
+	res, err := swapapi.GetPendingBigValueSwaps(*chainID)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetSwapQueueDepth api
+func (s *RouterSwapAPI) GetSwapQueueDepth(r *http.Request, chainID *string, result *map[string]map[string]int) error {
+	res, err := swapapi.GetSwapQueueDepths(*chainID)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetSwapStatsArgs args
+type GetSwapStatsArgs struct {
+	StartDay    string `json:"startday"`
+	EndDay      string `json:"endday"`
+	TokenID     string `json:"tokenid"`
+	FromChainID string `json:"fromchainid"`
+	ToChainID   string `json:"tochainid"`
+	Limit       int    `json:"limit"`
+}
+
+// GetSwapVolumeStats api
+func (s *RouterSwapAPI) GetSwapVolumeStats(r *http.Request, args *GetSwapStatsArgs, result *[]*mongodb.MgoSwapVolumeRollup) error {
+	res, err := swapapi.GetSwapVolumeStats(args.StartDay, args.EndDay, args.TokenID, args.FromChainID, args.ToChainID)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetSwapFailureStats api
+func (s *RouterSwapAPI) GetSwapFailureStats(r *http.Request, args *GetSwapStatsArgs, result *swapapi.SwapFailureStats) error {
+	res, err := swapapi.GetSwapFailureStats(args.StartDay, args.EndDay)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetTopSwapSenders api
+func (s *RouterSwapAPI) GetTopSwapSenders(r *http.Request, args *GetSwapStatsArgs, result *[]*mongodb.MgoSwapSenderRollup) error {
+	res, err := swapapi.GetTopSwapSenders(args.StartDay, args.EndDay, args.TokenID, args.Limit)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetLiquidityArgs args
+type GetLiquidityArgs struct {
+	ChainID string `json:"chainid"`
+	OnlyLow bool   `json:"onlylow"`
+}
+
+// GetLiquidity api
+func (s *RouterSwapAPI) GetLiquidity(r *http.Request, args *GetLiquidityArgs, result *[]*router.LiquidityInfo) error {
+	res, err := swapapi.GetLiquidityInfos(args.ChainID, args.OnlyLow)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetTokenAuditReportsArgs args
+type GetTokenAuditReportsArgs struct {
+	TokenID         string `json:"tokenid"`
+	OnlyDiscrepancy bool   `json:"onlydiscrepancy"`
+}
+
+// GetTokenAuditReports api
+func (s *RouterSwapAPI) GetTokenAuditReports(r *http.Request, args *GetTokenAuditReportsArgs, result *[]*router.TokenAuditReport) error {
+	res, err := swapapi.GetTokenAuditReports(args.TokenID, args.OnlyDiscrepancy)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
 // RouterGetSwapHistoryArgs args
 type RouterGetSwapHistoryArgs struct {
 	ChainID string `json:"chainid"`
@@ -205,3 +368,21 @@ func (s *RouterSwapAPI) GetFeeConfig(r *http.Request, args *GetSwapConfigArgs, r
 	}
 	return fmt.Errorf("fee config not found")
 }
+
+// GetSwapQuoteArgs args
+type GetSwapQuoteArgs struct {
+	TokenID     string `json:"tokenid"`
+	FromChainID string `json:"fromchainid"`
+	ToChainID   string `json:"tochainid"`
+	Amount      string `json:"amount"`
+	Sender      string `json:"sender"`
+}
+
+// GetSwapQuote api
+func (s *RouterSwapAPI) GetSwapQuote(r *http.Request, args *GetSwapQuoteArgs, result *swapapi.SwapQuote) error {
+	quote, err := swapapi.GetSwapQuote(args.TokenID, args.FromChainID, args.ToChainID, args.Amount, args.Sender)
+	if err == nil && quote != nil {
+		*result = *quote
+	}
+	return err
+}
@@ -131,8 +131,11 @@ func initRouterSwapRouter(r *mux.Router) {
 	r.HandleFunc("/serverinfo", restapi.ServerInfoHandler).Methods("GET")
 	r.HandleFunc("/oracleinfo", restapi.OracleInfoHandler).Methods("GET")
 	r.HandleFunc("/statusinfo", restapi.StatusInfoHandler).Methods("GET")
+	r.HandleFunc("/mpchealth", restapi.MPCHealthHandler).Methods("GET")
 	r.HandleFunc("/swap/register/{chainid}/{txid}", restapi.RegisterRouterSwapHandler).Methods("POST")
 	r.HandleFunc("/swap/status/{chainid}/{txid}", restapi.GetRouterSwapHandler).Methods("GET")
+	r.HandleFunc("/swap/signedtxs/{chainid}/{txid}", restapi.GetRouterSwapSignedTxsHandler).Methods("GET")
+	r.HandleFunc("/swap/replacedecisions/{chainid}/{txid}", restapi.GetRouterSwapReplaceDecisionsHandler).Methods("GET")
 	r.HandleFunc("/swap/history/{chainid}/{address}", restapi.GetRouterSwapHistoryHandler).Methods("GET")
 
 	r.HandleFunc("/allchainids", restapi.GetAllChainIDsHandler).Methods("GET")
@@ -142,4 +145,20 @@ func initRouterSwapRouter(r *mux.Router) {
 	r.HandleFunc("/tokenconfig/{chainid}/{address:.*}", restapi.GetTokenConfigHandler).Methods("GET")
 	r.HandleFunc("/swapconfig/{tokenid}/{fromchainid}/{tochainid}", restapi.GetSwapConfigHandler).Methods("GET")
 	r.HandleFunc("/feeconfig/{tokenid}/{fromchainid}/{tochainid}", restapi.GetFeeConfigHandler).Methods("GET")
+	r.HandleFunc("/quote/{tokenid}/{fromchainid}/{tochainid}/{amount}", restapi.GetSwapQuoteHandler).Methods("GET")
+	r.HandleFunc("/desttag/{chainid}/{tag}", restapi.GetDestTagHandler).Methods("GET")
+	r.HandleFunc("/bigvalue/pending", restapi.GetPendingBigValueSwapsHandler).Methods("GET")
+	r.HandleFunc("/bigvalue/pending/{chainid}", restapi.GetPendingBigValueSwapsHandler).Methods("GET")
+	r.HandleFunc("/liquidity", restapi.GetLiquidityHandler).Methods("GET")
+	r.HandleFunc("/liquidity/{chainid}", restapi.GetLiquidityHandler).Methods("GET")
+	r.HandleFunc("/tokenaudit", restapi.GetTokenAuditReportsHandler).Methods("GET")
+	r.HandleFunc("/tokenaudit/{tokenid}", restapi.GetTokenAuditReportsHandler).Methods("GET")
+	r.HandleFunc("/swapqueue", restapi.GetSwapQueueDepthHandler).Methods("GET")
+	r.HandleFunc("/swapqueue/{chainid}", restapi.GetSwapQueueDepthHandler).Methods("GET")
+	r.HandleFunc("/analytics/volume", restapi.GetSwapVolumeStatsHandler).Methods("GET")
+	r.HandleFunc("/analytics/failures", restapi.GetSwapFailureStatsHandler).Methods("GET")
+	r.HandleFunc("/analytics/senders", restapi.GetTopSwapSendersHandler).Methods("GET")
+	r.HandleFunc("/anycall/budget/{chainid}/{callfrom}", restapi.GetAnyCallBudgetHandler).Methods("GET")
+	r.HandleFunc("/anycall/usage/{chainid}", restapi.GetAnyCallDAppUsagesHandler).Methods("GET")
+	r.HandleFunc("/anycall/usage/{chainid}/{dapp}", restapi.GetAnyCallDAppUsageHandler).Methods("GET")
 }
@@ -0,0 +1,97 @@
+package screening
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+// FileProvider screen address by local file which contains
+// one flagged address per line (lines starting with '#' are ignored).
+// the file is reloaded automatically when it is modified,
+// and its results are not cached to reflect the modification immediately.
+type FileProvider struct {
+	filePath string
+
+	lock      sync.RWMutex
+	addresses map[string]struct{}
+	modTime   time.Time
+}
+
+// NewFileProvider new file provider
+func NewFileProvider(cfg *params.ScreeningConfig) *FileProvider {
+	return &FileProvider{
+		filePath:  cfg.FilePath,
+		addresses: make(map[string]struct{}),
+	}
+}
+
+// Name impl Provider
+func (p *FileProvider) Name() string {
+	return params.ScreeningProviderFile
+}
+
+// Cacheable impl Provider (the file is reloaded once modified)
+func (p *FileProvider) Cacheable() bool {
+	return false
+}
+
+// Screen impl Provider
+func (p *FileProvider) Screen(chainID, address string) (*Result, error) {
+	if err := p.reloadIfModified(); err != nil {
+		return nil, err
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	_, flagged := p.addresses[strings.ToLower(address)]
+	result := &Result{Flagged: flagged}
+	if flagged {
+		result.Reason = "in screening file"
+	}
+	return result, nil
+}
+
+func (p *FileProvider) reloadIfModified() error {
+	info, err := os.Stat(p.filePath)
+	if err != nil {
+		return err
+	}
+	p.lock.RLock()
+	isModified := !info.ModTime().Equal(p.modTime)
+	p.lock.RUnlock()
+	if !isModified {
+		return nil
+	}
+
+	file, err := os.Open(p.filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	addresses := make(map[string]struct{})
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addresses[strings.ToLower(line)] = struct{}{}
+	}
+	if err = scanner.Err(); err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	p.addresses = addresses
+	p.modTime = info.ModTime()
+	p.lock.Unlock()
+
+	log.Info("reload screening file success", "file", p.filePath, "count", len(addresses))
+	return nil
+}
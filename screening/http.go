@@ -0,0 +1,54 @@
+package screening
+
+import (
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
+)
+
+var defHTTPTimeout = 10 // seconds
+
+// HTTPProvider screen address by requesting external http service
+// with 'GET APIAddress?chainid=xxx&address=xxx',
+// and the response is like '{"flagged":true,"reason":"xxx"}'
+type HTTPProvider struct {
+	apiAddress string
+	headers    map[string]string
+	timeout    int
+}
+
+// NewHTTPProvider new http provider
+func NewHTTPProvider(cfg *params.ScreeningConfig) *HTTPProvider {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defHTTPTimeout
+	}
+	return &HTTPProvider{
+		apiAddress: cfg.APIAddress,
+		headers:    cfg.Headers,
+		timeout:    timeout,
+	}
+}
+
+// Name impl Provider
+func (p *HTTPProvider) Name() string {
+	return params.ScreeningProviderHTTP
+}
+
+// Cacheable impl Provider
+func (p *HTTPProvider) Cacheable() bool {
+	return true
+}
+
+// Screen impl Provider
+func (p *HTTPProvider) Screen(chainID, address string) (*Result, error) {
+	reqParams := map[string]string{
+		"chainid": chainID,
+		"address": address,
+	}
+	var result Result
+	err := client.RPCGetRequest(&result, p.apiAddress, reqParams, p.headers, p.timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,140 @@
+// Package screening screens swap addresses against external sanctions or risk services.
+package screening
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	provider     Provider
+	providerOnce sync.Once
+
+	cachedResults = new(sync.Map) // key is chainID:address
+
+	defCacheTTL = int64(3600) // seconds
+)
+
+// Result screening result
+type Result struct {
+	Flagged bool   `json:"flagged"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// Provider interface of screening provider
+type Provider interface {
+	Name() string
+	Screen(chainID, address string) (*Result, error)
+	// Cacheable returns if the screening results can be cached,
+	// local providers which are cheap to query should not be cached.
+	Cacheable() bool
+}
+
+type cachedResult struct {
+	result   *Result
+	expireAt int64
+}
+
+// IsEnabled is screening enabled
+func IsEnabled() bool {
+	return params.GetScreeningConfig() != nil
+}
+
+// IsFailClosed hold swap if screening failed
+func IsFailClosed() bool {
+	cfg := params.GetScreeningConfig()
+	return cfg != nil && cfg.FailClosed
+}
+
+func getProvider() Provider {
+	providerOnce.Do(func() {
+		cfg := params.GetScreeningConfig()
+		if cfg == nil {
+			return
+		}
+		switch cfg.Provider {
+		case params.ScreeningProviderHTTP:
+			provider = NewHTTPProvider(cfg)
+		case params.ScreeningProviderFile:
+			provider = NewFileProvider(cfg)
+		}
+		if provider != nil {
+			log.Info("init screening provider success", "provider", provider.Name())
+		}
+	})
+	return provider
+}
+
+// SetProvider set custom screening provider
+func SetProvider(p Provider) {
+	providerOnce.Do(func() {})
+	provider = p
+}
+
+func getCacheTTL() int64 {
+	if cfg := params.GetScreeningConfig(); cfg != nil && cfg.CacheTTL > 0 {
+		return cfg.CacheTTL
+	}
+	return defCacheTTL
+}
+
+// ScreenAddress screen address with cache
+func ScreenAddress(chainID, address string) (*Result, error) {
+	p := getProvider()
+	if p == nil || address == "" {
+		return &Result{}, nil
+	}
+	if !p.Cacheable() {
+		return screenAddress(p, chainID, address)
+	}
+	key := strings.ToLower(fmt.Sprintf("%s:%s", chainID, address))
+	now := time.Now().Unix()
+	if cached, exist := cachedResults.Load(key); exist {
+		if c := cached.(*cachedResult); c.expireAt > now {
+			return c.result, nil
+		}
+		cachedResults.Delete(key)
+	}
+	result, err := screenAddress(p, chainID, address)
+	if err != nil {
+		return nil, err
+	}
+	cachedResults.Store(key, &cachedResult{result: result, expireAt: now + getCacheTTL()})
+	return result, nil
+}
+
+func screenAddress(p Provider, chainID, address string) (*Result, error) {
+	result, err := p.Screen(chainID, address)
+	if err != nil {
+		log.Warn("screen address failed", "provider", p.Name(), "chainID", chainID, "address", address, "err", err)
+		return nil, err
+	}
+	if result.Flagged {
+		log.Warn("address is flagged by screening", "provider", p.Name(), "chainID", chainID, "address", address, "reason", result.Reason)
+	}
+	return result, nil
+}
+
+// ScreenAddresses screen addresses.
+// returns error wrapping tokens.ErrSwapFlaggedByScreen if any address is flagged.
+func ScreenAddresses(chainID string, addresses ...string) error {
+	for _, address := range addresses {
+		result, err := ScreenAddress(chainID, address)
+		if err != nil {
+			return err
+		}
+		if result.Flagged {
+			if result.Reason != "" {
+				return fmt.Errorf("%w: %v (%v)", tokens.ErrSwapFlaggedByScreen, address, result.Reason)
+			}
+			return fmt.Errorf("%w: %v", tokens.ErrSwapFlaggedByScreen, address)
+		}
+	}
+	return nil
+}
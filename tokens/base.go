@@ -3,6 +3,7 @@ package tokens
 import (
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -13,7 +14,8 @@ import (
 )
 
 var (
-	routerSwapType SwapType
+	routerSwapType  SwapType
+	routerSwapTypes []SwapType
 
 	swapConfigMap = new(sync.Map) // key is tokenID,fromChainID,toChainID
 	feeConfigMap  = new(sync.Map) // key is tokenID,fromChainID,toChainID
@@ -28,26 +30,77 @@ func IsNativeCoin(name string) bool {
 }
 
 // InitRouterSwapType init router swap type
-//nolint:goconst // allow dupl constant string
+// the additional swap types in the 'SwapTypes' config are enabled as well
 func InitRouterSwapType(swapTypeStr string) {
+	swapType, err := ParseSwapType(swapTypeStr)
+	if err != nil {
+		log.Fatal("init router swap type failed", "err", err)
+	}
+	routerSwapType = swapType
+	routerSwapTypes = []SwapType{swapType}
+	if cfg := params.GetRouterConfig(); cfg != nil {
+		extraTypes := make([]SwapType, 0, len(cfg.SwapTypes))
+		for str := range cfg.SwapTypes {
+			extraType, errp := ParseSwapType(str)
+			if errp != nil {
+				log.Fatal("init router swap type failed", "err", errp)
+			}
+			extraTypes = append(extraTypes, extraType)
+		}
+		sort.Slice(extraTypes, func(i, j int) bool { return extraTypes[i] < extraTypes[j] })
+		routerSwapTypes = append(routerSwapTypes, extraTypes...)
+	}
+	log.Info("init router swap type success", "swaptype", routerSwapType.String(), "swaptypes", routerSwapTypes)
+}
+
+// ParseSwapType parse swap type from string
+//nolint:goconst // allow dupl constant string
+func ParseSwapType(swapTypeStr string) (SwapType, error) {
 	switch strings.ToLower(swapTypeStr) {
 	case "erc20swap":
-		routerSwapType = ERC20SwapType
+		return ERC20SwapType, nil
 	case "nftswap":
-		routerSwapType = NFTSwapType
+		return NFTSwapType, nil
 	case "anycallswap":
-		routerSwapType = AnyCallSwapType
+		return AnyCallSwapType, nil
 	default:
-		log.Fatalf("invalid router swap type '%v'", swapTypeStr)
+		return UnknownSwapType, fmt.Errorf("invalid router swap type '%v'", swapTypeStr)
 	}
-	log.Info("init router swap type success", "swaptype", routerSwapType.String())
 }
 
-// GetRouterSwapType get router swap type
+// GetRouterSwapType get router swap type (the main swap type)
 func GetRouterSwapType() SwapType {
 	return routerSwapType
 }
 
+// GetRouterSwapTypes get all enabled router swap types (the main swap type is the first)
+func GetRouterSwapTypes() []SwapType {
+	return routerSwapTypes
+}
+
+// IsSwapTypeEnabled is swap type enabled in this router
+func IsSwapTypeEnabled(swapType SwapType) bool {
+	for _, t := range routerSwapTypes {
+		if t == swapType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTokenSwapType get swap type of token ID
+// tokens are of the main swap type unless listed in the 'SwapTypes' config
+func GetTokenSwapType(tokenID string) SwapType {
+	if cfg := params.GetRouterConfig(); cfg == nil || len(cfg.SwapTypes) == 0 {
+		return routerSwapType
+	}
+	swapType, err := ParseSwapType(params.GetTokenIDSwapType(tokenID))
+	if err != nil {
+		return routerSwapType
+	}
+	return swapType
+}
+
 // IsERC20Router is erc20 router
 func IsERC20Router() bool {
 	return routerSwapType == ERC20SwapType
@@ -150,6 +203,15 @@ func (b *CrossChainBridgeBase) GetRouterContract(token string) string {
 	return b.ChainConfig.RouterContract
 }
 
+// GetSwapTypeRouterContract get router contract of swap type
+// use the router contract in 'SwapTypes' config if exist, otherwise the chain router contract
+func (b *CrossChainBridgeBase) GetSwapTypeRouterContract(swapType SwapType) string {
+	if routerContract := params.GetSwapTypeRouterContract(swapType.String(), b.ChainConfig.ChainID); routerContract != "" {
+		return routerContract
+	}
+	return b.ChainConfig.RouterContract
+}
+
 // SetSwapConfigs set swap configs
 func SetSwapConfigs(swapCfgs *sync.Map) {
 	swapConfigMap = swapCfgs
@@ -205,7 +267,7 @@ func GetBigValueThreshold(tokenID, fromChainID, toChainID string, fromDecimals u
 
 // CheckTokenSwapValue check swap value is in right range
 func CheckTokenSwapValue(swapInfo *SwapTxInfo, fromDecimals, toDecimals uint8) bool {
-	if !IsERC20Router() {
+	if swapInfo.SwapType != ERC20SwapType {
 		return true
 	}
 	value := swapInfo.Value
@@ -232,61 +294,118 @@ func CheckTokenSwapValue(swapInfo *SwapTxInfo, fromDecimals, toDecimals uint8) b
 	return CalcSwapValue(tokenID, fromChainID, toChainID, value, fromDecimals, toDecimals, swapInfo.From, swapInfo.TxTo).Sign() > 0
 }
 
+// CalcRefundValue calc refund value (get rid of refund fee)
+func CalcRefundValue(chainID string, value *big.Int) *big.Int {
+	if value == nil || value.Sign() <= 0 {
+		return big.NewInt(0)
+	}
+	refundFee := new(big.Int).Mul(value, new(big.Int).SetUint64(params.GetRefundFeeRate(chainID)))
+	refundFee.Div(refundFee, big.NewInt(1000000))
+	if value.Cmp(refundFee) <= 0 {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Sub(value, refundFee)
+}
+
 // CalcSwapValue calc swap value (get rid of fee and convert by decimals)
 func CalcSwapValue(tokenID, fromChainID, toChainID string, value *big.Int, fromDecimals, toDecimals uint8, originFrom, originTxTo string) *big.Int {
-	if !IsERC20Router() {
+	if GetTokenSwapType(tokenID) != ERC20SwapType {
 		return value
 	}
-	feeCfg := GetFeeConfig(tokenID, fromChainID, toChainID)
-	if feeCfg == nil {
+	fee := CalcSwapFee(tokenID, fromChainID, toChainID, value, fromDecimals, originFrom, originTxTo)
+	if fee == nil {
 		return big.NewInt(0)
 	}
 
 	valueLeft := value
-	if feeCfg.SwapFeeRatePerMillion > 0 {
-		var swapFee, adjustBaseFee *big.Int
-		minSwapFee := ConvertTokenValue(feeCfg.MinimumSwapFee, 18, fromDecimals)
-		if params.IsInBigValueWhitelist(tokenID, originFrom) ||
-			params.IsInBigValueWhitelist(tokenID, originTxTo) {
-			swapFee = minSwapFee
-		} else {
-			swapFee = new(big.Int).Mul(value, new(big.Int).SetUint64(feeCfg.SwapFeeRatePerMillion))
-			swapFee.Div(swapFee, big.NewInt(1000000))
-
-			if swapFee.Cmp(minSwapFee) < 0 {
-				swapFee = minSwapFee
-			} else {
-				maxSwapFee := ConvertTokenValue(feeCfg.MaximumSwapFee, 18, fromDecimals)
-				if swapFee.Cmp(maxSwapFee) > 0 {
-					swapFee = maxSwapFee
-				}
-			}
-
-			baseFeePercent := params.GetBaseFeePercent(toChainID)
-			if baseFeePercent != 0 && minSwapFee.Sign() > 0 {
-				adjustBaseFee = new(big.Int).Set(minSwapFee)
-				adjustBaseFee.Mul(adjustBaseFee, big.NewInt(baseFeePercent))
-				adjustBaseFee.Div(adjustBaseFee, big.NewInt(100))
-				swapFee = new(big.Int).Add(swapFee, adjustBaseFee)
-				if swapFee.Sign() < 0 {
-					swapFee = big.NewInt(0)
-				}
-			}
-		}
-
-		if value.Cmp(swapFee) <= 0 {
+	if fee.SwapFeeRatePerMillion > 0 {
+		if value.Cmp(fee.SwapFee) <= 0 {
 			log.Warn("check swap value failed",
 				"value", value, "tokenID", tokenID, "toChainID", toChainID,
-				"minSwapFee", minSwapFee, "adjustBaseFee", adjustBaseFee, "swapFee", swapFee)
+				"minSwapFee", fee.MinimumSwapFee, "adjustBaseFee", fee.AdjustBaseFee, "swapFee", fee.SwapFee)
 			return big.NewInt(0)
 		}
 
-		valueLeft = new(big.Int).Sub(value, swapFee)
+		valueLeft = new(big.Int).Sub(value, fee.SwapFee)
 	}
 
 	return ConvertTokenValue(valueLeft, fromDecimals, toDecimals)
 }
 
+// swap fee clamp kinds
+const (
+	SwapFeeClampNone = ""
+	SwapFeeClampMin  = "min"
+	SwapFeeClampMax  = "max"
+)
+
+// SwapFeeDetail swap fee breakdown (values are in from token decimals)
+type SwapFeeDetail struct {
+	SwapFeeRatePerMillion uint64
+	RateFee               *big.Int
+	MinimumSwapFee        *big.Int
+	MaximumSwapFee        *big.Int
+	Clamp                 string
+	IsWhitelisted         bool
+	BaseFeePercent        int64
+	AdjustBaseFee         *big.Int
+	SwapFee               *big.Int
+}
+
+// CalcSwapFee calc swap fee of value (nil if no fee config)
+func CalcSwapFee(tokenID, fromChainID, toChainID string, value *big.Int, fromDecimals uint8, originFrom, originTxTo string) *SwapFeeDetail {
+	feeCfg := GetFeeConfig(tokenID, fromChainID, toChainID)
+	if feeCfg == nil {
+		return nil
+	}
+
+	fee := &SwapFeeDetail{
+		SwapFeeRatePerMillion: feeCfg.SwapFeeRatePerMillion,
+		MinimumSwapFee:        ConvertTokenValue(feeCfg.MinimumSwapFee, 18, fromDecimals),
+		MaximumSwapFee:        ConvertTokenValue(feeCfg.MaximumSwapFee, 18, fromDecimals),
+		SwapFee:               big.NewInt(0),
+	}
+	if feeCfg.SwapFeeRatePerMillion == 0 {
+		return fee
+	}
+
+	minSwapFee := fee.MinimumSwapFee
+	if params.IsInBigValueWhitelist(tokenID, originFrom) ||
+		params.IsInBigValueWhitelist(tokenID, originTxTo) {
+		fee.IsWhitelisted = true
+		fee.SwapFee = minSwapFee
+		return fee
+	}
+
+	swapFee := new(big.Int).Mul(value, new(big.Int).SetUint64(feeCfg.SwapFeeRatePerMillion))
+	swapFee.Div(swapFee, big.NewInt(1000000))
+	fee.RateFee = new(big.Int).Set(swapFee)
+
+	if swapFee.Cmp(minSwapFee) < 0 {
+		swapFee = minSwapFee
+		fee.Clamp = SwapFeeClampMin
+	} else if swapFee.Cmp(fee.MaximumSwapFee) > 0 {
+		swapFee = fee.MaximumSwapFee
+		fee.Clamp = SwapFeeClampMax
+	}
+
+	baseFeePercent := params.GetBaseFeePercent(toChainID)
+	if baseFeePercent != 0 && minSwapFee.Sign() > 0 {
+		adjustBaseFee := new(big.Int).Set(minSwapFee)
+		adjustBaseFee.Mul(adjustBaseFee, big.NewInt(baseFeePercent))
+		adjustBaseFee.Div(adjustBaseFee, big.NewInt(100))
+		swapFee = new(big.Int).Add(swapFee, adjustBaseFee)
+		if swapFee.Sign() < 0 {
+			swapFee = big.NewInt(0)
+		}
+		fee.BaseFeePercent = baseFeePercent
+		fee.AdjustBaseFee = adjustBaseFee
+	}
+
+	fee.SwapFee = swapFee
+	return fee
+}
+
 // ToBits calc
 func ToBits(valueStr string, decimals uint8) *big.Int {
 	parts := strings.Split(valueStr, ".")
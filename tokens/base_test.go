@@ -0,0 +1,220 @@
+package tokens
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+const (
+	tFeeTokenID     = "FEETEST"
+	tFeeFromChainID = "1"
+	tFeeToChainID   = "2"
+	tFeeBaseChainID = "3"
+	tFeeWhitelisted = "0x1111111111111111111111111111111111111111"
+	tFeeCaller      = "0x2222222222222222222222222222222222222222"
+)
+
+func setTestFeeConfig(feeCfg *FeeConfig) {
+	toMap := new(sync.Map)
+	toMap.Store(tFeeToChainID, feeCfg)
+	toMap.Store(tFeeBaseChainID, feeCfg)
+	fromMap := new(sync.Map)
+	fromMap.Store(tFeeFromChainID, toMap)
+	feeCfgs := new(sync.Map)
+	feeCfgs.Store(tFeeTokenID, fromMap)
+	SetFeeConfigs(feeCfgs)
+}
+
+// anycall is the main swap type, erc20 is enabled by 'SwapTypes' config
+const tSecondaryERC20Config = `
+Identifier = "routerswap"
+SwapType = "anycallswap"
+
+[SwapTypes.erc20swap]
+Identifier = "routerswap-erc20"
+TokenIDs = ["` + tFeeTokenID + `"]
+`
+
+func loadTestRouterConfig(t *testing.T, content string) {
+	file, err := ioutil.TempFile("", "router-config-*.toml")
+	if err != nil {
+		t.Fatalf("create config file failed: %v", err)
+	}
+	defer os.Remove(file.Name())
+	if _, err = file.WriteString(content); err != nil {
+		t.Fatalf("write config file failed: %v", err)
+	}
+	_ = file.Close()
+	config := params.LoadRouterConfig(file.Name(), true, false)
+	if err = config.CheckSwapTypesConfig(); err != nil {
+		t.Fatalf("check swap types config failed: %v", err)
+	}
+	InitRouterSwapType(config.SwapType)
+}
+
+func TestCalcSwapValueOfSecondarySwapType(t *testing.T) {
+	oldSwapType, oldSwapTypes := routerSwapType, routerSwapTypes
+	defer func() { routerSwapType, routerSwapTypes = oldSwapType, oldSwapTypes }()
+	loadTestRouterConfig(t, tSecondaryERC20Config)
+
+	oldFeeCfgs := feeConfigMap
+	defer SetFeeConfigs(oldFeeCfgs)
+	setTestFeeConfig(&FeeConfig{
+		SwapFeeRatePerMillion: 1000, // 0.1%
+		MinimumSwapFee:        big.NewInt(1e18),
+		MaximumSwapFee:        big.NewInt(8e18),
+	})
+
+	if GetRouterSwapType() != AnyCallSwapType || !IsSwapTypeEnabled(ERC20SwapType) {
+		t.Fatalf("wrong router swap types %v", GetRouterSwapTypes())
+	}
+
+	tests := []struct {
+		name         string
+		tokenID      string
+		value        *big.Int
+		fromDecimals uint8
+		toDecimals   uint8
+		want         *big.Int
+	}{
+		// fee is 0.1% of 2000e18 = 2e18, then converted from 18 to 6 decimals
+		{"erc20 token gets rid of fee and converts decimals", tFeeTokenID, new(big.Int).Mul(big.NewInt(2000), big.NewInt(1e18)), 18, 6, big.NewInt(1998e6)},
+		{"erc20 token with value below fee", tFeeTokenID, big.NewInt(1e17), 18, 18, big.NewInt(0)},
+		{"token of main anycall swap type keeps value", "OTHER", big.NewInt(12345), 18, 6, big.NewInt(12345)},
+	}
+	for _, tt := range tests {
+		have := CalcSwapValue(tt.tokenID, tFeeFromChainID, tFeeToChainID, tt.value, tt.fromDecimals, tt.toDecimals, "", "")
+		if have.Cmp(tt.want) != 0 {
+			t.Errorf("%v: swap value is %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
+
+func TestCalcSwapFee(t *testing.T) {
+	oldExtra := params.GetExtraConfig()
+	defer func() { _ = params.SetExtraConfig(oldExtra) }()
+	err := params.SetExtraConfig(&params.ExtraConfig{
+		BaseFeePercent: map[string]int64{tFeeBaseChainID: 50},
+	})
+	if err != nil {
+		t.Fatalf("set extra config failed: %v", err)
+	}
+	params.AddOrRemoveBigValueWhitelist(tFeeTokenID, []string{tFeeWhitelisted}, true)
+	defer params.AddOrRemoveBigValueWhitelist(tFeeTokenID, []string{tFeeWhitelisted}, false)
+
+	oldFeeCfgs := feeConfigMap
+	defer SetFeeConfigs(oldFeeCfgs)
+
+	// fee config values are in 18 decimals
+	feeCfg := &FeeConfig{
+		SwapFeeRatePerMillion: 1000, // 0.1%
+		MinimumSwapFee:        big.NewInt(1e18),
+		MaximumSwapFee:        big.NewInt(8e18),
+	}
+
+	tests := []struct {
+		name       string
+		feeCfg     *FeeConfig
+		toChainID  string
+		value      *big.Int
+		decimals   uint8
+		originFrom string
+		wantNil    bool
+		wantFee    *big.Int
+		wantClamp  string
+		whitelist  bool
+	}{
+		{
+			name:    "no fee config",
+			wantNil: true,
+		},
+		{
+			name:      "zero fee rate",
+			feeCfg:    &FeeConfig{MinimumSwapFee: big.NewInt(1e18), MaximumSwapFee: big.NewInt(8e18)},
+			toChainID: tFeeToChainID,
+			value:     big.NewInt(1000),
+			decimals:  6,
+			wantFee:   big.NewInt(0),
+		},
+		{
+			name:      "rate fee",
+			feeCfg:    feeCfg,
+			toChainID: tFeeToChainID,
+			value:     big.NewInt(5000e6), // 5000 with 6 decimals
+			decimals:  6,
+			wantFee:   big.NewInt(5e6),
+		},
+		{
+			name:      "clamp to min fee",
+			feeCfg:    feeCfg,
+			toChainID: tFeeToChainID,
+			value:     big.NewInt(100e6),
+			decimals:  6,
+			wantFee:   big.NewInt(1e6),
+			wantClamp: SwapFeeClampMin,
+		},
+		{
+			name:      "clamp to max fee",
+			feeCfg:    feeCfg,
+			toChainID: tFeeToChainID,
+			value:     big.NewInt(100000e6),
+			decimals:  6,
+			wantFee:   big.NewInt(8e6),
+			wantClamp: SwapFeeClampMax,
+		},
+		{
+			name:       "whitelisted caller pays min fee",
+			feeCfg:     feeCfg,
+			toChainID:  tFeeToChainID,
+			value:      big.NewInt(100000e6),
+			decimals:   6,
+			originFrom: tFeeWhitelisted,
+			wantFee:    big.NewInt(1e6),
+			whitelist:  true,
+		},
+		{
+			name:      "adjust base fee",
+			feeCfg:    feeCfg,
+			toChainID: tFeeBaseChainID,
+			value:     big.NewInt(5000e6),
+			decimals:  6,
+			wantFee:   big.NewInt(5500000), // 5 + 1 * 50%
+		},
+	}
+	for _, tt := range tests {
+		if tt.feeCfg == nil {
+			SetFeeConfigs(new(sync.Map))
+		} else {
+			setTestFeeConfig(tt.feeCfg)
+		}
+		originFrom := tt.originFrom
+		if originFrom == "" {
+			originFrom = tFeeCaller
+		}
+		fee := CalcSwapFee(tFeeTokenID, tFeeFromChainID, tt.toChainID, tt.value, tt.decimals, originFrom, tFeeCaller)
+		if tt.wantNil {
+			if fee != nil {
+				t.Errorf("%v: want nil fee, have %+v", tt.name, fee)
+			}
+			continue
+		}
+		if fee == nil {
+			t.Errorf("%v: want fee %v, have nil", tt.name, tt.wantFee)
+			continue
+		}
+		if fee.SwapFee.Cmp(tt.wantFee) != 0 {
+			t.Errorf("%v: swap fee is %v, want %v", tt.name, fee.SwapFee, tt.wantFee)
+		}
+		if fee.Clamp != tt.wantClamp {
+			t.Errorf("%v: clamp is '%v', want '%v'", tt.name, fee.Clamp, tt.wantClamp)
+		}
+		if fee.IsWhitelisted != tt.whitelist {
+			t.Errorf("%v: whitelisted is %v, want %v", tt.name, fee.IsWhitelisted, tt.whitelist)
+		}
+	}
+}
@@ -88,7 +88,7 @@ func (c *TokenConfig) CheckConfig() error {
 	if c.ContractAddress == "" {
 		return errors.New("token must config 'ContractAddress'")
 	}
-	if !IsERC20Router() && c.Decimals != 0 {
+	if GetTokenSwapType(c.TokenID) != ERC20SwapType && c.Decimals != 0 {
 		return errors.New("non ERC20 token must config 'Decimals' to 0")
 	}
 	return nil
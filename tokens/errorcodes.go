@@ -0,0 +1,177 @@
+package tokens
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// ErrorCategory category of swap error
+type ErrorCategory string
+
+// error categories
+const (
+	ErrCategoryUser     ErrorCategory = "user"     // fixable by user
+	ErrCategoryConfig   ErrorCategory = "config"   // fixable by config
+	ErrCategoryInfra    ErrorCategory = "infra"    // rpc, database, etc.
+	ErrCategorySecurity ErrorCategory = "security" // blacklist, big value, etc.
+)
+
+// ErrCodeNone no error
+const ErrCodeNone = 0
+
+// ErrCodeUnknown unknown error
+const ErrCodeUnknown = 9999
+
+// ErrorCode stable code of swap error
+type ErrorCode struct {
+	Code      int           `json:"code"`
+	Category  ErrorCategory `json:"category"`
+	Retryable bool          `json:"retryable"`
+
+	err error
+}
+
+var (
+	registerCommonOnce sync.Once
+
+	errorCodesLock sync.RWMutex
+	errorCodes     = make(map[int]*ErrorCode)
+	// sorted by error message length desc to match the most specific one
+	errorCodesSorted []*ErrorCode
+
+	unknownErrorCode = &ErrorCode{Code: ErrCodeUnknown, Category: ErrCategoryInfra}
+)
+
+// error codes of common errors (1000-1999)
+var commonErrorCodes = []*ErrorCode{
+	{Code: 1001, Category: ErrCategoryConfig, Retryable: false, err: ErrNotImplemented},
+	{Code: 1002, Category: ErrCategoryConfig, Retryable: false, err: ErrSwapTypeNotSupported},
+	{Code: 1003, Category: ErrCategoryConfig, Retryable: false, err: ErrNoBridgeForChainID},
+	{Code: 1004, Category: ErrCategoryConfig, Retryable: false, err: ErrSwapTradeNotSupport},
+	{Code: 1005, Category: ErrCategoryInfra, Retryable: true, err: ErrNotFound},
+	{Code: 1006, Category: ErrCategoryInfra, Retryable: true, err: ErrTxNotFound},
+	{Code: 1007, Category: ErrCategoryInfra, Retryable: true, err: ErrTxNotStable},
+	{Code: 1008, Category: ErrCategoryUser, Retryable: false, err: ErrLogIndexOutOfRange},
+	{Code: 1009, Category: ErrCategoryUser, Retryable: false, err: ErrTxWithWrongReceipt},
+	{Code: 1010, Category: ErrCategoryUser, Retryable: false, err: ErrTxWithWrongReceiver},
+	{Code: 1011, Category: ErrCategoryUser, Retryable: false, err: ErrTxWithWrongContract},
+	{Code: 1012, Category: ErrCategoryUser, Retryable: false, err: ErrTxWithWrongTopics},
+	{Code: 1013, Category: ErrCategoryUser, Retryable: false, err: ErrSwapoutLogNotFound},
+	{Code: 1014, Category: ErrCategoryInfra, Retryable: true, err: ErrTxWithRemovedLog},
+	{Code: 1015, Category: ErrCategoryUser, Retryable: false, err: ErrWrongBindAddress},
+	{Code: 1016, Category: ErrCategorySecurity, Retryable: false, err: ErrWrongRawTx},
+	{Code: 1017, Category: ErrCategoryUser, Retryable: false, err: ErrUnsupportedFuncHash},
+	{Code: 1018, Category: ErrCategorySecurity, Retryable: false, err: ErrWrongCountOfMsgHashes},
+	{Code: 1019, Category: ErrCategorySecurity, Retryable: false, err: ErrMsgHashMismatch},
+	{Code: 1020, Category: ErrCategorySecurity, Retryable: false, err: ErrSwapInBlacklist},
+	{Code: 1021, Category: ErrCategoryUser, Retryable: false, err: ErrTxBeforeInitialHeight},
+	{Code: 1022, Category: ErrCategoryInfra, Retryable: true, err: ErrEstimateGasFailed},
+	{Code: 1023, Category: ErrCategoryInfra, Retryable: true, err: ErrRPCQueryError},
+	{Code: 1024, Category: ErrCategoryConfig, Retryable: false, err: ErrMissDynamicFeeConfig},
+	{Code: 1025, Category: ErrCategoryUser, Retryable: false, err: ErrFromChainIDMismatch},
+	{Code: 1026, Category: ErrCategoryConfig, Retryable: false, err: ErrMissMPCPublicKey},
+	{Code: 1027, Category: ErrCategoryConfig, Retryable: false, err: ErrMissRouterInfo},
+	{Code: 1028, Category: ErrCategorySecurity, Retryable: false, err: ErrSenderMismatch},
+	{Code: 1029, Category: ErrCategoryUser, Retryable: false, err: ErrTxWithWrongSender},
+	{Code: 1030, Category: ErrCategoryUser, Retryable: false, err: ErrToChainIDMismatch},
+	{Code: 1031, Category: ErrCategoryUser, Retryable: false, err: ErrTxWithWrongStatus},
+	{Code: 1032, Category: ErrCategoryUser, Retryable: false, err: ErrUnknownSwapoutType},
+	{Code: 1033, Category: ErrCategoryConfig, Retryable: false, err: ErrEmptyTokenID},
+	{Code: 1034, Category: ErrCategoryUser, Retryable: true, err: ErrNoEnoughReserveBudget},
+	{Code: 1035, Category: ErrCategoryUser, Retryable: false, err: ErrTxWithNoPayment},
+	{Code: 1036, Category: ErrCategoryInfra, Retryable: true, err: ErrTxIsNotValidated},
+	{Code: 1037, Category: ErrCategoryConfig, Retryable: true, err: ErrPauseSwapInto},
+	{Code: 1038, Category: ErrCategoryInfra, Retryable: true, err: ErrBuildTxErrorAndDelay},
+	{Code: 1039, Category: ErrCategoryUser, Retryable: false, err: ErrTxWithWrongValue},
+	{Code: 1040, Category: ErrCategoryUser, Retryable: false, err: ErrTxWithWrongPath},
+	{Code: 1041, Category: ErrCategoryConfig, Retryable: true, err: ErrMissTokenConfig},
+	{Code: 1042, Category: ErrCategoryConfig, Retryable: true, err: ErrNoUnderlyingToken},
+	{Code: 1043, Category: ErrCategoryUser, Retryable: false, err: ErrNoEnoughRefundValue},
+	{Code: 1044, Category: ErrCategorySecurity, Retryable: false, err: ErrBigValueSwapCanceled},
+	{Code: 1045, Category: ErrCategorySecurity, Retryable: false, err: ErrSwapFlaggedByScreen},
+	{Code: 1046, Category: ErrCategorySecurity, Retryable: false, err: ErrSwapInAlreadyExist},
+	{Code: 1047, Category: ErrCategorySecurity, Retryable: false, err: ErrSwapTradeExecutor},
+}
+
+// RegisterErrorCode register stable code of error.
+// code must be unique, and should never be changed once released.
+func RegisterErrorCode(err error, code int, category ErrorCategory, retryable bool) {
+	registerCommonErrorCodes()
+
+	errorCodesLock.Lock()
+	defer errorCodesLock.Unlock()
+
+	registerErrorCode(&ErrorCode{
+		Code:      code,
+		Category:  category,
+		Retryable: retryable,
+		err:       err,
+	})
+}
+
+func registerCommonErrorCodes() {
+	registerCommonOnce.Do(func() {
+		errorCodesLock.Lock()
+		defer errorCodesLock.Unlock()
+
+		for _, errCode := range commonErrorCodes {
+			registerErrorCode(errCode)
+		}
+	})
+}
+
+func registerErrorCode(errCode *ErrorCode) {
+	err, code := errCode.err, errCode.Code
+	if err == nil || code == ErrCodeNone || code == ErrCodeUnknown {
+		panic("register error code with nil error or reserved code")
+	}
+	if exist, ok := errorCodes[code]; ok {
+		panic("register duplicate error code " + exist.err.Error())
+	}
+	errorCodes[code] = errCode
+	errorCodesSorted = append(errorCodesSorted, errCode)
+	sort.SliceStable(errorCodesSorted, func(i, j int) bool {
+		return len(errorCodesSorted[i].err.Error()) > len(errorCodesSorted[j].err.Error())
+	})
+}
+
+// GetErrorCode get error code of error (nil if err is nil)
+// the error is matched by its type (errors.Is), never by its message
+func GetErrorCode(err error) *ErrorCode {
+	if err == nil {
+		return nil
+	}
+	registerCommonErrorCodes()
+
+	errorCodesLock.RLock()
+	defer errorCodesLock.RUnlock()
+
+	for _, errCode := range errorCodesSorted {
+		if errors.Is(err, errCode.err) {
+			return errCode
+		}
+	}
+	return unknownErrorCode
+}
+
+// GetErrorCodeInfo get error code info by code (nil if not exist)
+func GetErrorCodeInfo(code int) *ErrorCode {
+	if code == ErrCodeUnknown {
+		return unknownErrorCode
+	}
+	registerCommonErrorCodes()
+
+	errorCodesLock.RLock()
+	defer errorCodesLock.RUnlock()
+
+	return errorCodes[code]
+}
+
+// GetErrorCodeValue get code value of error (ErrCodeNone if err is nil)
+func GetErrorCodeValue(err error) int {
+	if errCode := GetErrorCode(err); errCode != nil {
+		return errCode.Code
+	}
+	return ErrCodeNone
+}
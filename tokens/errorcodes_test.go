@@ -0,0 +1,74 @@
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestGetErrorCode(t *testing.T) {
+	errTestRegistered := errors.New("test registered error")
+	RegisterErrorCode(errTestRegistered, 1999, ErrCategoryInfra, true)
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+	}{
+		{"nil error", nil, ErrCodeNone},
+		{"common error", ErrTxNotFound, 1006},
+		{"wrapped common error", fmt.Errorf("%w: more details", ErrTxWithWrongValue), 1039},
+		{"double wrapped error", fmt.Errorf("outer: %w", fmt.Errorf("%w: inner", ErrSwapInBlacklist)), 1020},
+		{"registered error", errTestRegistered, 1999},
+		{"wrapped registered error", fmt.Errorf("%w, retry later", errTestRegistered), 1999},
+		{"same message but not wrapped", errors.New(ErrTxNotFound.Error()), ErrCodeUnknown},
+		{"message contains but not wrapped", fmt.Errorf("verify failed, %v", ErrTxNotFound), ErrCodeUnknown},
+		{"unknown error", errors.New("some unknown error"), ErrCodeUnknown},
+	}
+	for _, tt := range tests {
+		if code := GetErrorCodeValue(tt.err); code != tt.wantCode {
+			t.Errorf("%v: get error code %v, want %v", tt.name, code, tt.wantCode)
+		}
+	}
+}
+
+func TestErrorCodeInfo(t *testing.T) {
+	tests := []struct {
+		code      int
+		wantNil   bool
+		category  ErrorCategory
+		retryable bool
+	}{
+		{code: ErrCodeNone, wantNil: true},
+		{code: 1005, category: ErrCategoryInfra, retryable: true},
+		{code: 1020, category: ErrCategorySecurity, retryable: false},
+		{code: 1041, category: ErrCategoryConfig, retryable: true},
+		{code: ErrCodeUnknown, category: ErrCategoryInfra, retryable: false},
+		{code: 1998, wantNil: true},
+	}
+	for _, tt := range tests {
+		info := GetErrorCodeInfo(tt.code)
+		if tt.wantNil {
+			if info != nil {
+				t.Errorf("code %v: want nil info, have %+v", tt.code, info)
+			}
+			continue
+		}
+		if info == nil {
+			t.Errorf("code %v: want info, have nil", tt.code)
+			continue
+		}
+		if info.Code != tt.code || info.Category != tt.category || info.Retryable != tt.retryable {
+			t.Errorf("code %v: have %+v, want category %v retryable %v", tt.code, info, tt.category, tt.retryable)
+		}
+	}
+}
+
+func TestRegisterDuplicateErrorCode(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("register duplicate error code should panic")
+		}
+	}()
+	RegisterErrorCode(errors.New("duplicate code"), 1001, ErrCategoryConfig, false)
+}
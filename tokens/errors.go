@@ -44,6 +44,11 @@ var (
 	ErrTxIsNotValidated      = errors.New("tx is not validated")
 	ErrPauseSwapInto         = errors.New("maintain: pause swap into")
 	ErrBuildTxErrorAndDelay  = errors.New("[build tx error]")
+	ErrNoEnoughRefundValue   = errors.New("no enough value to refund")
+	ErrBigValueSwapCanceled  = errors.New("big value swap is canceled")
+	ErrSwapFlaggedByScreen   = errors.New("swap is flagged by address screening")
+	ErrSwapInAlreadyExist    = errors.New("swapin already exist on destination chain")
+	ErrSwapTradeExecutor     = errors.New("swap trade with not allowed executor")
 
 	// errors should register in router swap
 	ErrTxWithWrongValue  = errors.New("tx with wrong value")
@@ -65,6 +70,18 @@ func ShouldRegisterRouterSwapForError(err error) bool {
 	return false
 }
 
+// IsRefundableError return true if swap with this error can be refunded
+func IsRefundableError(err error) bool {
+	switch {
+	case errors.Is(err, ErrTxWithWrongValue),
+		errors.Is(err, ErrTxWithWrongPath),
+		errors.Is(err, ErrMissTokenConfig),
+		errors.Is(err, ErrNoUnderlyingToken):
+		return true
+	}
+	return false
+}
+
 // IsRPCQueryOrNotFoundError is rpc or not found error
 func IsRPCQueryOrNotFoundError(err error) bool {
 	return errors.Is(err, ErrRPCQueryError) || errors.Is(err, ErrNotFound)
@@ -0,0 +1,241 @@
+package eth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/common/hexutil"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/eth/abicoder"
+	"github.com/anyswap/CrossChain-Router/v3/types"
+)
+
+var (
+	// ensure Bridge impl tokens.TxRevertInfoGetter
+	_ tokens.TxRevertInfoGetter = &Bridge{}
+
+	// anyFallback(address,bytes)
+	AnyFallbackFuncHash = common.FromHex("0xa35fe8bf")
+
+	// Error(string)
+	revertErrorFuncHash = common.FromHex("0x08c379a0")
+	// Panic(uint256)
+	revertPanicFuncHash = common.FromHex("0x4e487b71")
+
+	errNotAnyCallFallbackTx = errors.New("not anycall fallback tx")
+	errNoAnyCallFallback    = errors.New("anycall without fallback")
+)
+
+// GetTxRevertInfo get revert info of onchain failed tx.
+// the revert reason is decoded by replaying the tx at its block.
+func (b *Bridge) GetTxRevertInfo(txHash string, txStatus *tokens.TxStatus) (*tokens.TxRevertInfo, error) {
+	receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
+	if !ok || receipt == nil || receipt.GasUsed == nil {
+		return nil, errors.New("tx receipt without gas used")
+	}
+	tx, err := b.GetTransactionByHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.GasLimit == nil {
+		return nil, errors.New("tx without gas limit")
+	}
+	info := &tokens.TxRevertInfo{
+		GasLimit: uint64(*tx.GasLimit),
+		GasUsed:  uint64(*receipt.GasUsed),
+	}
+	info.IsOutOfGas = isOutOfGas(info.GasLimit, info.GasUsed)
+	info.Reason = b.replayTxForRevertReason(tx)
+	return info, nil
+}
+
+// isOutOfGas the 1/64 remaining gas of nested call will not be enough
+// if the failed tx consumed nearly all of its gas limit
+func isOutOfGas(gasLimit, gasUsed uint64) bool {
+	return gasUsed*64 >= gasLimit*63
+}
+
+func (b *Bridge) replayTxForRevertReason(tx *types.RPCTransaction) string {
+	if tx.Recipient == nil || tx.From == nil || tx.BlockNumber == nil {
+		return ""
+	}
+	reqArgs := map[string]interface{}{
+		"from":  tx.From,
+		"to":    tx.Recipient,
+		"gas":   tx.GasLimit,
+		"value": tx.Amount,
+		"data":  tx.Payload,
+	}
+	blockNumber := hexutil.EncodeBig(tx.BlockNumber.ToInt())
+	var result hexutil.Bytes
+	var err error
+	for _, url := range b.GatewayConfig.APIAddress {
+		err = client.RPCPostWithTimeout(b.RPCClientTimeout, &result, url, "eth_call", reqArgs, blockNumber)
+		if err == nil {
+			return "" // state changed, can not replay the revert
+		}
+		if data, ok := client.GetErrorData(err).(string); ok {
+			if reason := decodeRevertReason(common.FromHex(data)); reason != "" {
+				return reason
+			}
+		}
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+func decodeRevertReason(data []byte) string {
+	if len(data) < 4 {
+		return ""
+	}
+	switch {
+	case bytes.Equal(data[:4], revertErrorFuncHash):
+		reason, err := abicoder.ParseStringInData(data[4:], 0)
+		if err == nil {
+			return reason
+		}
+	case bytes.Equal(data[:4], revertPanicFuncHash):
+		if len(data) >= 36 {
+			return fmt.Sprintf("panic code 0x%x", common.GetBigInt(data[4:], 0, 32))
+		}
+	}
+	return ""
+}
+
+// verifyAnyCallFallbackTx verify onchain failed anyExec tx sent by mpc,
+// which is used to trigger the fallback call on the source chain
+func (b *Bridge) verifyAnyCallFallbackTx(swapInfo *tokens.SwapTxInfo, receipt *types.RPCTxReceipt) error {
+	if swapInfo.LogIndex != 0 {
+		return tokens.ErrLogIndexOutOfRange
+	}
+	if receipt == nil || receipt.IsStatusOk() || receipt.Recipient == nil || receipt.GasUsed == nil {
+		return errNotAnyCallFallbackTx
+	}
+	routerContract := b.GetSwapTypeRouterContract(tokens.AnyCallSwapType)
+	if !common.IsEqualIgnoreCase(receipt.Recipient.LowerHex(), routerContract) {
+		return tokens.ErrTxWithWrongContract
+	}
+	routerMPC, err := router.GetRouterMPC("", b.ChainConfig.ChainID)
+	if err != nil {
+		return err
+	}
+	if !common.IsEqualIgnoreCase(receipt.From.LowerHex(), routerMPC) {
+		return tokens.ErrTxWithWrongSender
+	}
+	tx, err := b.GetTransactionByHash(swapInfo.Hash)
+	if err != nil {
+		return err
+	}
+	if tx.GasLimit == nil || tx.Payload == nil {
+		return errNotAnyCallFallbackTx
+	}
+	// out of gas failure is retried with higher gas limit by the router,
+	// and is allowed to fallback after its retries are used up.
+	swapInfo.TxTo = receipt.Recipient.LowerHex() // TxTo
+	swapInfo.To = swapInfo.TxTo                  // To
+	swapInfo.From = receipt.From.LowerHex()      // From
+	swapInfo.FromChainID = b.ChainConfig.GetChainID()
+
+	switch params.GetSwapTypeSubType(tokens.AnyCallSwapType.String()) {
+	case tokens.CurveAnycallSubType:
+		err = parseCurveAnyExecFallback(swapInfo, *tx.Payload)
+	default:
+		err = parseAnyExecFallback(swapInfo, *tx.Payload)
+	}
+	if err != nil {
+		return err
+	}
+	if router.GetBridgeByChainID(swapInfo.ToChainID.String()) == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	log.Info("verify anycall fallback tx success", "chainID", b.ChainConfig.ChainID, "txid", swapInfo.Hash, "toChainID", swapInfo.ToChainID)
+	return nil
+}
+
+// parseAnyExecFallback parse input of
+// anyExec(address,address[],bytes[],address[],uint256[],uint256)
+// and call anyFallback of the callbacks on the source chain
+func parseAnyExecFallback(swapInfo *tokens.SwapTxInfo, input []byte) (err error) {
+	if len(input) < 4+192 || !bytes.Equal(input[:4], AnyExecFuncHash) {
+		return errNotAnyCallFallbackTx
+	}
+	data := input[4:]
+	callFrom := common.BytesToAddress(common.GetData(data, 0, 32)).LowerHex()
+	callTo, err := abicoder.ParseAddressSliceInData(data, 32)
+	if err != nil {
+		return err
+	}
+	callData, err := abicoder.ParseBytesSliceInData(data, 64)
+	if err != nil {
+		return err
+	}
+	callbacks, err := abicoder.ParseAddressSliceInData(data, 96)
+	if err != nil {
+		return err
+	}
+	callNonces, err := abicoder.ParseNumberSliceAsBigIntsInData(data, 128)
+	if err != nil {
+		return err
+	}
+	if len(callTo) != len(callData) || len(callTo) != len(callbacks) || len(callTo) != len(callNonces) {
+		return abicoder.ErrParseDataError
+	}
+	swapInfo.ToChainID = common.GetBigInt(data, 160, 32)
+
+	fallbackInfo := &tokens.AnyCallSwapInfo{CallFrom: callFrom}
+	zeroAddress := common.Address{}.LowerHex()
+	for i, callback := range callbacks {
+		if callback == "" || strings.EqualFold(callback, zeroAddress) {
+			continue
+		}
+		fallbackData := abicoder.PackDataWithFuncHash(AnyFallbackFuncHash, common.HexToAddress(callTo[i]), callData[i])
+		fallbackInfo.CallTo = append(fallbackInfo.CallTo, callback)
+		fallbackInfo.CallData = append(fallbackInfo.CallData, fallbackData)
+		fallbackInfo.Callbacks = append(fallbackInfo.Callbacks, zeroAddress)
+		fallbackInfo.CallNonces = append(fallbackInfo.CallNonces, new(big.Int).Set(callNonces[i]))
+	}
+	if len(fallbackInfo.CallTo) == 0 {
+		return errNoAnyCallFallback
+	}
+	swapInfo.SwapInfo = tokens.SwapInfo{AnyCallSwapInfo: fallbackInfo}
+	return nil
+}
+
+// parseCurveAnyExecFallback parse input of
+// anyExec(address,address,bytes,address,uint256)
+// and call anyFallback of the fallback on the source chain
+func parseCurveAnyExecFallback(swapInfo *tokens.SwapTxInfo, input []byte) (err error) {
+	if len(input) < 4+160 || !bytes.Equal(input[:4], CurveAnyExecFuncHash) {
+		return errNotAnyCallFallbackTx
+	}
+	data := input[4:]
+	callFrom := common.BytesToAddress(common.GetData(data, 0, 32)).LowerHex()
+	callTo := common.BytesToAddress(common.GetData(data, 32, 32))
+	callData, err := abicoder.ParseBytesInData(data, 64)
+	if err != nil {
+		return err
+	}
+	fallback := common.BytesToAddress(common.GetData(data, 96, 32))
+	if fallback == (common.Address{}) {
+		return errNoAnyCallFallback
+	}
+	swapInfo.ToChainID = common.GetBigInt(data, 128, 32)
+
+	swapInfo.SwapInfo = tokens.SwapInfo{CurveAnyCallSwapInfo: &tokens.CurveAnyCallSwapInfo{
+		CallFrom: callFrom,
+		CallTo:   fallback.LowerHex(),
+		CallData: abicoder.PackDataWithFuncHash(AnyFallbackFuncHash, callTo, callData),
+		Fallback: common.Address{}.LowerHex(),
+	}}
+	return nil
+}
@@ -0,0 +1,50 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens/eth/abicoder"
+)
+
+func TestDecodeRevertReason(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"empty data", nil, ""},
+		{"too short data", revertErrorFuncHash[:3], ""},
+		{"error reason", abicoder.PackDataWithFuncHash(revertErrorFuncHash, "insufficient balance"), "insufficient balance"},
+		{"empty error reason", abicoder.PackDataWithFuncHash(revertErrorFuncHash, ""), ""},
+		{"wrong error data", append(append([]byte{}, revertErrorFuncHash...), 1, 2, 3), ""},
+		{"panic code", abicoder.PackDataWithFuncHash(revertPanicFuncHash, big.NewInt(0x11)), "panic code 0x11"},
+		{"too short panic data", append(append([]byte{}, revertPanicFuncHash...), 1), ""},
+		{"custom error", abicoder.PackDataWithFuncHash([]byte{1, 2, 3, 4}, big.NewInt(1)), ""},
+	}
+	for _, tt := range tests {
+		if have := decodeRevertReason(tt.data); have != tt.want {
+			t.Errorf("%v: revert reason is '%v', want '%v'", tt.name, have, tt.want)
+		}
+	}
+}
+
+func TestIsOutOfGas(t *testing.T) {
+	tests := []struct {
+		gasLimit uint64
+		gasUsed  uint64
+		want     bool
+	}{
+		{gasLimit: 6400000, gasUsed: 6400000, want: true},
+		{gasLimit: 6400000, gasUsed: 6300000, want: true},
+		{gasLimit: 6400000, gasUsed: 6299999, want: false},
+		{gasLimit: 6400000, gasUsed: 3000000, want: false},
+		{gasLimit: 21000, gasUsed: 21000, want: true},
+		{gasLimit: 0, gasUsed: 0, want: true},
+	}
+	for _, tt := range tests {
+		if have := isOutOfGas(tt.gasLimit, tt.gasUsed); have != tt.want {
+			t.Errorf("isOutOfGas(%v, %v) is %v, want %v", tt.gasLimit, tt.gasUsed, have, tt.want)
+		}
+	}
+}
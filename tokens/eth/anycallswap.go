@@ -36,6 +36,9 @@ func (b *Bridge) registerAnyCallSwapTx(txHash string, logIndex int) ([]*tokens.S
 	commonInfo.LogIndex = logIndex               // LogIndex
 
 	receipt, err := b.getSwapTxReceipt(commonInfo, true)
+	if errors.Is(err, tokens.ErrTxWithWrongReceipt) && receipt != nil {
+		return []*tokens.SwapTxInfo{commonInfo}, []error{b.verifyAnyCallFallbackTx(commonInfo, receipt)}
+	}
 	if err != nil {
 		return []*tokens.SwapTxInfo{commonInfo}, []error{err}
 	}
@@ -85,6 +88,9 @@ func (b *Bridge) verifyAnyCallSwapTx(txHash string, logIndex int, allowUnstable
 	swapInfo.LogIndex = logIndex               // LogIndex
 
 	receipt, err := b.getSwapTxReceipt(swapInfo, allowUnstable)
+	if errors.Is(err, tokens.ErrTxWithWrongReceipt) && receipt != nil {
+		return swapInfo, b.verifyAnyCallFallbackTx(swapInfo, receipt)
+	}
 	if err != nil {
 		return swapInfo, err
 	}
@@ -115,7 +121,7 @@ func (b *Bridge) verifyAnyCallSwapTx(txHash string, logIndex int, allowUnstable
 }
 
 func getCallFrom(swapInfo *tokens.SwapTxInfo) string {
-	switch params.GetSwapSubType() {
+	switch params.GetSwapTypeSubType(tokens.AnyCallSwapType.String()) {
 	case tokens.CurveAnycallSubType:
 		return swapInfo.CurveAnyCallSwapInfo.CallFrom
 	default:
@@ -126,7 +132,7 @@ func getCallFrom(swapInfo *tokens.SwapTxInfo) string {
 func (b *Bridge) verifyAnyCallSwapTxLog(swapInfo *tokens.SwapTxInfo, rlog *types.RPCLog) (err error) {
 	swapInfo.To = rlog.Address.LowerHex() // To
 
-	switch params.GetSwapSubType() {
+	switch params.GetSwapTypeSubType(tokens.AnyCallSwapType.String()) {
 	case tokens.CurveAnycallSubType:
 		err = b.parseCurveAnyCallSwapTxLog(swapInfo, rlog)
 	default:
@@ -141,7 +147,7 @@ func (b *Bridge) verifyAnyCallSwapTxLog(swapInfo *tokens.SwapTxInfo, rlog *types
 		return tokens.ErrTxWithRemovedLog
 	}
 
-	routerContract := b.GetRouterContract("")
+	routerContract := b.GetSwapTypeRouterContract(tokens.AnyCallSwapType)
 	if !common.IsEqualIgnoreCase(rlog.Address.LowerHex(), routerContract) {
 		log.Warn("swap tx with wrong contract", "log.Address", rlog.Address.LowerHex(), "routerContract", routerContract)
 		return tokens.ErrTxWithWrongContract
@@ -256,7 +262,7 @@ func (b *Bridge) buildAnyCallSwapTxInput(args *tokens.BuildTxArgs) (err error) {
 	}
 
 	var input []byte
-	switch params.GetSwapSubType() {
+	switch params.GetSwapTypeSubType(tokens.AnyCallSwapType.String()) {
 	case tokens.CurveAnycallSubType:
 		funcHash := CurveAnyExecFuncHash
 		anycallSwapInfo := args.CurveAnyCallSwapInfo
@@ -288,7 +294,7 @@ func (b *Bridge) buildAnyCallSwapTxInput(args *tokens.BuildTxArgs) (err error) {
 
 	args.Input = (*hexutil.Bytes)(&input) // input
 
-	routerContract := b.GetRouterContract("")
+	routerContract := b.GetSwapTypeRouterContract(tokens.AnyCallSwapType)
 	args.To = routerContract // to
 
 	return nil
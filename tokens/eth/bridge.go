@@ -122,7 +122,7 @@ func (b *Bridge) InitRouterInfo(routerContract string) (err error) {
 	chainID := b.ChainConfig.ChainID
 	log.Info(fmt.Sprintf("[%5v] start init router info", chainID), "routerContract", routerContract)
 	var routerFactory, routerWNative string
-	if tokens.IsERC20Router() {
+	if tokens.IsSwapTypeEnabled(tokens.ERC20SwapType) {
 		routerFactory, err = b.GetFactoryAddress(routerContract)
 		if err != nil {
 			log.Warn("get router factory address failed", "chainID", chainID, "routerContract", routerContract, "err", err)
@@ -188,7 +188,7 @@ func (b *Bridge) InitRouterInfo(routerContract string) (err error) {
 func (b *Bridge) SetTokenConfig(tokenAddr string, tokenCfg *tokens.TokenConfig) {
 	b.CrossChainBridgeBase.SetTokenConfig(tokenAddr, tokenCfg)
 
-	if tokenCfg == nil || !tokens.IsERC20Router() {
+	if tokenCfg == nil || tokens.GetTokenSwapType(tokenCfg.TokenID) != tokens.ERC20SwapType {
 		return
 	}
 
@@ -38,6 +38,14 @@ var (
 	AnySwapInExactTokensForTokensFuncHash = common.FromHex("0x2fc1e728")
 	// anySwapInExactTokensForNative(bytes32 txs, uint amountIn, uint amountOutMin, address[] path, address to, uint deadline, uint fromChainID)
 	AnySwapInExactTokensForNativeFuncHash = common.FromHex("0x52a397d5")
+	// anySwapInExactTokensForTokensV3(bytes32 txs, uint amountIn, uint amountOutMin, bytes path, address to, uint deadline, uint fromChainID)
+	AnySwapInExactTokensForTokensV3FuncHash = common.FromHex("0xb1e9b7a8")
+	// anySwapInExactTokensForNativeV3(bytes32 txs, uint amountIn, uint amountOutMin, bytes path, address to, uint deadline, uint fromChainID)
+	AnySwapInExactTokensForNativeV3FuncHash = common.FromHex("0x9286df6f")
+	// anySwapInAndAggregate(bytes32 txs, address token, address to, uint amount, uint fromChainID, address tokenOut, uint amountOutMin, address executor, bytes data)
+	AnySwapInAndAggregateFuncHash = common.FromHex("0x9c04bf8e")
+	// anySwapInUnderlyingAndAggregate(bytes32 txs, address token, address to, uint amount, uint fromChainID, address tokenOut, uint amountOutMin, address executor, bytes data)
+	AnySwapInUnderlyingAndAggregateFuncHash = common.FromHex("0x8cf17879")
 	// anySwapInAndExec(bytes32 txs, address token, address to, uint amount, uint fromChainID, address anycallProxy, bytes calldata data)
 	AnySwapInAndExecFuncHash = common.FromHex("0x86377115")
 	// anySwapInUnderlyingAndExec(bytes32 txs, address token, address to, uint amount, uint fromChainID, address anycallProxy, bytes calldata data)
@@ -174,21 +182,87 @@ func (b *Bridge) buildERC20SwapTradeTxInput(args *tokens.BuildTxArgs, multichain
 	}
 	erc20SwapInfo := args.ERC20SwapInfo
 
+	if erc20SwapInfo.IsSwapTradeAggregate() {
+		return b.buildERC20SwapTradeAndAggregateTxInput(args, multichainToken, receiver, amount)
+	}
+
+	var input []byte
+	if erc20SwapInfo.IsSwapTradeV3() {
+		var funcHash []byte
+		if erc20SwapInfo.ForNative {
+			funcHash = AnySwapInExactTokensForNativeV3FuncHash
+		} else {
+			funcHash = AnySwapInExactTokensForTokensV3FuncHash
+		}
+		path, errf := encodeSwapTradeV3Path(erc20SwapInfo.Path, erc20SwapInfo.PoolFees)
+		if errf != nil {
+			return errf
+		}
+		input = abicoder.PackDataWithFuncHash(funcHash,
+			common.HexToHash(args.SwapID),
+			amount,
+			erc20SwapInfo.AmountOutMin,
+			path,
+			receiver,
+			calcSwapDeadline(args),
+			args.FromChainID,
+		)
+	} else {
+		var funcHash []byte
+		if erc20SwapInfo.ForNative {
+			funcHash = AnySwapInExactTokensForNativeFuncHash
+		} else {
+			funcHash = AnySwapInExactTokensForTokensFuncHash
+		}
+		input = abicoder.PackDataWithFuncHash(funcHash,
+			common.HexToHash(args.SwapID),
+			amount,
+			erc20SwapInfo.AmountOutMin,
+			toAddresses(erc20SwapInfo.Path),
+			receiver,
+			calcSwapDeadline(args),
+			args.FromChainID,
+		)
+	}
+	args.Input = (*hexutil.Bytes)(&input)          // input
+	args.To = b.GetRouterContract(multichainToken) // to
+	args.SwapValue = amount                        // swapValue
+
+	return nil
+}
+
+// the router contract calls the executor with the call data,
+// and reverts if the receiver gets less than 'amountOutMin' of 'tokenOut'.
+func (b *Bridge) buildERC20SwapTradeAndAggregateTxInput(args *tokens.BuildTxArgs, multichainToken string, receiver common.Address, amount *big.Int) error {
+	toTokenCfg := b.GetTokenConfig(multichainToken)
+	if toTokenCfg == nil {
+		return tokens.ErrMissTokenConfig
+	}
+	erc20SwapInfo := args.ERC20SwapInfo
+	if len(erc20SwapInfo.Path) != 2 {
+		return tokens.ErrTxWithWrongPath
+	}
+	if !params.IsInSwapTradeExecutorWhitelist(b.ChainConfig.ChainID, erc20SwapInfo.Executor) {
+		return tokens.ErrSwapTradeExecutor
+	}
+
 	var funcHash []byte
-	if erc20SwapInfo.ForNative {
-		funcHash = AnySwapInExactTokensForNativeFuncHash
+	if common.HexToAddress(erc20SwapInfo.Path[0]) == common.HexToAddress(multichainToken) {
+		funcHash = AnySwapInAndAggregateFuncHash
 	} else {
-		funcHash = AnySwapInExactTokensForTokensFuncHash
+		funcHash = AnySwapInUnderlyingAndAggregateFuncHash
 	}
 
 	input := abicoder.PackDataWithFuncHash(funcHash,
 		common.HexToHash(args.SwapID),
-		amount,
-		erc20SwapInfo.AmountOutMin,
-		toAddresses(erc20SwapInfo.Path),
+		common.HexToAddress(multichainToken),
 		receiver,
-		calcSwapDeadline(args),
+		amount,
 		args.FromChainID,
+		common.HexToAddress(erc20SwapInfo.Path[1]),
+		erc20SwapInfo.AmountOutMin,
+		common.HexToAddress(erc20SwapInfo.Executor),
+		erc20SwapInfo.CallData,
 	)
 	args.Input = (*hexutil.Bytes)(&input)          // input
 	args.To = b.GetRouterContract(multichainToken) // to
@@ -197,6 +271,24 @@ func (b *Bridge) buildERC20SwapTradeTxInput(args *tokens.BuildTxArgs, multichain
 	return nil
 }
 
+// encodeSwapTradeV3Path encode path of concentrated liquidity pools,
+// which is packed as token0 (20 bytes) + fee (3 bytes) + token1 + ... + tokenN
+func encodeSwapTradeV3Path(path []string, fees []uint32) ([]byte, error) {
+	if len(path) < 2 || len(fees) != len(path)-1 {
+		return nil, tokens.ErrTxWithWrongPath
+	}
+	encoded := make([]byte, 0, common.AddressLength+(3+common.AddressLength)*len(fees))
+	encoded = append(encoded, common.HexToAddress(path[0]).Bytes()...)
+	for i, fee := range fees {
+		if uint64(fee) > maxPoolFee {
+			return nil, tokens.ErrTxWithWrongPath
+		}
+		encoded = append(encoded, byte(fee>>16), byte(fee>>8), byte(fee))
+		encoded = append(encoded, common.HexToAddress(path[i+1]).Bytes()...)
+	}
+	return encoded, nil
+}
+
 func calcSwapDeadline(args *tokens.BuildTxArgs) int64 {
 	var deadline int64
 	if args.Extra != nil && args.Extra.EthExtra != nil {
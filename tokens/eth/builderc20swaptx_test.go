@@ -0,0 +1,38 @@
+package eth
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+func TestEncodeSwapTradeV3Path(t *testing.T) {
+	path := []string{
+		"0x1111111111111111111111111111111111111111",
+		"0x2222222222222222222222222222222222222222",
+		"0x3333333333333333333333333333333333333333",
+	}
+	fees := []uint32{500, 3000}
+
+	encoded, err := encodeSwapTradeV3Path(path, fees)
+	if err != nil {
+		t.Fatalf("encode v3 path failed: %v", err)
+	}
+	want := common.FromHex("0x" +
+		"1111111111111111111111111111111111111111" + "0001f4" +
+		"2222222222222222222222222222222222222222" + "000bb8" +
+		"3333333333333333333333333333333333333333")
+	if !bytes.Equal(encoded, want) {
+		t.Errorf("encode v3 path mismatch, have %x want %x", encoded, want)
+	}
+
+	if _, err = encodeSwapTradeV3Path(path, fees[:1]); !errors.Is(err, tokens.ErrTxWithWrongPath) {
+		t.Errorf("encode v3 path with wrong count of fees, have err %v want %v", err, tokens.ErrTxWithWrongPath)
+	}
+	if _, err = encodeSwapTradeV3Path(path, []uint32{500, 1 << 24}); !errors.Is(err, tokens.ErrTxWithWrongPath) {
+		t.Errorf("encode v3 path with overflow fee, have err %v want %v", err, tokens.ErrTxWithWrongPath)
+	}
+}
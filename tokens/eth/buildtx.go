@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/common/hexutil"
 	"github.com/anyswap/CrossChain-Router/v3/log"
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
@@ -63,6 +64,34 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 	return b.buildTx(args)
 }
 
+// BuildCancelTransaction build tx to cancel pending swap tx
+// by sending zero value to self with the same nonce
+func (b *Bridge) BuildCancelTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	if args.From == "" {
+		return nil, fmt.Errorf("forbid empty sender")
+	}
+	extra := getOrInitEthExtra(args)
+	if extra.Nonce == nil {
+		return nil, fmt.Errorf("forbid cancel tx without nonce")
+	}
+	args.Extra.IsCancel = true
+	input := hexutil.Bytes{}
+	args.To = args.From
+	args.Value = big.NewInt(0)
+	args.Input = &input
+	if extra.Gas == nil {
+		extra.Gas = new(uint64)
+		*extra.Gas = 21000
+	}
+
+	err = b.setDefaults(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.buildTx(args)
+}
+
 func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
 	var (
 		to        = common.HexToAddress(args.To)
@@ -272,11 +301,14 @@ func (b *Bridge) adjustSwapGasPrice(args *tokens.BuildTxArgs, oldGasPrice *big.I
 		addPercent = serverCfg.PlusGasPricePercentage
 	}
 	replaceNum := args.GetReplaceNum()
+	maxPlusPercent := serverCfg.MaxPlusGasPricePercentage
 	if replaceNum > 0 {
-		addPercent += replaceNum * serverCfg.ReplacePlusGasPricePercent
+		strategy := params.GetReplaceStrategy(b.ChainConfig.ChainID)
+		addPercent += strategy.GetReplacePlusPercent(replaceNum)
+		maxPlusPercent = strategy.MaxPlusGasPricePercentage
 	}
-	if addPercent > serverCfg.MaxPlusGasPricePercentage {
-		addPercent = serverCfg.MaxPlusGasPricePercentage
+	if addPercent > maxPlusPercent {
+		addPercent = maxPlusPercent
 	}
 	if addPercent > 0 {
 		newGasPrice.Mul(newGasPrice, big.NewInt(int64(100+addPercent)))
@@ -377,11 +409,14 @@ func (b *Bridge) getGasTipCap(args *tokens.BuildTxArgs) (gasTipCap *big.Int, err
 
 	addPercent := dfConfig.PlusGasTipCapPercent
 	replaceNum := args.GetReplaceNum()
+	maxPlusPercent := serverCfg.MaxPlusGasPricePercentage
 	if replaceNum > 0 {
-		addPercent += replaceNum * serverCfg.ReplacePlusGasPricePercent
+		strategy := params.GetReplaceStrategy(b.ChainConfig.ChainID)
+		addPercent += strategy.GetReplacePlusPercent(replaceNum)
+		maxPlusPercent = strategy.MaxPlusGasPricePercentage
 	}
-	if addPercent > serverCfg.MaxPlusGasPricePercentage {
-		addPercent = serverCfg.MaxPlusGasPricePercentage
+	if addPercent > maxPlusPercent {
+		addPercent = maxPlusPercent
 	}
 	if addPercent > 0 {
 		gasTipCap.Mul(gasTipCap, big.NewInt(int64(100+addPercent)))
@@ -395,7 +430,7 @@ func (b *Bridge) getGasTipCap(args *tokens.BuildTxArgs) (gasTipCap *big.Int, err
 	return gasTipCap, nil
 }
 
-func (b *Bridge) getGasFeeCap(_ *tokens.BuildTxArgs, gasTipCap *big.Int) (gasFeeCap *big.Int, err error) {
+func (b *Bridge) getGasFeeCap(args *tokens.BuildTxArgs, gasTipCap *big.Int) (gasFeeCap *big.Int, err error) {
 	dfConfig := params.GetDynamicFeeTxConfig(b.ChainConfig.ChainID)
 	if dfConfig == nil {
 		return nil, tokens.ErrMissDynamicFeeConfig
@@ -417,7 +452,17 @@ func (b *Bridge) getGasFeeCap(_ *tokens.BuildTxArgs, gasTipCap *big.Int) (gasFee
 	newGasFeeCap := new(big.Int).Set(gasTipCap) // copy
 	newGasFeeCap.Add(newGasFeeCap, baseFee.Mul(baseFee, big.NewInt(2)))
 
-	newGasFeeCap.Mul(newGasFeeCap, big.NewInt(int64(100+dfConfig.PlusGasFeeCapPercent)))
+	addPercent := dfConfig.PlusGasFeeCapPercent
+	// escalate fee cap along with tip cap, or the replace tx may be rejected
+	if replaceNum := args.GetReplaceNum(); replaceNum > 0 {
+		strategy := params.GetReplaceStrategy(b.ChainConfig.ChainID)
+		replacePercent := strategy.GetReplacePlusPercent(replaceNum)
+		if replacePercent > strategy.MaxPlusGasPricePercentage {
+			replacePercent = strategy.MaxPlusGasPricePercentage
+		}
+		addPercent += replacePercent
+	}
+	newGasFeeCap.Mul(newGasFeeCap, big.NewInt(int64(100+addPercent)))
 	newGasFeeCap.Div(newGasFeeCap, big.NewInt(100))
 
 	maxGasFeeCap := dfConfig.GetMaxGasFeeCap()
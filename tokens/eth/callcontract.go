@@ -8,8 +8,12 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/common/hexutil"
 	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
 )
 
+// ensure Bridge impl tokens.TokenAuditor
+var _ tokens.TokenAuditor = &Bridge{}
+
 // token types (should be all upper case)
 const (
 	ERC20TokenType = "ERC20"
@@ -0,0 +1,30 @@
+package eth
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+// ensure Bridge impl tokens.LiquidityGetter
+var _ tokens.LiquidityGetter = &Bridge{}
+
+// GetTokenLiquidity impl LiquidityGetter
+// the liquidity of token with underlying is the underlying balance of the anyToken,
+// which will be transferred to receiver in 'ForUnderlying' swaps.
+func (b *Bridge) GetTokenLiquidity(tokenCfg *tokens.TokenConfig, mpc string) (holder string, liquidity *big.Int, err error) {
+	underlying := tokenCfg.GetUnderlying()
+	if underlying == "" {
+		return "", nil, nil
+	}
+	holder = tokenCfg.ContractAddress
+	for i := 0; i < retryRPCCount; i++ {
+		liquidity, err = b.GetErc20Balance(underlying, holder)
+		if err == nil {
+			break
+		}
+		time.Sleep(retryRPCInterval)
+	}
+	return holder, liquidity, err
+}
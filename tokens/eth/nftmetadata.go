@@ -0,0 +1,144 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/common/hexutil"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/eth/abicoder"
+)
+
+// NFTMetadataTokenVersion destination nft token version which preserves metadata and royalty
+const NFTMetadataTokenVersion = uint64(30000)
+
+// nft metadata func hashes
+var (
+	// tokenURI(uint256)
+	nft721TokenURIFuncHash = common.FromHex("0xc87b56dd")
+	// uri(uint256)
+	nft1155URIFuncHash = common.FromHex("0x0e89341c")
+	// royaltyInfo(uint256,uint256)
+	royaltyInfoFuncHash = common.FromHex("0x2a55205a")
+
+	// nft721SwapInWithMetadata(bytes32,address,address,uint256,uint256,string,address,uint256)
+	nft721SwapInWithMetadataFuncHash = common.FromHex("0x5b5d67b2")
+	// nft1155SwapInWithMetadata(bytes32,address,address,uint256,uint256,uint256,string,address,uint256)
+	nft1155SwapInWithMetadataFuncHash = common.FromHex("0x93da6c38")
+	// nft1155BatchSwapInWithMetadata(bytes32,address,address,uint256[],uint256[],uint256,string[],address[],uint256[])
+	nft1155BatchSwapInWithMetadataFuncHash = common.FromHex("0x3efa19ec")
+
+	// sale price to query royalty in basis points
+	royaltyBpsSalePrice = big.NewInt(10000)
+)
+
+// isNFTMetadataPreserved is the destination token preserves metadata
+func isNFTMetadataPreserved(dstBridge tokens.IBridge, multichainToken string) bool {
+	tokenCfg := dstBridge.GetTokenConfig(multichainToken)
+	return tokenCfg != nil && tokenCfg.ContractVersion == NFTMetadataTokenVersion
+}
+
+// readNFTMetadatas read token uri and royalty info on the source chain.
+// read at the block before the swap tx as the nft may be burned in it.
+func (b *Bridge) readNFTMetadatas(swapInfo *tokens.SwapTxInfo) error {
+	nftSwapInfo := swapInfo.NFTSwapInfo
+	if swapInfo.Height == 0 {
+		return tokens.ErrTxNotFound
+	}
+	blockNumber := hexutil.EncodeUint64(swapInfo.Height - 1)
+	is1155 := len(nftSwapInfo.Amounts) > 0
+	metadatas := make([]*tokens.NFTMetadata, len(nftSwapInfo.IDs))
+	for i, id := range nftSwapInfo.IDs {
+		uriFuncHash := nft721TokenURIFuncHash
+		if is1155 {
+			uriFuncHash = nft1155URIFuncHash
+		}
+		res, err := b.CallContract(nftSwapInfo.Token, abicoder.PackDataWithFuncHash(uriFuncHash, id), blockNumber)
+		if err != nil {
+			return err
+		}
+		uri, err := abicoder.ParseStringInData(common.FromHex(res), 0)
+		if err != nil {
+			return fmt.Errorf("parse token uri of %v failed: %w", id, err)
+		}
+		metadata := &tokens.NFTMetadata{URI: uri}
+
+		// royalty is optional (EIP-2981)
+		res, err = b.CallContract(nftSwapInfo.Token, abicoder.PackDataWithFuncHash(royaltyInfoFuncHash, id, royaltyBpsSalePrice), blockNumber)
+		if data := common.FromHex(res); err == nil && len(data) >= 64 {
+			receiver := common.BytesToAddress(common.GetData(data, 0, 32))
+			if receiver != (common.Address{}) {
+				metadata.RoyaltyReceiver = receiver.LowerHex()
+				metadata.RoyaltyBps = common.GetBigInt(data, 32, 32).Uint64()
+			}
+		}
+		metadatas[i] = metadata
+	}
+	nftSwapInfo.Metadatas = metadatas
+	log.Debug("read nft metadatas success", "chainID", b.ChainConfig.ChainID, "txid", swapInfo.Hash, "logIndex", swapInfo.LogIndex, "token", nftSwapInfo.Token, "ids", nftSwapInfo.IDs)
+	return nil
+}
+
+func buildNFTSwapInWithMetadataInput(args *tokens.BuildTxArgs, multichainToken string, receiver common.Address) ([]byte, error) {
+	nftSwapInfo := args.NFTSwapInfo
+	if len(nftSwapInfo.Metadatas) != len(nftSwapInfo.IDs) {
+		return nil, fmt.Errorf("nft metadatas count %v mismatch ids count %v", len(nftSwapInfo.Metadatas), len(nftSwapInfo.IDs))
+	}
+	uris := make([]string, len(nftSwapInfo.Metadatas))
+	royaltyReceivers := make([]common.Address, len(nftSwapInfo.Metadatas))
+	royaltyBps := make([]*big.Int, len(nftSwapInfo.Metadatas))
+	for i, m := range nftSwapInfo.Metadatas {
+		uris[i] = m.URI
+		royaltyReceivers[i] = common.HexToAddress(m.RoyaltyReceiver)
+		royaltyBps[i] = new(big.Int).SetUint64(m.RoyaltyBps)
+	}
+
+	switch {
+	case nftSwapInfo.Batch:
+		if len(nftSwapInfo.IDs) != len(nftSwapInfo.Amounts) || len(nftSwapInfo.IDs) == 0 {
+			return nil, errWrongIDsOrAmounts
+		}
+		return abicoder.PackDataWithFuncHash(nft1155BatchSwapInWithMetadataFuncHash,
+			common.HexToHash(args.SwapID),
+			common.HexToAddress(multichainToken),
+			receiver,
+			nftSwapInfo.IDs,
+			nftSwapInfo.Amounts,
+			args.FromChainID,
+			uris,
+			royaltyReceivers,
+			royaltyBps,
+		), nil
+	case len(nftSwapInfo.Amounts) > 0:
+		if len(nftSwapInfo.IDs) != 1 || len(nftSwapInfo.Amounts) != 1 {
+			return nil, errWrongIDsOrAmounts
+		}
+		return abicoder.PackDataWithFuncHash(nft1155SwapInWithMetadataFuncHash,
+			common.HexToHash(args.SwapID),
+			common.HexToAddress(multichainToken),
+			receiver,
+			nftSwapInfo.IDs[0],
+			nftSwapInfo.Amounts[0],
+			args.FromChainID,
+			uris[0],
+			royaltyReceivers[0],
+			royaltyBps[0],
+		), nil
+	default:
+		if len(nftSwapInfo.IDs) != 1 || len(nftSwapInfo.Amounts) != 0 {
+			return nil, errWrongIDsOrAmounts
+		}
+		return abicoder.PackDataWithFuncHash(nft721SwapInWithMetadataFuncHash,
+			common.HexToHash(args.SwapID),
+			common.HexToAddress(multichainToken),
+			receiver,
+			nftSwapInfo.IDs[0],
+			args.FromChainID,
+			uris[0],
+			royaltyReceivers[0],
+			royaltyBps[0],
+		), nil
+	}
+}
@@ -353,6 +353,9 @@ func (b *Bridge) checkNFTSwapInfo(swapInfo *tokens.SwapTxInfo) error {
 			return errWrongIDsOrAmounts
 		}
 	}
+	if isNFTMetadataPreserved(dstBridge, multichainToken) {
+		return b.readNFTMetadatas(swapInfo)
+	}
 	return nil
 }
 
@@ -382,6 +385,11 @@ func (b *Bridge) buildNFTSwapTxInput(args *tokens.BuildTxArgs) (err error) {
 	var input []byte
 
 	switch {
+	case isNFTMetadataPreserved(b, multichainToken):
+		input, err = buildNFTSwapInWithMetadataInput(args, multichainToken, receiver)
+		if err != nil {
+			return err
+		}
 	case nftSwapInfo.Batch:
 		if len(nftSwapInfo.IDs) != len(nftSwapInfo.Amounts) || len(nftSwapInfo.IDs) == 0 {
 			return errWrongIDsOrAmounts
@@ -0,0 +1,115 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/common/hexutil"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/eth/abicoder"
+)
+
+// ensure Bridge impl tokens.TxRefunder
+var _ tokens.TxRefunder = &Bridge{}
+
+// BuildRefundTransaction build tx to refund unswappable swap
+// back to the original sender on the source chain
+func (b *Bridge) BuildRefundTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	if !args.Refund {
+		return nil, errors.New("forbid build refund tx without refund flag")
+	}
+	if args.FromChainID.Cmp(args.ToChainID) != 0 ||
+		(!params.IsTestMode && args.ToChainID.String() != b.ChainConfig.ChainID) {
+		return nil, tokens.ErrToChainIDMismatch
+	}
+	if args.Input != nil {
+		return nil, fmt.Errorf("forbid build raw refund tx with input data")
+	}
+	if args.From == "" {
+		return nil, fmt.Errorf("forbid empty sender")
+	}
+	routerMPC, err := router.GetRouterMPC(args.GetTokenID(), b.ChainConfig.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsEqualIgnoreCase(args.From, routerMPC) {
+		log.Error("build refund tx mpc mismatch", "have", args.From, "want", routerMPC)
+		return nil, tokens.ErrSenderMismatch
+	}
+
+	switch args.SwapType {
+	case tokens.ERC20SwapType:
+		err = b.buildERC20RefundTxInput(args)
+	default:
+		return nil, tokens.ErrSwapTypeNotSupported
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.setDefaults(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.buildTx(args)
+}
+
+// buildERC20RefundTxInput swapin the deposited token to the original sender
+// with the source chain ID, so the refund is distinguishable from normal swapins
+func (b *Bridge) buildERC20RefundTxInput(args *tokens.BuildTxArgs) error {
+	erc20SwapInfo := args.ERC20SwapInfo
+	if erc20SwapInfo == nil || erc20SwapInfo.Token == "" {
+		return errors.New("build refund tx without token")
+	}
+	if !common.IsEqualIgnoreCase(args.Bind, args.OriginFrom) {
+		return tokens.ErrWrongBindAddress
+	}
+	receiver := common.HexToAddress(args.Bind)
+	if receiver == (common.Address{}) || !common.IsHexAddress(args.Bind) {
+		log.Warn("refund to wrong receiver", "receiver", args.Bind)
+		return errors.New("can not refund to empty or invalid receiver")
+	}
+
+	tokenCfg := b.GetTokenConfig(erc20SwapInfo.Token)
+	if tokenCfg == nil {
+		return tokens.ErrMissTokenConfig
+	}
+
+	amount := tokens.CalcRefundValue(b.ChainConfig.ChainID, args.OriginValue)
+	if amount.Sign() <= 0 {
+		return tokens.ErrNoEnoughRefundValue
+	}
+
+	funcHash := getRefundFuncHash(tokenCfg)
+
+	input := abicoder.PackDataWithFuncHash(funcHash,
+		common.HexToHash(args.SwapID),
+		common.HexToAddress(erc20SwapInfo.Token),
+		receiver,
+		amount,
+		args.FromChainID,
+	)
+	args.Input = (*hexutil.Bytes)(&input)              // input
+	args.To = b.GetRouterContract(erc20SwapInfo.Token) // to
+	args.SwapValue = amount                            // swapValue
+
+	return nil
+}
+
+// getRefundFuncHash return the deposited asset back:
+// the underlying (or native) token if the token has underlying, otherwise the token itself
+func getRefundFuncHash(tokenCfg *tokens.TokenConfig) []byte {
+	if common.HexToAddress(tokenCfg.GetUnderlying()) == (common.Address{}) {
+		return AnySwapInFuncHash
+	}
+	if tokenCfg.ContractVersion == ForceAnySwapInNativeTokenVersion {
+		return AnySwapInNativeFuncHash
+	}
+	return AnySwapInUnderlyingFuncHash
+}
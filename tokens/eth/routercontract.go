@@ -2,13 +2,22 @@ package eth
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
+	"sync"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
 )
 
 var (
-	cachedPairsMap = make(map[string]string) // key is of format `chainid:token0:token1` (lowercase)
+	cachedPairsMap = new(sync.Map) // key is of format `chainid:token0:token1[:fee]` (lowercase)
+)
+
+// ensure Bridge impl tokens.ISwapTrade and tokens.ISwapTradeV3
+var (
+	_ tokens.ISwapTrade   = &Bridge{}
+	_ tokens.ISwapTradeV3 = &Bridge{}
 )
 
 func getCachedPairKey(chainID, token0, token1 string) string {
@@ -19,9 +28,8 @@ func getCachedPairKey(chainID, token0, token1 string) string {
 func (b *Bridge) GetPairFor(factory, token0, token1 string) (string, error) {
 	// first search in cache
 	key := getCachedPairKey(b.ChainConfig.ChainID, token0, token1)
-	cachedPairs := cachedPairsMap[key]
-	if cachedPairs != "" {
-		return cachedPairs, nil
+	if cachedPairs, exist := cachedPairsMap.Load(key); exist {
+		return cachedPairs.(string), nil
 	}
 
 	faunHash := common.FromHex("0xe6a43905")
@@ -34,7 +42,34 @@ func (b *Bridge) GetPairFor(factory, token0, token1 string) (string, error) {
 		return "", err
 	}
 	pairs := common.BytesToAddress(common.GetData(common.FromHex(res), 0, 32)).LowerHex()
-	cachedPairsMap[key] = pairs
-	cachedPairsMap[getCachedPairKey(b.ChainConfig.ChainID, token1, token0)] = pairs
+	cachedPairsMap.Store(key, pairs)
+	cachedPairsMap.Store(getCachedPairKey(b.ChainConfig.ChainID, token1, token0), pairs)
 	return pairs, nil
 }
+
+// GetPoolFor call "getPool(address,address,uint24)"
+func (b *Bridge) GetPoolFor(factory, token0, token1 string, fee uint32) (string, error) {
+	// first search in cache
+	key := getCachedPairKey(b.ChainConfig.ChainID, token0, token1) + fmt.Sprintf(":%v", fee)
+	if cachedPool, exist := cachedPairsMap.Load(key); exist {
+		return cachedPool.(string), nil
+	}
+
+	funcHash := common.FromHex("0x1698ee82")
+	data := make([]byte, 100)
+	copy(data[:4], funcHash)
+	copy(data[4:36], common.HexToAddress(token0).Hash().Bytes())
+	copy(data[36:68], common.HexToAddress(token1).Hash().Bytes())
+	copy(data[68:100], common.BigToHash(new(big.Int).SetUint64(uint64(fee))).Bytes())
+	res, err := b.CallContract(factory, data, "latest")
+	if err != nil {
+		return "", err
+	}
+	pool := common.BytesToAddress(common.GetData(common.FromHex(res), 0, 32))
+	if pool == (common.Address{}) {
+		return "", nil
+	}
+	cachedPairsMap.Store(key, pool.LowerHex())
+	cachedPairsMap.Store(getCachedPairKey(b.ChainConfig.ChainID, token1, token0)+fmt.Sprintf(":%v", fee), pool.LowerHex())
+	return pool.LowerHex(), nil
+}
@@ -35,3 +35,21 @@ func (b *Bridge) SendTransaction(signedTx interface{}) (txHash string, err error
 	}
 	return txHash, err
 }
+
+// MarshalSignedTx marshal signed tx to raw bytes
+func (b *Bridge) MarshalSignedTx(signedTx interface{}) ([]byte, error) {
+	tx, ok := signedTx.(*types.Transaction)
+	if !ok {
+		return nil, errors.New("wrong signed transaction type")
+	}
+	return tx.MarshalBinary()
+}
+
+// UnmarshalSignedTx unmarshal signed tx from raw bytes
+func (b *Bridge) UnmarshalSignedTx(data []byte) (interface{}, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -34,9 +34,28 @@ func (b *Bridge) verifyTransactionReceiver(rawTx interface{}, tokenID string) (*
 	return tx, nil
 }
 
+func (b *Bridge) verifyCancelTransaction(rawTx interface{}, args *tokens.BuildTxArgs) (*types.Transaction, error) {
+	tx, ok := rawTx.(*types.Transaction)
+	if !ok {
+		return nil, errors.New("[sign] wrong raw tx param")
+	}
+	if tx.To() == nil || !strings.EqualFold(tx.To().String(), args.From) {
+		return nil, errors.New("[sign] cancel tx receiver is not sender")
+	}
+	if tx.Value().Sign() != 0 || len(tx.Data()) != 0 {
+		return nil, errors.New("[sign] cancel tx with value or data")
+	}
+	return tx, nil
+}
+
 // MPCSignTransaction mpc sign raw tx
 func (b *Bridge) MPCSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs) (signTx interface{}, txHash string, err error) {
-	tx, err := b.verifyTransactionReceiver(rawTx, args.GetTokenID())
+	var tx *types.Transaction
+	if args.IsCancelTx() {
+		tx, err = b.verifyCancelTransaction(rawTx, args)
+	} else {
+		tx, err = b.verifyTransactionReceiver(rawTx, args.GetTokenID())
+	}
 	if err != nil {
 		return nil, "", err
 	}
@@ -75,6 +94,7 @@ func (b *Bridge) MPCSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs)
 		return nil, "", err
 	}
 	log.Info(logPrefix+"finished", "keyID", keyID, "txid", txid, "msghash", msgHash.String())
+	args.MPCKeyID = keyID
 
 	if len(rsvs) != 1 {
 		log.Warn("get sign status require one rsv but return many",
@@ -0,0 +1,107 @@
+package eth
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/types"
+)
+
+var (
+	// LogAnySwapIn(bytes32 indexed txhash, address indexed token, address indexed to, uint amount, uint fromChainID, uint toChainID)
+	LogAnySwapInTopic = common.FromHex("0xaac9ce45fe3adf5143598c4f18a369591a20a3384aedaf1b525d29127e1fcd55")
+)
+
+// ensure Bridge impl tokens.SwapInFinder
+var _ tokens.SwapInFinder = &Bridge{}
+
+// FindSwapIns impl SwapInFinder
+// find 'LogAnySwapIn' logs of all the configed router contracts
+func (b *Bridge) FindSwapIns(swapID string, fromBlock, toBlock uint64) ([]*tokens.SwapInRecord, error) {
+	topics := [][]common.Hash{{common.BytesToHash(LogAnySwapInTopic)}}
+	if swapID != "" {
+		topics = append(topics, []common.Hash{common.HexToHash(swapID)})
+	}
+	filter := &types.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: b.getAllRouterContracts(),
+		Topics:    topics,
+	}
+	logs, err := b.GetLogs(filter)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*tokens.SwapInRecord, 0, len(logs))
+	for _, rlog := range logs {
+		if rlog.Removed != nil && *rlog.Removed {
+			continue
+		}
+		if len(rlog.Topics) != 4 || rlog.Data == nil || len(*rlog.Data) != 96 {
+			continue
+		}
+		logData := *rlog.Data
+		record := &tokens.SwapInRecord{
+			SwapID:      rlog.Topics[1].Hex(),
+			Token:       common.BytesToAddress(rlog.Topics[2].Bytes()).LowerHex(),
+			Receiver:    common.BytesToAddress(rlog.Topics[3].Bytes()).LowerHex(),
+			Amount:      common.GetBigInt(logData, 0, 32),
+			FromChainID: common.GetBigInt(logData, 32, 32),
+			ToChainID:   common.GetBigInt(logData, 64, 32),
+		}
+		if rlog.TxHash != nil {
+			record.TxHash = rlog.TxHash.Hex()
+		}
+		if rlog.BlockNumber != nil {
+			record.BlockHeight = uint64(*rlog.BlockNumber)
+		}
+		if rlog.LogIndex != nil {
+			record.LogIndex = int(*rlog.LogIndex)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// GetBlockNumberByTime impl SwapInFinder
+// binary search in the block range as the block timestamp is increasing
+func (b *Bridge) GetBlockNumberByTime(timestamp, fromBlock, toBlock uint64) (uint64, error) {
+	low, high := fromBlock, toBlock
+	for low < high {
+		mid := low + (high-low)/2
+		block, err := b.GetBlockByNumber(new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, err
+		}
+		if block.Time == nil {
+			return 0, tokens.ErrRPCQueryError
+		}
+		if block.Time.ToInt().Uint64() < timestamp {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+	return low, nil
+}
+
+func (b *Bridge) getAllRouterContracts() []common.Address {
+	exists := make(map[string]struct{})
+	contracts := make([]common.Address, 0, 1)
+	addContract := func(contract string) {
+		key := strings.ToLower(contract)
+		if _, exist := exists[key]; exist || contract == "" {
+			return
+		}
+		exists[key] = struct{}{}
+		contracts = append(contracts, common.HexToAddress(contract))
+	}
+	addContract(b.ChainConfig.RouterContract)
+	b.TokenConfigMap.Range(func(k, v interface{}) bool {
+		addContract(v.(*tokens.TokenConfig).RouterContract)
+		return true
+	})
+	return contracts
+}
@@ -27,6 +27,12 @@ var (
 	LogAnySwapTradeTokensForTokensTopic = common.FromHex("0xfea6abdf4fd32f20966dff7619354cd82cd43dc78a3bee479f04c74dbfc585b3")
 	// LogAnySwapTradeTokensForNative(address[] path, address from, address to, uint amountIn, uint amountOutMin, uint fromChainID, uint toChainID);
 	LogAnySwapTradeTokensForNativeTopic = common.FromHex("0x278277e0209c347189add7bd92411973b5f6b8644f7ac62ea1be984ce993f8f4")
+	// LogAnySwapTradeTokensForTokensV3(address[] path, uint24[] fees, address from, address to, uint amountIn, uint amountOutMin, uint fromChainID, uint toChainID);
+	LogAnySwapTradeTokensForTokensV3Topic = common.FromHex("0x59de91631ffd89288c75a663857df94c08d727207cb5f634f964b44b9b220b1f")
+	// LogAnySwapTradeTokensForNativeV3(address[] path, uint24[] fees, address from, address to, uint amountIn, uint amountOutMin, uint fromChainID, uint toChainID);
+	LogAnySwapTradeTokensForNativeV3Topic = common.FromHex("0xd04d18daabc52d462d1a976bb30eda35d86557368d69b2768cdd646a5e3beedd")
+	// LogAnySwapTradeAndAggregate(address[] path, address from, address to, uint amountIn, uint amountOutMin, uint fromChainID, uint toChainID, address executor, bytes data);
+	LogAnySwapTradeAndAggregateTopic = common.FromHex("0x1022ce58dad124557d825449d107e7de280923882a84aac5c7686be5e9161fbb")
 
 	anySwapOutUnderlyingWithPermitFuncHash         = common.FromHex("0x8d7d3eea")
 	anySwapOutUnderlyingWithTransferPermitFuncHash = common.FromHex("0x1b91a934")
@@ -37,6 +43,9 @@ var (
 	// then we can replace temply to the old token and with this special version,
 	// after old token swapouts are processed, we should replace back to the new token config.
 	PauseSwapIntoTokenVersion = uint64(90000)
+
+	// max fee tier of concentrated liquidity pool (uint24)
+	maxPoolFee = uint64(1<<24 - 1)
 )
 
 func (b *Bridge) verifyERC20SwapTx(txHash string, logIndex int, allowUnstable bool) (*tokens.SwapTxInfo, error) {
@@ -85,6 +94,12 @@ func (b *Bridge) verifyERC20SwapTx(txHash string, logIndex int, allowUnstable bo
 				"forUnderlying", swapInfo.ERC20SwapInfo.ForUnderlying,
 				"amountOutMin", swapInfo.ERC20SwapInfo.AmountOutMin,
 			)
+			if swapInfo.ERC20SwapInfo.IsSwapTradeV3() {
+				ctx = append(ctx, "poolFees", swapInfo.ERC20SwapInfo.PoolFees)
+			}
+			if swapInfo.ERC20SwapInfo.IsSwapTradeAggregate() {
+				ctx = append(ctx, "executor", swapInfo.ERC20SwapInfo.Executor)
+			}
 		} else if swapInfo.ERC20SwapInfo.CallProxy != "" {
 			ctx = append(ctx,
 				"callProxy", swapInfo.ERC20SwapInfo.CallProxy,
@@ -235,6 +250,12 @@ func (b *Bridge) verifyERC20SwapTxLog(swapInfo *tokens.SwapTxInfo, rlog *types.R
 		err = b.parseERC20SwapTradeTxLog(swapInfo, rlog, false)
 	case bytes.Equal(logTopic, LogAnySwapTradeTokensForNativeTopic):
 		err = b.parseERC20SwapTradeTxLog(swapInfo, rlog, true)
+	case bytes.Equal(logTopic, LogAnySwapTradeTokensForTokensV3Topic):
+		err = b.parseERC20SwapTradeV3TxLog(swapInfo, rlog, false)
+	case bytes.Equal(logTopic, LogAnySwapTradeTokensForNativeV3Topic):
+		err = b.parseERC20SwapTradeV3TxLog(swapInfo, rlog, true)
+	case bytes.Equal(logTopic, LogAnySwapTradeAndAggregateTopic):
+		err = b.parseERC20SwapTradeAndAggregateTxLog(swapInfo, rlog)
 	default:
 		return tokens.ErrSwapoutLogNotFound
 	}
@@ -395,6 +416,116 @@ func (b *Bridge) parseERC20SwapTradeTxLog(swapInfo *tokens.SwapTxInfo, rlog *typ
 	}
 	swapInfo.ToChainID = common.GetBigInt(logData, 128, 32)
 
+	erc20SwapInfo.ForNative = false // the executor decides what to receive
+	erc20SwapInfo.Token = path[0]
+	erc20SwapInfo.Path = path[1:]
+
+	tokenCfg := b.GetTokenConfig(erc20SwapInfo.Token)
+	if tokenCfg == nil {
+		return tokens.ErrMissTokenConfig
+	}
+	erc20SwapInfo.TokenID = tokenCfg.TokenID
+
+	return checkSwapTradePath(swapInfo)
+}
+
+func (b *Bridge) parseERC20SwapTradeV3TxLog(swapInfo *tokens.SwapTxInfo, rlog *types.RPCLog, forNative bool) error {
+	if !params.IsSwapTradeEnabled() {
+		return tokens.ErrSwapTradeNotSupport
+	}
+	logTopics := rlog.Topics
+	if len(logTopics) != 3 {
+		return tokens.ErrTxWithWrongTopics
+	}
+	logData := *rlog.Data
+	if len(logData) < 256 {
+		return abicoder.ErrParseDataError
+	}
+	erc20SwapInfo := swapInfo.ERC20SwapInfo
+	erc20SwapInfo.ForNative = forNative
+	swapInfo.From = common.BytesToAddress(logTopics[1].Bytes()).LowerHex()
+	swapInfo.Bind = common.BytesToAddress(logTopics[2].Bytes()).LowerHex()
+	path, err := abicoder.ParseAddressSliceInData(logData, 0)
+	if err != nil {
+		return err
+	}
+	if len(path) < 3 {
+		return tokens.ErrTxWithWrongPath
+	}
+	fees, err := abicoder.ParseNumberSliceAsBigIntsInData(logData, 32)
+	if err != nil {
+		return err
+	}
+	// fee tiers of the hops in dest chain path (path[1:])
+	if len(fees) != len(path)-2 {
+		return tokens.ErrTxWithWrongPath
+	}
+	poolFees := make([]uint32, len(fees))
+	for i, fee := range fees {
+		if !fee.IsUint64() || fee.Uint64() > maxPoolFee {
+			return tokens.ErrTxWithWrongPath
+		}
+		poolFees[i] = uint32(fee.Uint64())
+	}
+	swapInfo.Value = common.GetBigInt(logData, 64, 32)
+	erc20SwapInfo.AmountOutMin = common.GetBigInt(logData, 96, 32)
+	if params.IsUseFromChainIDInReceiptDisabled(b.ChainConfig.ChainID) {
+		swapInfo.FromChainID = b.ChainConfig.GetChainID()
+	} else {
+		swapInfo.FromChainID = common.GetBigInt(logData, 128, 32)
+	}
+	swapInfo.ToChainID = common.GetBigInt(logData, 160, 32)
+
+	erc20SwapInfo.Token = path[0]
+	erc20SwapInfo.Path = path[1:]
+	erc20SwapInfo.PoolFees = poolFees
+
+	tokenCfg := b.GetTokenConfig(erc20SwapInfo.Token)
+	if tokenCfg == nil {
+		return tokens.ErrMissTokenConfig
+	}
+	erc20SwapInfo.TokenID = tokenCfg.TokenID
+
+	return checkSwapTradePath(swapInfo)
+}
+
+func (b *Bridge) parseERC20SwapTradeAndAggregateTxLog(swapInfo *tokens.SwapTxInfo, rlog *types.RPCLog) error {
+	if !params.IsSwapTradeEnabled() {
+		return tokens.ErrSwapTradeNotSupport
+	}
+	logTopics := rlog.Topics
+	if len(logTopics) != 3 {
+		return tokens.ErrTxWithWrongTopics
+	}
+	logData := *rlog.Data
+	if len(logData) < 288 {
+		return abicoder.ErrParseDataError
+	}
+	erc20SwapInfo := swapInfo.ERC20SwapInfo
+	swapInfo.From = common.BytesToAddress(logTopics[1].Bytes()).LowerHex()
+	swapInfo.Bind = common.BytesToAddress(logTopics[2].Bytes()).LowerHex()
+	path, err := abicoder.ParseAddressSliceInData(logData, 0)
+	if err != nil {
+		return err
+	}
+	// path is [srcToken, tokenIn, tokenOut]
+	if len(path) != 3 {
+		return tokens.ErrTxWithWrongPath
+	}
+	swapInfo.Value = common.GetBigInt(logData, 32, 32)
+	erc20SwapInfo.AmountOutMin = common.GetBigInt(logData, 64, 32)
+	if params.IsUseFromChainIDInReceiptDisabled(b.ChainConfig.ChainID) {
+		swapInfo.FromChainID = b.ChainConfig.GetChainID()
+	} else {
+		swapInfo.FromChainID = common.GetBigInt(logData, 96, 32)
+	}
+	swapInfo.ToChainID = common.GetBigInt(logData, 128, 32)
+	erc20SwapInfo.Executor = common.BytesToAddress(common.GetData(logData, 160, 32)).LowerHex()
+	erc20SwapInfo.CallData, err = abicoder.ParseBytesInData(logData, 192)
+	if err != nil {
+		return err
+	}
+
 	erc20SwapInfo.Token = path[0]
 	erc20SwapInfo.Path = path[1:]
 
@@ -408,7 +539,8 @@ func (b *Bridge) parseERC20SwapTradeTxLog(swapInfo *tokens.SwapTxInfo, rlog *typ
 }
 
 // amend trade path [0] if missing,
-// then check path exists in pairs of dest chain
+// then check path exists in pairs (or pools) of dest chain,
+// or the executor is allowed in aggregate mode
 //nolint:gocyclo // allow long check trade path
 func checkSwapTradePath(swapInfo *tokens.SwapTxInfo) error {
 	dstChainID := swapInfo.ToChainID.String()
@@ -435,6 +567,11 @@ func checkSwapTradePath(swapInfo *tokens.SwapTxInfo) error {
 		log.Warn("check swap trade path first element failed", "token", path[0])
 		return tokens.ErrTxWithWrongPath
 	}
+
+	if erc20SwapInfo.IsSwapTradeAggregate() {
+		return checkSwapTradeExecutor(dstChainID, erc20SwapInfo)
+	}
+
 	routerContract := dstBridge.GetRouterContract(multichainToken)
 	if routerContract == "" {
 		return tokens.ErrMissRouterInfo
@@ -454,7 +591,14 @@ func checkSwapTradePath(swapInfo *tokens.SwapTxInfo) error {
 			return tokens.ErrTxWithWrongPath
 		}
 	}
-	factory := routerInfo.RouterFactory
+
+	if erc20SwapInfo.IsSwapTradeV3() {
+		return checkSwapTradePools(dstBridge, path, erc20SwapInfo.PoolFees)
+	}
+	return checkSwapTradePairs(dstBridge, routerInfo.RouterFactory, path)
+}
+
+func checkSwapTradePairs(dstBridge tokens.IBridge, factory string, path []string) error {
 	if common.HexToAddress(factory) == (common.Address{}) {
 		return tokens.ErrSwapTradeNotSupport
 	}
@@ -477,6 +621,56 @@ func checkSwapTradePath(swapInfo *tokens.SwapTxInfo) error {
 	return nil
 }
 
+func checkSwapTradePools(dstBridge tokens.IBridge, path []string, fees []uint32) error {
+	factory := params.GetSwapTradeV3Factory(dstBridge.GetChainConfig().ChainID)
+	if common.HexToAddress(factory) == (common.Address{}) {
+		return tokens.ErrSwapTradeNotSupport
+	}
+
+	swapTrader, ok := dstBridge.(tokens.ISwapTradeV3)
+	if !ok {
+		return tokens.ErrSwapTradeNotSupport
+	}
+
+	if len(fees) != len(path)-1 {
+		return tokens.ErrTxWithWrongPath
+	}
+	for i := 1; i < len(path); i++ {
+		pool, err := swapTrader.GetPoolFor(factory, path[i-1], path[i], fees[i-1])
+		if err != nil || pool == "" {
+			if tokens.IsRPCQueryOrNotFoundError(err) {
+				return err
+			}
+			log.Warn("check swap trade path pools failed", "factory", factory, "token0", path[i-1], "token1", path[i], "fee", fees[i-1], "err", err)
+			return tokens.ErrTxWithWrongPath
+		}
+	}
+	return nil
+}
+
+func checkSwapTradeExecutor(dstChainID string, erc20SwapInfo *tokens.ERC20SwapInfo) error {
+	// path is [tokenIn, tokenOut]
+	if len(erc20SwapInfo.Path) != 2 {
+		return tokens.ErrTxWithWrongPath
+	}
+	if common.HexToAddress(erc20SwapInfo.Path[1]) == (common.Address{}) {
+		return tokens.ErrTxWithWrongPath
+	}
+	if !params.IsInSwapTradeExecutorWhitelist(dstChainID, erc20SwapInfo.Executor) {
+		log.Warn("check swap trade executor failed", "chainID", dstChainID, "executor", erc20SwapInfo.Executor)
+		return tokens.ErrSwapTradeExecutor
+	}
+	if len(erc20SwapInfo.CallData) < 4 {
+		log.Warn("check swap trade executor call data failed", "chainID", dstChainID, "executor", erc20SwapInfo.Executor)
+		return tokens.ErrSwapTradeExecutor
+	}
+	// the received amount is enforced onchain by router contract
+	if erc20SwapInfo.AmountOutMin == nil || erc20SwapInfo.AmountOutMin.Sign() <= 0 {
+		return tokens.ErrTxWithWrongPath
+	}
+	return nil
+}
+
 func (b *Bridge) checkSwapWithPermit(swapInfo *tokens.SwapTxInfo) error {
 	if params.IsSwapWithPermitEnabled() {
 		return nil
@@ -1,6 +1,8 @@
 package eth
 
 import (
+	"errors"
+	"math/big"
 	"time"
 
 	"github.com/anyswap/CrossChain-Router/v3/log"
@@ -37,6 +39,26 @@ func (b *Bridge) GetTransactionStatus(txHash string) (*tokens.TxStatus, error) {
 	return &txStatus, nil
 }
 
+// GetTxFee get gas used and effective gas price of onchain tx
+func (b *Bridge) GetTxFee(txHash string, txStatus *tokens.TxStatus) (gasUsed uint64, gasPrice *big.Int, err error) {
+	receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
+	if !ok || receipt == nil || receipt.GasUsed == nil {
+		return 0, nil, errors.New("tx receipt without gas used")
+	}
+	gasUsed = uint64(*receipt.GasUsed)
+	if receipt.EffectiveGasPrice != nil {
+		return gasUsed, receipt.EffectiveGasPrice.ToInt(), nil
+	}
+	tx, err := b.GetTransactionByHash(txHash)
+	if err != nil {
+		return 0, nil, err
+	}
+	if tx.Price == nil {
+		return 0, nil, errors.New("tx without gas price")
+	}
+	return gasUsed, tx.Price.ToInt(), nil
+}
+
 // VerifyMsgHash verify msg hash
 func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) error {
 	tx, ok := rawTx.(*types.Transaction)
@@ -60,3 +60,86 @@ type NonceSetter interface {
 	GetPoolNonce(address, height string) (uint64, error)
 	RecycleSwapNonce(sender string, nonce uint64)
 }
+
+// ISwapTradeV3 interface (to check concentrated liquidity pools in swap trade path)
+type ISwapTradeV3 interface {
+	GetPoolFor(factory, token0, token1 string, fee uint32) (string, error)
+}
+
+// SignedTxMarshaler interface (to persist and rebroadcast signed tx)
+type SignedTxMarshaler interface {
+	MarshalSignedTx(signedTx interface{}) ([]byte, error)
+	UnmarshalSignedTx(data []byte) (signedTx interface{}, err error)
+}
+
+// TxCanceler interface (to cancel a pending swap tx)
+type TxCanceler interface {
+	BuildCancelTransaction(args *BuildTxArgs) (rawTx interface{}, err error)
+}
+
+// TxRefunder interface (to refund unswappable swap on the source chain)
+type TxRefunder interface {
+	BuildRefundTransaction(args *BuildTxArgs) (rawTx interface{}, err error)
+}
+
+// SwapTxSubscriber interface (to subscribe and register swap txs instantly)
+type SwapTxSubscriber interface {
+	IsSwapTxSubscribeEnabled() bool
+	// SubscribeSwapTxs blocks until disconnected or stopped
+	SubscribeSwapTxs(stopCh <-chan struct{}, handler func(txHash string)) error
+}
+
+// TxRevertInfo revert info of onchain failed tx
+type TxRevertInfo struct {
+	Reason     string
+	GasLimit   uint64
+	GasUsed    uint64
+	IsOutOfGas bool
+}
+
+// TxRevertInfoGetter interface (to get why an onchain tx is failed)
+type TxRevertInfoGetter interface {
+	GetTxRevertInfo(txHash string, txStatus *TxStatus) (*TxRevertInfo, error)
+}
+
+// TxFeeGetter interface (to get the gas cost of an onchain tx)
+type TxFeeGetter interface {
+	GetTxFee(txHash string, txStatus *TxStatus) (gasUsed uint64, gasPrice *big.Int, err error)
+}
+
+// LiquidityGetter interface (to get the liquidity of token for swapping out)
+type LiquidityGetter interface {
+	// GetTokenLiquidity returns the account which holds the liquidity and its balance.
+	// returns nil liquidity if the token is minted when swapping out (no liquidity limit).
+	GetTokenLiquidity(tokenCfg *TokenConfig, mpc string) (holder string, liquidity *big.Int, err error)
+}
+
+// TokenAuditor interface (to audit the decimals and supply of token)
+type TokenAuditor interface {
+	GetErc20Decimals(contract string) (uint8, error)
+	GetErc20TotalSupply(contract string) (*big.Int, error)
+	GetErc20Balance(contract, address string) (*big.Int, error)
+}
+
+// SwapInRecord swapin record on the destination chain
+type SwapInRecord struct {
+	SwapID      string
+	FromChainID *big.Int
+	ToChainID   *big.Int
+	Token       string
+	Receiver    string
+	Amount      *big.Int
+	TxHash      string
+	BlockHeight uint64
+	LogIndex    int
+}
+
+// SwapInFinder interface (to find swapins on the destination chain to prevent double spending)
+type SwapInFinder interface {
+	// FindSwapIns find swapins in the block range [fromBlock, toBlock].
+	// find all swapins in the block range if swapID is empty.
+	FindSwapIns(swapID string, fromBlock, toBlock uint64) ([]*SwapInRecord, error)
+	// GetBlockNumberByTime get the first block in the block range [fromBlock, toBlock]
+	// whose timestamp is not less than the given timestamp (unix seconds).
+	GetBlockNumberByTime(timestamp, fromBlock, toBlock uint64) (uint64, error)
+}
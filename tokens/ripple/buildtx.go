@@ -77,28 +77,32 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
-	if asset.IsNative() {
-		needAmount := new(big.Int).Add(amount, b.getMinReserveFee())
-		err = b.checkNativeBalance(args.From, needAmount, true)
-		if err != nil {
-			return nil, err
-		}
-		err = b.checkNativeBalance(receiver, amount, false)
+	sourceAsset, err := b.GetPathFindSourceAsset(token.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	extra, err := b.setExtraArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var sendMaxStr string
+	var paths *data.PathSet
+	if sourceAsset != nil {
+		// deliver the token by cross currency payment through the DEX
+		var sendMax *data.Amount
+		sendMax, paths, err = b.setCrossCurrencyPaymentArgs(args, receiver, amt, sourceAsset)
 		if err != nil {
 			return nil, err
 		}
+		sendMaxStr = sendMax.String()
+		err = b.checkCrossCurrencyBalance(args.From, receiver, asset, amount, sendMax)
 	} else {
-		err = b.checkNativeBalance(receiver, nil, false)
-		if err != nil {
-			return nil, err
-		}
-		err = b.checkNonNativeBalance(asset.Currency, asset.Issuer, args.From, receiver, amt)
-		if err != nil {
-			return nil, err
-		}
+		extra.SendMax = nil
+		extra.Paths = nil
+		err = b.checkDirectPaymentBalance(args.From, receiver, asset, amount, amt)
 	}
-
-	extra, err := b.setExtraArgs(args)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +111,65 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 	memo := fmt.Sprintf("%v:%v:%v", args.FromChainID, args.SwapID, args.LogIndex)
 	return NewUnsignedPaymentTransaction(
 		ripplePubKey, nil, uint32(*extra.Sequence),
-		receiver, toTag, amt.String(), *extra.Fee, memo, "", 0)
+		receiver, toTag, amt.String(), sendMaxStr, *extra.Fee, memo, paths, 0)
+}
+
+func (b *Bridge) checkDirectPaymentBalance(sender, receiver string, asset *data.Asset, amount *big.Int, amt *data.Amount) error {
+	if asset.IsNative() {
+		needAmount := new(big.Int).Add(amount, b.getMinReserveFee())
+		err := b.checkNativeBalance(sender, needAmount, true)
+		if err != nil {
+			return err
+		}
+		return b.checkNativeBalance(receiver, amount, false)
+	}
+	err := b.checkNativeBalance(receiver, nil, false)
+	if err != nil {
+		return err
+	}
+	return b.checkNonNativeBalance(asset.Currency, asset.Issuer, sender, receiver, amt)
+}
+
+func (b *Bridge) checkCrossCurrencyBalance(sender, receiver string, asset *data.Asset, amount *big.Int, sendMax *data.Amount) error {
+	// receiver side
+	if asset.IsNative() {
+		err := b.checkNativeBalance(receiver, amount, false)
+		if err != nil {
+			return err
+		}
+	} else {
+		err := b.checkNativeBalance(receiver, nil, false)
+		if err != nil {
+			return err
+		}
+		if params.IsSwapServer {
+			_, err = b.GetAccountLine(asset.Currency, asset.Issuer, receiver)
+			if err != nil {
+				log.Error("get receiver account line failed", "currency", asset.Currency, "issuer", asset.Issuer, "receiver", receiver, "err", err)
+				return fmt.Errorf("%w %v", tokens.ErrBuildTxErrorAndDelay, "get receiver account line failed")
+			}
+		}
+	}
+	// sender side
+	if sendMax.IsNative() {
+		needAmount := new(big.Int).Add(big.NewInt(sendMax.Value.Drops()), b.getMinReserveFee())
+		return b.checkNativeBalance(sender, needAmount, true)
+	}
+	if !params.IsSwapServer {
+		return nil
+	}
+	sourceAsset := sendMax.Asset()
+	if sourceAsset.Issuer == sender {
+		return nil
+	}
+	accl, err := b.GetAccountLine(sourceAsset.Currency, sourceAsset.Issuer, sender)
+	if err != nil {
+		return fmt.Errorf("sender account line: %w", err)
+	}
+	if accl.Balance.Value.Compare(*sendMax.Value) < 0 {
+		return fmt.Errorf("insufficient %v balance, issuer: %v, account: %v", sourceAsset.Currency, sourceAsset.Issuer, sender)
+	}
+	return nil
 }
 
 func (b *Bridge) getReceiverAndAmount(args *tokens.BuildTxArgs, multichainToken string) (receiver string, destTag *uint32, amount *big.Int, err error) {
@@ -322,7 +384,7 @@ func (b *Bridge) GetSeq(args *tokens.BuildTxArgs) (nonceptr *uint64, err error)
 func NewUnsignedPaymentTransaction(
 	key crypto.Key, keyseq *uint32, txseq uint32,
 	dest string, destinationTag *uint32,
-	amt, fee, memo, path string, flags uint32,
+	amt, sendMax, fee, memo string, paths *data.PathSet, flags uint32,
 ) (data.Transaction, error) {
 	destination, err := data.NewAccountFromAddress(dest)
 	if err != nil {
@@ -348,13 +410,17 @@ func NewUnsignedPaymentTransaction(
 		tx.Memos = append(tx.Memos, *memoStr)
 	}
 
-	if path != "" {
-		tx.Paths, err = ParsePaths(path)
+	if sendMax != "" {
+		tx.SendMax, err = data.NewAmount(sendMax)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if paths != nil && len(*paths) > 0 {
+		tx.Paths = paths
+	}
+
 	base := tx.GetBase()
 
 	base.Sequence = txseq
@@ -374,7 +440,7 @@ func NewUnsignedPaymentTransaction(
 		return nil, err
 	}
 	log.Info("Build unsigned payment tx success",
-		"destination", dest, "amount", amt, "memo", memo,
+		"destination", dest, "amount", amt, "sendMax", sendMax, "memo", memo,
 		"fee", fee, "sequence", txseq, "txflags", txFlags.String(),
 		"signing hash", hash.String(), "blob", fmt.Sprintf("%X", msg))
 
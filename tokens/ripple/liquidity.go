@@ -0,0 +1,40 @@
+package ripple
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/data"
+)
+
+// ensure Bridge impl tokens.LiquidityGetter
+var _ tokens.LiquidityGetter = &Bridge{}
+
+// GetTokenLiquidity impl LiquidityGetter
+// the liquidity is the native balance or the trust line balance of mpc.
+// returns nil liquidity if mpc is the issuer (issuer has no limit).
+func (b *Bridge) GetTokenLiquidity(tokenCfg *tokens.TokenConfig, mpc string) (holder string, liquidity *big.Int, err error) {
+	assetI, exist := assetMap.Load(tokenCfg.ContractAddress)
+	if !exist {
+		return "", nil, fmt.Errorf("non exist asset %v", tokenCfg.ContractAddress)
+	}
+	asset := assetI.(*data.Asset)
+
+	if asset.IsNative() {
+		liquidity, err = b.GetBalance(mpc)
+		return mpc, liquidity, err
+	}
+	if asset.Issuer == mpc {
+		return "", nil, nil
+	}
+	accl, err := b.GetAccountLine(asset.Currency, asset.Issuer, mpc)
+	if err != nil {
+		return mpc, nil, err
+	}
+	// convert balance value to the smallest unit of token
+	rat := accl.Balance.Value.Rat()
+	rat.Mul(rat, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(tokenCfg.Decimals)), nil)))
+	liquidity = new(big.Int).Quo(rat.Num(), rat.Denom())
+	return mpc, liquidity, nil
+}
@@ -0,0 +1,201 @@
+package ripple
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/data"
+)
+
+const (
+	// MemoTypeSwapJSON memo type of json swap intent
+	// memo data is `{"bind":"<address>","toChainID":"<chainID>","value":"<optional value>"}`
+	MemoTypeSwapJSON = "swap/json"
+	// MemoTypeSwapBinary memo type of compact binary swap intent
+	// memo data is `version(1) | toChainID(8) | valueLen(1) | value(valueLen) | bind`
+	MemoTypeSwapBinary = "swap/bin"
+
+	swapBinaryVersion = 1
+)
+
+var (
+	errWrongSwapMemo      = errors.New("wrong swap memo")
+	errSwapValueExceeded  = errors.New("sum of swap values exceeds the delivered amount")
+	errMultipleRestValues = errors.New("only one swap intent can omit value")
+	errDestTagNotFound    = errors.New("destination tag is not registered")
+)
+
+// swapIntent swap intent carried by memos or destination tag
+// nil Value means the rest of the delivered amount
+type swapIntent struct {
+	Bind      string   `json:"bind"`
+	ToChainID *big.Int `json:"-"`
+	Value     *big.Int `json:"-"`
+
+	ToChainIDStr string `json:"toChainID"`
+	ValueStr     string `json:"value,omitempty"`
+}
+
+// getSwapIntents get swap intents of payment and allocate the delivered amount.
+// the order is: structured memos, then 'bind:toChainID' memo, then destination tag
+func (b *Bridge) getSwapIntents(payment *data.Payment, delivered *big.Int) ([]*swapIntent, error) {
+	intents, err := parseStructuredMemos(payment.Memos)
+	if err != nil {
+		return nil, err
+	}
+	if len(intents) == 0 {
+		swapInfo := &tokens.SwapTxInfo{}
+		if parseSwapMemos(swapInfo, payment.Memos) {
+			intents = append(intents, &swapIntent{Bind: swapInfo.Bind, ToChainID: swapInfo.ToChainID})
+		}
+	}
+	if len(intents) == 0 && payment.DestinationTag != nil {
+		intent, errt := b.getDestTagIntent(*payment.DestinationTag, payment.GetHash().String())
+		if errt != nil {
+			return nil, errt
+		}
+		intents = append(intents, intent)
+	}
+	if len(intents) == 0 {
+		return nil, tokens.ErrWrongBindAddress
+	}
+	return intents, allocateSwapValues(intents, delivered)
+}
+
+func allocateSwapValues(intents []*swapIntent, delivered *big.Int) error {
+	rest := new(big.Int).Set(delivered)
+	var restIntent *swapIntent
+	for _, intent := range intents {
+		if intent.Value == nil {
+			if restIntent != nil {
+				return errMultipleRestValues
+			}
+			restIntent = intent
+			continue
+		}
+		rest.Sub(rest, intent.Value)
+	}
+	if rest.Sign() < 0 {
+		return errSwapValueExceeded
+	}
+	if restIntent != nil {
+		restIntent.Value = rest
+	}
+	return nil
+}
+
+func parseStructuredMemos(memos data.Memos) (intents []*swapIntent, err error) {
+	for _, memo := range memos {
+		var intent *swapIntent
+		memoType := strings.TrimSpace(string(memo.Memo.MemoType.Bytes()))
+		switch memoType {
+		case MemoTypeSwapJSON:
+			intent, err = parseJSONSwapMemo(memo.Memo.MemoData.Bytes())
+		case MemoTypeSwapBinary:
+			intent, err = parseBinarySwapMemo(memo.Memo.MemoData.Bytes())
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errWrongSwapMemo, err)
+		}
+		if intent.Value != nil && intent.Value.Sign() <= 0 {
+			return nil, fmt.Errorf("%w: non positive value", errWrongSwapMemo)
+		}
+		intents = append(intents, intent)
+	}
+	return intents, nil
+}
+
+func parseJSONSwapMemo(memoData []byte) (*swapIntent, error) {
+	var intent swapIntent
+	if err := json.Unmarshal(memoData, &intent); err != nil {
+		return nil, err
+	}
+	toChainID, err := common.GetBigIntFromStr(intent.ToChainIDStr)
+	if err != nil {
+		return nil, err
+	}
+	intent.ToChainID = toChainID
+	if intent.ValueStr != "" {
+		value, errv := common.GetBigIntFromStr(intent.ValueStr)
+		if errv != nil {
+			return nil, errv
+		}
+		intent.Value = value
+	}
+	return &intent, nil
+}
+
+func parseBinarySwapMemo(memoData []byte) (*swapIntent, error) {
+	if len(memoData) < 10 {
+		return nil, errors.New("memo data too short")
+	}
+	if memoData[0] != swapBinaryVersion {
+		return nil, fmt.Errorf("unknown version %v", memoData[0])
+	}
+	intent := &swapIntent{}
+	intent.ToChainID = new(big.Int).SetUint64(binary.BigEndian.Uint64(memoData[1:9]))
+	valueLen := int(memoData[9])
+	if len(memoData) < 10+valueLen {
+		return nil, errors.New("memo data too short")
+	}
+	if valueLen > 0 {
+		intent.Value = new(big.Int).SetBytes(memoData[10 : 10+valueLen])
+	}
+	intent.Bind = string(memoData[10+valueLen:])
+	return intent, nil
+}
+
+// checkSwapIntent check the bind address on the destination chain
+func (b *Bridge) checkSwapIntent(intent *swapIntent) error {
+	dstBridge := router.GetBridgeByChainID(intent.ToChainID.String())
+	if dstBridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	if !dstBridge.IsValidAddress(intent.Bind) {
+		return tokens.ErrWrongBindAddress
+	}
+	return nil
+}
+
+// getDestTagIntent get swap intent of registered destination tag
+// the route is resolved only from our own database, as the bind address
+// must be checked independently and can not be trusted from others.
+// the route becomes immutable once it is used by a deposit.
+func (b *Bridge) getDestTagIntent(tag uint32, txHash string) (*swapIntent, error) {
+	if !mongodb.HasClient() {
+		return nil, fmt.Errorf("%w: %v, no database to resolve it", errDestTagNotFound, tag)
+	}
+	chainID := b.ChainConfig.ChainID
+	destTag, err := mongodb.FindDestTag(chainID, uint64(tag))
+	if err != nil || destTag == nil || destTag.Bind == "" {
+		return nil, fmt.Errorf("%w: %v", errDestTagNotFound, tag)
+	}
+	toChainID, err := common.GetBigIntFromStr(destTag.ToChainID)
+	if err != nil {
+		return nil, err
+	}
+	if !destTag.Used {
+		if err = mongodb.MarkDestTagUsed(chainID, uint64(tag), txHash); err != nil {
+			return nil, err
+		}
+	}
+	return &swapIntent{Bind: destTag.Bind, ToChainID: toChainID}, nil
+}
+
+// hasSwapIntent whether payment may carry swap intents
+func hasSwapIntent(payment *data.Payment) bool {
+	if intents, err := parseStructuredMemos(payment.Memos); err != nil || len(intents) > 0 {
+		return true
+	}
+	return parseSwapMemos(&tokens.SwapTxInfo{}, payment.Memos) || payment.DestinationTag != nil
+}
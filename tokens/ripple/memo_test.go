@@ -0,0 +1,90 @@
+package ripple
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+)
+
+func newBinarySwapMemo(version byte, toChainID uint64, value []byte, bind string) []byte {
+	memo := make([]byte, 10, 10+len(value)+len(bind))
+	memo[0] = version
+	binary.BigEndian.PutUint64(memo[1:9], toChainID)
+	memo[9] = byte(len(value))
+	memo = append(memo, value...)
+	return append(memo, bind...)
+}
+
+func TestParseBinarySwapMemo(t *testing.T) {
+	bind := "0x1111111111111111111111111111111111111111"
+	tests := []struct {
+		name      string
+		memo      []byte
+		wantErr   bool
+		toChainID uint64
+		value     *big.Int
+		bind      string
+	}{
+		{
+			name:      "with value",
+			memo:      newBinarySwapMemo(swapBinaryVersion, 56, big.NewInt(1000000).Bytes(), bind),
+			toChainID: 56,
+			value:     big.NewInt(1000000),
+			bind:      bind,
+		},
+		{
+			name:      "without value",
+			memo:      newBinarySwapMemo(swapBinaryVersion, 1, nil, bind),
+			toChainID: 1,
+			bind:      bind,
+		},
+		{
+			name:      "empty bind",
+			memo:      newBinarySwapMemo(swapBinaryVersion, 1, []byte{1}, ""),
+			toChainID: 1,
+			value:     big.NewInt(1),
+		},
+		{
+			name:    "unknown version",
+			memo:    newBinarySwapMemo(swapBinaryVersion+1, 1, nil, bind),
+			wantErr: true,
+		},
+		{
+			name:    "too short header",
+			memo:    []byte{swapBinaryVersion, 0, 0, 0, 0, 0, 0, 0, 1},
+			wantErr: true,
+		},
+		{
+			name:      "minimum length",
+			memo:      newBinarySwapMemo(swapBinaryVersion, 1, nil, ""),
+			toChainID: 1,
+		},
+		{
+			name:    "value length exceeds data",
+			memo:    append(newBinarySwapMemo(swapBinaryVersion, 1, nil, "")[:9], 8, 1, 2),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		intent, err := parseBinarySwapMemo(tt.memo)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%v: want error, have nil", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: want no error, have %v", tt.name, err)
+			continue
+		}
+		if intent.ToChainID.Uint64() != tt.toChainID {
+			t.Errorf("%v: to chain id is %v, want %v", tt.name, intent.ToChainID, tt.toChainID)
+		}
+		if (intent.Value == nil) != (tt.value == nil) || (tt.value != nil && intent.Value.Cmp(tt.value) != 0) {
+			t.Errorf("%v: value is %v, want %v", tt.name, intent.Value, tt.value)
+		}
+		if intent.Bind != tt.bind {
+			t.Errorf("%v: bind is %v, want %v", tt.name, intent.Bind, tt.bind)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package ripple
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/data"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/websockets"
+)
+
+const (
+	// custom key of source asset, the full key is 'pathFindSourceAsset:<token address>'
+	pathFindSourceAssetKey = "pathFindSourceAsset:"
+	// custom key of slippage percent of the path find quote
+	pathFindSlippageKey = "pathFindSlippage"
+
+	// custom key of websocket addresses (comma separated) to subscribe path_find,
+	// use the subscribe addresses if it is not configured
+	pathFindAddressKey = "pathFindAddress"
+
+	defPathFindSlippage = uint64(1)
+	maxPathFindSlippage = uint64(50)
+
+	pathFindTimeout = 10 * time.Second
+
+	// protocol limits of the payment paths
+	maxPaymentPaths     = 6
+	maxPaymentPathSteps = 8
+)
+
+// GetPathFindSourceAsset get the asset mpc pays to deliver the token by cross currency payment
+// return nil if the token is delivered by direct payment
+func (b *Bridge) GetPathFindSourceAsset(tokenAddr string) (*data.Asset, error) {
+	sourceAsset := params.GetCustom(b.ChainConfig.ChainID, pathFindSourceAssetKey+tokenAddr)
+	if sourceAsset == "" || sourceAsset == tokenAddr {
+		return nil, nil
+	}
+	return convertToAsset(sourceAsset)
+}
+
+func (b *Bridge) getPathFindSlippage() uint64 {
+	slippageStr := params.GetCustom(b.ChainConfig.ChainID, pathFindSlippageKey)
+	if slippageStr == "" {
+		return defPathFindSlippage
+	}
+	slippage, err := common.GetUint64FromStr(slippageStr)
+	if err != nil || slippage > maxPathFindSlippage {
+		log.Warn("wrong path find slippage, use default", "chainID", b.ChainConfig.ChainID, "slippage", slippageStr)
+		return defPathFindSlippage
+	}
+	return slippage
+}
+
+// PaymentPath is an alternative to deliver amount by paying source amount through paths
+type PaymentPath struct {
+	SourceAmount data.Amount
+	Paths        data.PathSet
+}
+
+func (b *Bridge) getPathFindAddresses() []string {
+	if addrs := getCustomAddresses(b.ChainConfig.ChainID, pathFindAddressKey); len(addrs) > 0 {
+		return addrs
+	}
+	return b.getSubscribeAddresses()
+}
+
+// GetPaymentPaths find payment paths by the websocket path_find subscription,
+// and fall back to ripple_path_find if there is no websocket address configured
+func (b *Bridge) GetPaymentPaths(from, to string, amount *data.Amount, sourceAsset *data.Asset) (alternatives []*PaymentPath, err error) {
+	wsAddrs := b.getPathFindAddresses()
+	if len(wsAddrs) == 0 {
+		return b.ripplePathFind(from, to, amount, sourceAsset)
+	}
+	src, err := data.NewAccountFromAddress(from)
+	if err != nil {
+		return nil, err
+	}
+	dest, err := data.NewAccountFromAddress(to)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < rpcRetryTimes; i++ {
+		for _, wsAddr := range wsAddrs {
+			alternatives, err = subscribePathFind(wsAddr, *src, *dest, amount, sourceAsset)
+			if err == nil {
+				return alternatives, nil
+			}
+			log.Warn("path find failed", "chainID", b.ChainConfig.ChainID, "wsAddr", wsAddr, "err", err)
+		}
+		time.Sleep(rpcRetryInterval)
+	}
+	return nil, wrapRPCQueryError(err, "GetPaymentPaths")
+}
+
+// subscribePathFind create a path_find subscription and wait for the full reply,
+// the first reply may be computed on part of the order books only
+func subscribePathFind(wsAddr string, src, dest data.Account, amount *data.Amount, sourceAsset *data.Asset) ([]*PaymentPath, error) {
+	remote, err := websockets.NewRemote(wsAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer remote.Close()
+
+	sourceCurrencies := []websockets.SourceCurrency{
+		{Currency: sourceAsset.Currency, Issuer: sourceAsset.Issuer},
+	}
+	res, err := remote.PathFindCreate(src, dest, *amount, nil, &sourceCurrencies)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := time.NewTimer(pathFindTimeout)
+	defer timeout.Stop()
+WAIT_LOOP:
+	for !res.FullReply {
+		select {
+		case msg, ok := <-remote.Incoming:
+			if !ok {
+				return nil, errSubscribeConnClose
+			}
+			if update, isPathFind := msg.(*websockets.PathFindCreateResult); isPathFind {
+				res = update
+			}
+		case <-timeout.C:
+			log.Warn("path find wait full reply timeout", "wsAddr", wsAddr, "alternatives", len(res.Alternatives))
+			break WAIT_LOOP
+		}
+	}
+	if errc := remote.PathFindClose(); errc != nil {
+		log.Warn("close path find failed", "wsAddr", wsAddr, "err", errc)
+	}
+
+	alternatives := make([]*PaymentPath, 0, len(res.Alternatives))
+	for _, alt := range res.Alternatives {
+		alternatives = append(alternatives, &PaymentPath{
+			SourceAmount: alt.SourceAmount,
+			Paths:        alt.PathsComputed,
+		})
+	}
+	return alternatives, nil
+}
+
+// ripplePathFind call ripple_path_find
+func (b *Bridge) ripplePathFind(from, to string, amount *data.Amount, sourceAsset *data.Asset) ([]*PaymentPath, error) {
+	sourceCurrency := map[string]string{"currency": sourceAsset.Currency}
+	if sourceAsset.Issuer != "" {
+		sourceCurrency["issuer"] = sourceAsset.Issuer
+	}
+	rpcParams := map[string]interface{}{
+		"source_account":      from,
+		"destination_account": to,
+		"destination_amount":  amount,
+		"source_currencies":   []interface{}{sourceCurrency},
+	}
+	var err error
+	urls := append(b.GetGatewayConfig().APIAddress, b.GetGatewayConfig().APIAddressExt...)
+	for i := 0; i < rpcRetryTimes; i++ {
+		for _, url := range urls {
+			var res *websockets.RipplePathFindResult
+			err = client.RPCPostWithTimeout(b.RPCClientTimeout, &res, url, "ripple_path_find", rpcParams)
+			if err == nil && res != nil {
+				alternatives := make([]*PaymentPath, 0, len(res.Alternatives))
+				for _, alt := range res.Alternatives {
+					alternatives = append(alternatives, &PaymentPath{
+						SourceAmount: alt.SrcAmount,
+						Paths:        alt.PathsComputed,
+					})
+				}
+				return alternatives, nil
+			}
+		}
+		time.Sleep(rpcRetryInterval)
+	}
+	return nil, wrapRPCQueryError(err, "ripplePathFind")
+}
+
+// findPaymentPaths find the cheapest paths to deliver amount by paying source asset,
+// and return all the found paths of the source asset as the allowed paths
+func (b *Bridge) findPaymentPaths(from, to string, amount *data.Amount, sourceAsset *data.Asset) (sourceAmount *data.Amount, paths, allowedPaths data.PathSet, err error) {
+	alternatives, err := b.GetPaymentPaths(from, to, amount, sourceAsset)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	for _, alt := range alternatives {
+		if alt.SourceAmount.Value == nil || !isSameAsset(alt.SourceAmount.Asset(), sourceAsset) {
+			continue
+		}
+		allowedPaths = append(allowedPaths, alt.Paths...)
+		if sourceAmount == nil || alt.SourceAmount.Value.Less(*sourceAmount.Value) {
+			sourceAmount = &alt.SourceAmount
+			paths = alt.Paths
+		}
+	}
+	if sourceAmount == nil {
+		return nil, nil, nil, fmt.Errorf("%w no payment path from %v to deliver %v", tokens.ErrBuildTxErrorAndDelay, sourceAsset, amount)
+	}
+	return sourceAmount, paths, allowedPaths, nil
+}
+
+// setCrossCurrencyPaymentArgs set send max and paths of cross currency payment
+// if they are already set (eg. by the initiator), then verify the send max is within slippage
+func (b *Bridge) setCrossCurrencyPaymentArgs(args *tokens.BuildTxArgs, receiver string, amount *data.Amount, sourceAsset *data.Asset) (sendMax *data.Amount, paths *data.PathSet, err error) {
+	sourceAmount, foundPaths, allowedPaths, err := b.findPaymentPaths(args.From, receiver, amount, sourceAsset)
+	if err != nil {
+		return nil, nil, err
+	}
+	slippage := b.getPathFindSlippage()
+	extra := args.Extra
+
+	if extra.SendMax == nil {
+		sendMax, err = applySlippage(sourceAmount, slippage)
+		if err != nil {
+			return nil, nil, err
+		}
+		sendMaxStr := sendMax.String()
+		extra.SendMax = &sendMaxStr
+		if len(foundPaths) > 0 {
+			pathsData, errm := json.Marshal(foundPaths)
+			if errm != nil {
+				return nil, nil, errm
+			}
+			pathsStr := string(pathsData)
+			extra.Paths = &pathsStr
+		}
+	} else {
+		sendMax, err = data.NewAmount(*extra.SendMax)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wrong send max '%v', %w", *extra.SendMax, err)
+		}
+		if !isSameAsset(sendMax.Asset(), sourceAsset) {
+			return nil, nil, fmt.Errorf("send max asset mismatch, have %v want %v", sendMax.Asset(), sourceAsset)
+		}
+		// allow the quote moving within slippage between initiator and verifier
+		maxSendMax, errs := applySlippage(sourceAmount, 2*slippage)
+		if errs != nil {
+			return nil, nil, errs
+		}
+		if maxSendMax.Value.Less(*sendMax.Value) {
+			return nil, nil, fmt.Errorf("send max %v exceeds slippage bound %v", sendMax, maxSendMax)
+		}
+	}
+
+	if extra.Paths != nil {
+		var ps data.PathSet
+		if err = json.Unmarshal([]byte(*extra.Paths), &ps); err != nil {
+			return nil, nil, fmt.Errorf("wrong payment paths, %w", err)
+		}
+		if err = checkPaymentPaths(ps, allowedPaths); err != nil {
+			return nil, nil, err
+		}
+		paths = &ps
+	}
+
+	log.Info("set cross currency payment args", "swapID", args.SwapID, "receiver", receiver,
+		"amount", amount, "sourceAmount", sourceAmount, "sendMax", sendMax, "slippage", slippage)
+	return sendMax, paths, nil
+}
+
+// checkPaymentPaths check the paths are bounded by the protocol limits,
+// and every path is one of the allowed paths found by ourself
+func checkPaymentPaths(paths, allowedPaths data.PathSet) error {
+	if len(paths) > maxPaymentPaths {
+		return fmt.Errorf("too many payment paths %v, max %v", len(paths), maxPaymentPaths)
+	}
+	allowed := make(map[string]struct{}, len(allowedPaths))
+	for _, path := range allowedPaths {
+		allowed[path.String()] = struct{}{}
+	}
+	for _, path := range paths {
+		if len(path) == 0 || len(path) > maxPaymentPathSteps {
+			return fmt.Errorf("payment path has %v steps, max %v", len(path), maxPaymentPathSteps)
+		}
+		if _, exist := allowed[path.String()]; !exist {
+			return fmt.Errorf("payment path %v is not found by path find", path)
+		}
+	}
+	return nil
+}
+
+func applySlippage(amount *data.Amount, slippage uint64) (*data.Amount, error) {
+	if amount.IsNative() {
+		drops := new(big.Int).SetInt64(amount.Value.Drops())
+		drops.Mul(drops, new(big.Int).SetUint64(100+slippage))
+		drops.Div(drops, big.NewInt(100))
+		if !drops.IsInt64() {
+			return nil, fmt.Errorf("amount value %v is overflow of type int64", drops)
+		}
+		return data.NewAmount(drops.Int64())
+	}
+	factor, err := data.NewNonNativeValue(int64(100+slippage), -2)
+	if err != nil {
+		return nil, err
+	}
+	value, err := amount.Value.Multiply(*factor)
+	if err != nil {
+		return nil, err
+	}
+	return &data.Amount{
+		Value:    value,
+		Currency: amount.Currency,
+		Issuer:   amount.Issuer,
+	}, nil
+}
+
+func isSameAsset(a, b *data.Asset) bool {
+	return a.Currency == b.Currency && a.Issuer == b.Issuer
+}
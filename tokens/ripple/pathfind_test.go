@@ -0,0 +1,53 @@
+package ripple
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/data"
+)
+
+func mustParsePaths(t *testing.T, paths ...string) data.PathSet {
+	ps := make(data.PathSet, 0, len(paths))
+	for _, s := range paths {
+		path, err := data.NewPath(s)
+		if err != nil {
+			t.Fatalf("parse path '%v' failed: %v", s, err)
+		}
+		ps = append(ps, path)
+	}
+	return ps
+}
+
+func TestCheckPaymentPaths(t *testing.T) {
+	const (
+		usdPath = "USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"
+		eurPath = "EUR/rhub8VRN55s94qWKDv6jmDy1pUykJzF3wq"
+		acct    = "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
+	)
+	allowed := mustParsePaths(t, usdPath, eurPath+" => "+usdPath)
+
+	tooLong := acct
+	for i := 0; i < maxPaymentPathSteps; i++ {
+		tooLong += " => " + acct
+	}
+
+	tests := []struct {
+		name    string
+		paths   data.PathSet
+		wantErr bool
+	}{
+		{"no paths", nil, false},
+		{"allowed path", mustParsePaths(t, usdPath), false},
+		{"all allowed paths", mustParsePaths(t, eurPath+" => "+usdPath, usdPath), false},
+		{"not found path", mustParsePaths(t, acct+" => "+usdPath), true},
+		{"too many steps", mustParsePaths(t, tooLong), true},
+		{"too many paths", mustParsePaths(t, usdPath, usdPath, usdPath, usdPath, usdPath, usdPath, usdPath), true},
+		{"empty path", data.PathSet{data.Path{}}, true},
+	}
+	for _, tt := range tests {
+		err := checkPaymentPaths(tt.paths, allowed)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%v: check payment paths error %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
@@ -17,7 +17,11 @@ func (b *Bridge) RegisterSwap(txHash string, args *tokens.RegisterArgs) ([]*toke
 	}
 }
 
+// registerERC20SwapTx if logIndex is 0 then register all swaps in tx
 func (b *Bridge) registerERC20SwapTx(txHash string, logIndex int) ([]*tokens.SwapTxInfo, []error) {
+	if logIndex == 0 {
+		return b.verifySwapoutTxs(txHash, true)
+	}
 	swapInfo, err := b.verifySwapoutTx(txHash, logIndex, true)
 	return []*tokens.SwapTxInfo{swapInfo}, []error{err}
 }
@@ -34,6 +34,7 @@ type PathFindCreateCommand struct {
 
 type SourceCurrency struct {
 	Currency string `json:"currency"`
+	Issuer   string `json:"issuer,omitempty"`
 }
 
 func (r *Remote) PathFindCreate(src, dest data.Account, amt data.Amount, sendMax *data.Amount, sourceCurrencies *[]SourceCurrency) (*PathFindCreateResult, error) {
@@ -54,6 +55,25 @@ func (r *Remote) PathFindCreate(src, dest data.Account, amt data.Amount, sendMax
 	return cmd.Result, nil
 }
 
+type PathFindCloseCommand struct {
+	*Command
+	Subcommand string `json:"subcommand"`
+}
+
+// PathFindClose stops sending the path_find stream messages
+func (r *Remote) PathFindClose() error {
+	cmd := &PathFindCloseCommand{
+		Command:    newCommand("path_find"),
+		Subcommand: "close",
+	}
+	r.outgoing <- cmd
+	<-cmd.Ready
+	if cmd.CommandError != nil {
+		return cmd.CommandError
+	}
+	return nil
+}
+
 /*
 
 {
@@ -93,14 +113,14 @@ func (r *Remote) PathFindCreate(src, dest data.Account, amt data.Amount, sendMax
 */
 
 type PathFindAlternative struct {
-	// TODO paths_computed
-	SourceAmount data.Amount `json:"source_amount"`
+	PathsComputed data.PathSet `json:"paths_computed,omitempty"`
+	SourceAmount  data.Amount  `json:"source_amount"`
 }
 
 type PathFindCreateResult struct {
-	SourceAccount      data.Account `json:"source_account"`
-	DestinationAccount data.Account `json:"destination_account"`
-	DestinationAmount  data.Amount  `json:"destination_amount"`
-	// TODO
-	Alternatives []PathFindAlternative
+	SourceAccount      data.Account          `json:"source_account"`
+	DestinationAccount data.Account          `json:"destination_account"`
+	DestinationAmount  data.Amount           `json:"destination_amount"`
+	Alternatives       []PathFindAlternative `json:"alternatives"`
+	FullReply          bool                  `json:"full_reply"`
 }
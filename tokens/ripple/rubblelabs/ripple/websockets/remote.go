@@ -502,6 +502,25 @@ func (r *Remote) Subscribe(ledger, transactions, transactionsProposed, server bo
 	return cmd.Result, nil
 }
 
+// Synchronously subscribe to validated transactions of accounts and the ledger stream
+// Streams are recived asynchronously over the Incoming channel
+func (r *Remote) SubscribeAccounts(accounts []data.Account) (*SubscribeResult, error) {
+	cmd := &SubscribeCommand{
+		Command:  newCommand("subscribe"),
+		Streams:  []string{"ledger"},
+		Accounts: accounts,
+	}
+	r.outgoing <- cmd
+	<-cmd.Ready
+	if cmd.CommandError != nil {
+		return nil, cmd.CommandError
+	}
+	if cmd.Result.LedgerStreamMsg == nil {
+		return nil, fmt.Errorf("Missing ledger subscribe response")
+	}
+	return cmd.Result, nil
+}
+
 type OrderBookSubscription struct {
 	TakerGets data.Asset `json:"taker_gets"`
 	TakerPays data.Asset `json:"taker_pays"`
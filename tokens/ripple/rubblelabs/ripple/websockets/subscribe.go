@@ -58,9 +58,10 @@ var streamMessageFactory = map[string]func() interface{}{
 
 type SubscribeCommand struct {
 	*Command
-	Streams []string                `json:"streams"`
-	Books   []OrderBookSubscription `json:"books,omitempty"`
-	Result  *SubscribeResult        `json:"result,omitempty"`
+	Streams  []string                `json:"streams"`
+	Accounts []data.Account          `json:"accounts,omitempty"`
+	Books    []OrderBookSubscription `json:"books,omitempty"`
+	Result   *SubscribeResult        `json:"result,omitempty"`
 }
 
 type SubscribeResult struct {
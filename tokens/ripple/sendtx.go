@@ -1,6 +1,7 @@
 package ripple
 
 import (
+	"bytes"
 	"fmt"
 	"time"
 
@@ -55,3 +56,28 @@ func (b *Bridge) SendTransaction(signedTx interface{}) (txHash string, err error
 	}
 	return "", err
 }
+
+// MarshalSignedTx marshal signed tx to raw bytes
+func (b *Bridge) MarshalSignedTx(signedTx interface{}) ([]byte, error) {
+	tx, ok := signedTx.(data.Transaction)
+	if !ok {
+		return nil, tokens.ErrWrongRawTx
+	}
+	_, raw, err := data.Raw(tx)
+	return raw, err
+}
+
+// UnmarshalSignedTx unmarshal signed tx from raw bytes
+func (b *Bridge) UnmarshalSignedTx(raw []byte) (interface{}, error) {
+	tx, err := data.ReadTransaction(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	// the decoder does not fill in the hash, recompute it from the signed blob
+	hash, _, err := data.Raw(tx)
+	if err != nil {
+		return nil, err
+	}
+	copy(tx.GetHash().Bytes(), hash.Bytes())
+	return tx, nil
+}
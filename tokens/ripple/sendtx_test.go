@@ -0,0 +1,46 @@
+package ripple
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/data"
+)
+
+const (
+	tSeed        = "sp5fghtJtpUorTwvof1NpDXAzNwf5"
+	tDestAddress = "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe"
+)
+
+func TestUnmarshalSignedTxHash(t *testing.T) {
+	b := NewCrossChainBridge()
+
+	key, err := ImportKeyFromSeed(tSeed, "ecdsa")
+	if err != nil {
+		t.Fatalf("import key failed: %v", err)
+	}
+	keyseq := uint32(0)
+	rawTx, err := NewUnsignedPaymentTransaction(key, &keyseq, 1, tDestAddress, nil, "1000000", "", "10", "", nil, 0)
+	if err != nil {
+		t.Fatalf("build tx failed: %v", err)
+	}
+	signedTx, txHash, err := b.SignTransactionWithRippleKey(rawTx, key, &keyseq)
+	if err != nil {
+		t.Fatalf("sign tx failed: %v", err)
+	}
+
+	raw, err := b.MarshalSignedTx(signedTx)
+	if err != nil {
+		t.Fatalf("marshal tx failed: %v", err)
+	}
+	decoded, err := b.UnmarshalSignedTx(raw)
+	if err != nil {
+		t.Fatalf("unmarshal tx failed: %v", err)
+	}
+	tx, ok := decoded.(data.Transaction)
+	if !ok {
+		t.Fatalf("unmarshal tx has wrong type %T", decoded)
+	}
+	if have := tx.GetHash().String(); have != txHash {
+		t.Errorf("unmarshaled tx hash mismatch, have %v want %v", have, txHash)
+	}
+}
@@ -0,0 +1,212 @@
+package ripple
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/data"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/websockets"
+)
+
+var (
+	// ensure Bridge impl tokens.SwapTxSubscriber
+	_ tokens.SwapTxSubscriber = &Bridge{}
+
+	errNoDepositAddress   = errors.New("no deposit address to subscribe")
+	errSubscribeConnClose = errors.New("subscribe connection closed")
+)
+
+const (
+	// custom key of websocket addresses (comma separated) to subscribe deposit txs
+	subscribeAddressKey = "subscribeAddress"
+	// synced cursor name of the subscribed ledger
+	subscribeCursorName = "subscribeLedger"
+
+	accountTxPageSize  = 200
+	saveCursorInterval = uint64(10) // ledgers
+)
+
+func (b *Bridge) getSubscribeAddresses() []string {
+	return getCustomAddresses(b.ChainConfig.ChainID, subscribeAddressKey)
+}
+
+func getCustomAddresses(chainID, key string) []string {
+	addrsStr := params.GetCustom(chainID, key)
+	if addrsStr == "" {
+		return nil
+	}
+	var addrs []string
+	for _, addr := range strings.Split(addrsStr, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+// IsSwapTxSubscribeEnabled is swap tx subscribe enabled
+func (b *Bridge) IsSwapTxSubscribeEnabled() bool {
+	return mongodb.HasClient() && len(b.getSubscribeAddresses()) > 0
+}
+
+// GetDepositAddresses get deposit addresses of all tokens
+func (b *Bridge) GetDepositAddresses() []string {
+	exist := make(map[string]struct{})
+	addrs := make([]string, 0, 1)
+	addAddress := func(addr string) {
+		if _, ok := exist[addr]; ok || addr == "" {
+			return
+		}
+		exist[addr] = struct{}{}
+		addrs = append(addrs, addr)
+	}
+	addAddress(b.ChainConfig.RouterContract)
+	b.TokenConfigMap.Range(func(k, v interface{}) bool {
+		addAddress(v.(*tokens.TokenConfig).RouterContract)
+		return true
+	})
+	return addrs
+}
+
+// SubscribeSwapTxs subscribe txs of deposit addresses and call handler with the swap txs.
+// missed ledgers since the persisted cursor are back-filled by account_tx.
+func (b *Bridge) SubscribeSwapTxs(stopCh <-chan struct{}, handler func(txHash string)) error {
+	chainID := b.ChainConfig.ChainID
+	depositAddrs := b.GetDepositAddresses()
+	if len(depositAddrs) == 0 {
+		return errNoDepositAddress
+	}
+	depositAddrsMap := make(map[string]struct{}, len(depositAddrs))
+	accounts := make([]data.Account, 0, len(depositAddrs))
+	for _, addr := range depositAddrs {
+		account, err := data.NewAccountFromAddress(addr)
+		if err != nil {
+			return err
+		}
+		accounts = append(accounts, *account)
+		depositAddrsMap[addr] = struct{}{}
+	}
+
+	remote, wsAddr, err := b.dialSubscribeRemote()
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	subRes, err := remote.SubscribeAccounts(accounts)
+	if err != nil {
+		return err
+	}
+	startLedger := uint64(subRes.LedgerStreamMsg.LedgerSequence)
+	log.Info("subscribe deposit addresses success", "chainID", chainID, "wsAddr", wsAddr, "addresses", depositAddrs, "ledger", startLedger)
+
+	handleTx := func(txm *data.TransactionWithMetaData) {
+		if b.isSwapTx(txm, depositAddrsMap) {
+			handler(txm.GetHash().String())
+		}
+	}
+
+	// back-fill from the persisted cursor
+	var backfilled int32
+	fromLedger := b.getSubscribeCursor()
+	if fromLedger == 0 || fromLedger >= startLedger {
+		atomic.StoreInt32(&backfilled, 1)
+	} else {
+		go func() {
+			err := b.backfillSwapTxs(wsAddr, accounts, fromLedger, startLedger, handleTx)
+			if err != nil {
+				log.Warn("back-fill swap txs failed", "chainID", chainID, "fromLedger", fromLedger, "toLedger", startLedger, "err", err)
+				return
+			}
+			atomic.StoreInt32(&backfilled, 1)
+		}()
+	}
+
+	lastSaved := fromLedger
+	for {
+		select {
+		case <-stopCh:
+			return nil
+		case msg, ok := <-remote.Incoming:
+			if !ok {
+				return errSubscribeConnClose
+			}
+			switch msg := msg.(type) {
+			case *websockets.TransactionStreamMsg:
+				if msg.Validated {
+					handleTx(&msg.Transaction)
+				}
+			case *websockets.LedgerStreamMsg:
+				// the txs of the latest ledger may be not handled yet
+				ledger := uint64(msg.LedgerSequence) - 1
+				if atomic.LoadInt32(&backfilled) == 1 && ledger >= lastSaved+saveCursorInterval {
+					if errs := mongodb.UpdateSyncedCursor(chainID, subscribeCursorName, ledger); errs == nil {
+						lastSaved = ledger
+					}
+				}
+			}
+		}
+	}
+}
+
+func (b *Bridge) dialSubscribeRemote() (remote *websockets.Remote, wsAddr string, err error) {
+	for _, wsAddr = range b.getSubscribeAddresses() {
+		remote, err = websockets.NewRemote(wsAddr)
+		if err == nil {
+			return remote, wsAddr, nil
+		}
+		log.Warn("dial subscribe remote failed", "chainID", b.ChainConfig.ChainID, "wsAddr", wsAddr, "err", err)
+	}
+	return nil, "", err
+}
+
+func (b *Bridge) getSubscribeCursor() uint64 {
+	cursor, err := mongodb.FindSyncedCursor(b.ChainConfig.ChainID, subscribeCursorName)
+	if err == nil && cursor != nil {
+		return cursor.Cursor
+	}
+	return b.ChainConfig.InitialHeight
+}
+
+// backfillSwapTxs back-fill with a separate connection, as account_tx
+// responses will be blocked by the stream messages in the subscribe connection
+func (b *Bridge) backfillSwapTxs(wsAddr string, accounts []data.Account, fromLedger, toLedger uint64, handleTx func(*data.TransactionWithMetaData)) error {
+	remote, err := websockets.NewRemote(wsAddr)
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	log.Info("start back-fill swap txs", "chainID", b.ChainConfig.ChainID, "fromLedger", fromLedger, "toLedger", toLedger)
+	start := time.Now()
+	count := 0
+	for _, account := range accounts {
+		for txm := range remote.AccountTx(account, accountTxPageSize, int64(fromLedger), int64(toLedger)) {
+			handleTx(txm)
+			count++
+		}
+	}
+	log.Info("back-fill swap txs finished", "chainID", b.ChainConfig.ChainID, "fromLedger", fromLedger, "toLedger", toLedger, "txs", count, "timespent", time.Since(start).String())
+	return nil
+}
+
+// isSwapTx is successful payment to deposit address with swap memos
+func (b *Bridge) isSwapTx(txm *data.TransactionWithMetaData, depositAddrs map[string]struct{}) bool {
+	if !txm.MetaData.TransactionResult.Success() {
+		return false
+	}
+	payment, ok := txm.Transaction.(*data.Payment)
+	if !ok || payment.GetTransactionType() != data.PAYMENT {
+		return false
+	}
+	if _, exist := depositAddrs[payment.Destination.String()]; !exist {
+		return false
+	}
+	return hasSwapIntent(payment)
+}
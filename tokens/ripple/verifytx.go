@@ -3,6 +3,7 @@ package ripple
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
@@ -60,65 +61,90 @@ func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tok
 	}
 }
 
+func (b *Bridge) verifySwapoutTx(txHash string, logIndex int, allowUnstable bool) (*tokens.SwapTxInfo, error) {
+	swapInfos, errs := b.verifySwapoutTxs(txHash, allowUnstable)
+	if len(swapInfos) == 1 && logIndex == 0 {
+		return swapInfos[0], errs[0]
+	}
+	for i, swapInfo := range swapInfos {
+		if swapInfo.LogIndex == logIndex {
+			return swapInfo, errs[i]
+		}
+	}
+	swapInfo := &tokens.SwapTxInfo{}
+	swapInfo.SwapType = tokens.ERC20SwapType
+	swapInfo.Hash = txHash
+	swapInfo.LogIndex = logIndex
+	swapInfo.FromChainID = b.ChainConfig.GetChainID()
+	return swapInfo, tokens.ErrLogIndexOutOfRange
+}
+
+// verifySwapoutTxs verify all swaps in tx, LogIndex is the index of swap intent
+// if the tx is invalid, return only one swap info with the error
+//
 //nolint:gocyclo,funlen // ok
-func (b *Bridge) verifySwapoutTx(txHash string, _ int, allowUnstable bool) (*tokens.SwapTxInfo, error) {
+func (b *Bridge) verifySwapoutTxs(txHash string, allowUnstable bool) ([]*tokens.SwapTxInfo, []error) {
 	swapInfo := &tokens.SwapTxInfo{}
 	swapInfo.SwapType = tokens.ERC20SwapType          // SwapType
 	swapInfo.Hash = txHash                            // Hash
-	swapInfo.LogIndex = 0                             // LogIndex always 0 (do not support multiple in one tx)
+	swapInfo.LogIndex = 0                             // LogIndex
 	swapInfo.FromChainID = b.ChainConfig.GetChainID() // FromChainID
 
+	errResult := func(err error) ([]*tokens.SwapTxInfo, []error) {
+		return []*tokens.SwapTxInfo{swapInfo}, []error{err}
+	}
+
 	tx, err := b.GetTransaction(txHash)
 	if err != nil {
 		log.Debug("[verifySwapout] "+b.ChainConfig.BlockChain+" Bridge::GetTransaction fail", "tx", txHash, "err", err)
-		return swapInfo, tokens.ErrTxNotFound
+		return errResult(tokens.ErrTxNotFound)
 	}
 
 	txres, ok := tx.(*websockets.TxResult)
 	if !ok {
-		return swapInfo, errTxResultType
+		return errResult(errTxResultType)
 	}
 
 	if !txres.Validated {
-		return swapInfo, tokens.ErrTxIsNotValidated
+		return errResult(tokens.ErrTxIsNotValidated)
 	}
 
 	if !allowUnstable {
 		h, errf := b.GetLatestBlockNumber()
 		if errf != nil {
-			return swapInfo, errf
+			return errResult(errf)
 		}
 
 		if h < uint64(txres.TransactionWithMetaData.LedgerSequence)+b.GetChainConfig().Confirmations {
-			return swapInfo, tokens.ErrTxNotStable
+			return errResult(tokens.ErrTxNotStable)
 		}
 		if h < b.ChainConfig.InitialHeight {
-			return swapInfo, tokens.ErrTxBeforeInitialHeight
+			return errResult(tokens.ErrTxBeforeInitialHeight)
 		}
 	}
 
 	// Check tx status
 	if !txres.TransactionWithMetaData.MetaData.TransactionResult.Success() {
-		return swapInfo, tokens.ErrTxWithWrongStatus
+		return errResult(tokens.ErrTxWithWrongStatus)
 	}
 
 	asset := txres.TransactionWithMetaData.MetaData.DeliveredAmount.Asset().String()
 	token := b.GetTokenConfig(asset)
 	if token == nil {
-		return swapInfo, tokens.ErrMissTokenConfig
+		return errResult(tokens.ErrMissTokenConfig)
 	}
 
 	payment, ok := txres.TransactionWithMetaData.Transaction.(*data.Payment)
 	if !ok || payment.GetTransactionType() != data.PAYMENT {
 		log.Printf("Not a payment transaction")
-		return swapInfo, fmt.Errorf("not a payment transaction")
+		return errResult(fmt.Errorf("not a payment transaction"))
 	}
 
 	txRecipient := payment.Destination.String()
 	// special usage, ripple has no router contract, and use deposit methods
 	depositAddress := b.GetRouterContract(asset)
 	if !common.IsEqualIgnoreCase(txRecipient, depositAddress) {
-		return swapInfo, tokens.ErrTxWithWrongReceiver
+		return errResult(tokens.ErrTxWithWrongReceiver)
 	}
 
 	erc20SwapInfo := &tokens.ERC20SwapInfo{}
@@ -128,16 +154,11 @@ func (b *Bridge) verifySwapoutTx(txHash string, _ int, allowUnstable bool) (*tok
 
 	err = b.checkToken(token, &txres.TransactionWithMetaData)
 	if err != nil {
-		return swapInfo, err
-	}
-
-	if success := parseSwapMemos(swapInfo, payment.Memos); !success {
-		log.Info("wrong memos", "memos", common.ToJSONString(payment.Memos, false))
-		return swapInfo, tokens.ErrWrongBindAddress
+		return errResult(err)
 	}
 
 	if !txres.TransactionWithMetaData.MetaData.DeliveredAmount.IsPositive() {
-		return swapInfo, tokens.ErrTxWithNoPayment
+		return errResult(tokens.ErrTxWithNoPayment)
 	}
 	amt := tokens.ToBits(txres.TransactionWithMetaData.MetaData.DeliveredAmount.Value.String(), token.Decimals)
 
@@ -145,18 +166,40 @@ func (b *Bridge) verifySwapoutTx(txHash string, _ int, allowUnstable bool) (*tok
 	swapInfo.From = payment.Account.String() // From
 	swapInfo.Value = amt
 
-	err = b.checkSwapoutInfo(swapInfo)
+	intents, err := b.getSwapIntents(payment, amt)
 	if err != nil {
-		return swapInfo, err
+		log.Info("wrong swap memos", "txid", txHash, "memos", common.ToJSONString(payment.Memos, false), "destTag", payment.DestinationTag, "err", err)
+		return errResult(err)
 	}
 
-	if !allowUnstable {
-		log.Info("verify swapin pass",
-			"asset", asset, "from", swapInfo.From, "to", swapInfo.To,
-			"bind", swapInfo.Bind, "value", swapInfo.Value, "txid", swapInfo.Hash,
-			"height", swapInfo.Height, "timestamp", swapInfo.Timestamp, "logIndex", swapInfo.LogIndex)
+	swapInfos := make([]*tokens.SwapTxInfo, 0, len(intents))
+	errs := make([]error, 0, len(intents))
+	for i, intent := range intents {
+		info := *swapInfo
+		info.SwapInfo = tokens.SwapInfo{ERC20SwapInfo: &tokens.ERC20SwapInfo{
+			Token:   asset,
+			TokenID: token.TokenID,
+		}}
+		info.LogIndex = i                           // LogIndex
+		info.Bind = intent.Bind                     // Bind
+		info.ToChainID = intent.ToChainID           // ToChainID
+		info.Value = new(big.Int).Set(intent.Value) // Value
+		swapInfos = append(swapInfos, &info)
+
+		err = b.checkSwapIntent(intent)
+		if err == nil {
+			err = b.checkSwapoutInfo(&info)
+		}
+		errs = append(errs, err)
+
+		if !allowUnstable && err == nil {
+			log.Info("verify swapin pass",
+				"asset", asset, "from", info.From, "to", info.To,
+				"bind", info.Bind, "value", info.Value, "txid", info.Hash,
+				"height", info.Height, "timestamp", info.Timestamp, "logIndex", info.LogIndex)
+		}
 	}
-	return swapInfo, nil
+	return swapInfos, errs
 }
 
 func (b *Bridge) checkToken(token *tokens.TokenConfig, txmeta *data.TransactionWithMetaData) error {
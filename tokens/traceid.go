@@ -0,0 +1,21 @@
+package tokens
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+)
+
+// GetTraceIDOfSwapKey get trace ID of swap key (fromChainID:txid:logIndex).
+// the trace ID is 16 bytes in hex as required by opentelemetry.
+func GetTraceIDOfSwapKey(swapKey string) string {
+	hash := common.Keccak256Hash([]byte(strings.ToLower(swapKey)))
+	return hex.EncodeToString(hash[:16])
+}
+
+// GetSwapTraceID get trace ID of swap, which is derived from the swap key
+func GetSwapTraceID(fromChainID, txid string, logIndex int) string {
+	return GetTraceIDOfSwapKey(fmt.Sprintf("%v:%v:%v", fromChainID, txid, logIndex))
+}
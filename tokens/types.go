@@ -51,11 +51,23 @@ type ERC20SwapInfo struct {
 	ForUnderlying bool     `json:"forUnderlying,omitempty"`
 	Path          []string `json:"path,omitempty"`
 	AmountOutMin  *big.Int `json:"amountOutMin,omitempty"`
+	PoolFees      []uint32 `json:"poolFees,omitempty"` // fee tiers of concentrated liquidity pools in path
+	Executor      string   `json:"executor,omitempty"` // aggregator executor called with CallData
 
 	CallProxy string        `json:"callProxy,omitempty"`
 	CallData  hexutil.Bytes `json:"callData,omitempty"`
 }
 
+// IsSwapTradeV3 is swap trade through concentrated liquidity pools
+func (s *ERC20SwapInfo) IsSwapTradeV3() bool {
+	return len(s.Path) > 0 && len(s.PoolFees) > 0
+}
+
+// IsSwapTradeAggregate is swap trade through aggregator executor
+func (s *ERC20SwapInfo) IsSwapTradeAggregate() bool {
+	return len(s.Path) > 0 && s.Executor != ""
+}
+
 // NFTSwapInfo struct
 type NFTSwapInfo struct {
 	Token   string        `json:"token"`
@@ -64,6 +76,15 @@ type NFTSwapInfo struct {
 	Amounts []*big.Int    `json:"amounts"`
 	Batch   bool          `json:"batch"`
 	Data    hexutil.Bytes `json:"data,omitempty"`
+
+	Metadatas []*NFTMetadata `json:"metadatas,omitempty"` // same order as IDs
+}
+
+// NFTMetadata nft metadata and royalty info on the source chain
+type NFTMetadata struct {
+	URI             string `json:"uri"`
+	RoyaltyReceiver string `json:"royaltyReceiver,omitempty"`
+	RoyaltyBps      uint64 `json:"royaltyBps,omitempty"` // in basis points
 }
 
 // AnyCallSwapInfo struct
@@ -182,6 +203,19 @@ type SwapArgs struct {
 	FromChainID *big.Int `json:"fromChainID"`
 	ToChainID   *big.Int `json:"toChainID"`
 	Reswapping  bool     `json:"reswapping,omitempty"`
+	Refund      bool     `json:"refund,omitempty"`
+	TraceID     string   `json:"traceID,omitempty"`
+}
+
+// GetTraceID get trace ID of swap (derived from the swap key if not assigned)
+func (args *SwapArgs) GetTraceID() string {
+	if args.TraceID != "" {
+		return args.TraceID
+	}
+	if args.FromChainID == nil || args.SwapID == "" {
+		return ""
+	}
+	return GetSwapTraceID(args.FromChainID.String(), args.SwapID, args.LogIndex)
 }
 
 // BuildTxArgs struct
@@ -197,6 +231,15 @@ type BuildTxArgs struct {
 	Memo        string         `json:"memo,omitempty"`
 	Input       *hexutil.Bytes `json:"input,omitempty"`
 	Extra       *AllExtras     `json:"extra,omitempty"`
+
+	MPCKeyID   string      `json:"-"` // assigned after mpc sign
+	ChainLease *ChainLease `json:"-"` // assigned in cluster mode to fence the swap writes
+}
+
+// ChainLease chain lease with fencing token in cluster mode
+type ChainLease struct {
+	Owner string
+	Token uint64
 }
 
 // AllExtras struct
@@ -206,6 +249,9 @@ type AllExtras struct {
 	Sequence   *uint64       `json:"sequence,omitempty"`
 	Fee        *string       `json:"fee,omitempty"`
 	Gas        *uint64       `json:"gas,omitempty"`
+	IsCancel   bool          `json:"isCancel,omitempty"`
+	SendMax    *string       `json:"sendMax,omitempty"`
+	Paths      *string       `json:"paths,omitempty"`
 }
 
 // EthExtraArgs struct
@@ -235,6 +281,11 @@ func (args *BuildTxArgs) GetExtraArgs() *BuildTxArgs {
 	}
 }
 
+// IsCancelTx is cancel tx (to cancel pending swap tx)
+func (args *BuildTxArgs) IsCancelTx() bool {
+	return args.Extra != nil && args.Extra.IsCancel
+}
+
 // GetTxNonce get tx nonce
 func (args *BuildTxArgs) GetTxNonce() uint64 {
 	if args.Extra != nil {
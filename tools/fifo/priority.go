@@ -0,0 +1,193 @@
+package fifo
+
+import (
+	"sync"
+	"time"
+)
+
+// PriorityQueue priority queue with multiple priority classes.
+// class 0 has the highest priority.
+// items of the same class are taken round robin among groups (fairness),
+// and items of each group are taken in fifo order.
+type PriorityQueue struct {
+	lock    sync.Mutex
+	classes []*priorityClass
+	count   int
+
+	// take one item of lower class after taking starvationLimit items of higher classes
+	starvationLimit int
+	served          int
+
+	// take the oldest item if it has waited longer than maxWaitTime
+	maxWaitTime time.Duration
+}
+
+type priorityClass struct {
+	groups map[string][]*priorityItem
+	order  []string
+	next   int
+	count  int
+}
+
+type priorityItem struct {
+	item    interface{}
+	addTime time.Time
+}
+
+// NewPriorityQueue creates a new and empty priority queue
+func NewPriorityQueue(classCount, starvationLimit int, maxWaitTime time.Duration) *PriorityQueue {
+	if classCount <= 0 {
+		classCount = 1
+	}
+	q := &PriorityQueue{
+		classes:         make([]*priorityClass, classCount),
+		starvationLimit: starvationLimit,
+		maxWaitTime:     maxWaitTime,
+	}
+	for i := range q.classes {
+		q.classes[i] = &priorityClass{groups: make(map[string][]*priorityItem)}
+	}
+	return q
+}
+
+// Len Return the number of items in the queue
+func (q *PriorityQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.count
+}
+
+// LenOfClass Return the number of items of the priority class
+func (q *PriorityQueue) LenOfClass(class int) int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if class < 0 || class >= len(q.classes) {
+		return 0
+	}
+	return q.classes[class].count
+}
+
+// Add an item to the end of the group of the priority class
+func (q *PriorityQueue) Add(item interface{}, class int, group string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if item == nil {
+		panic("can not add nil item to priority queue")
+	}
+	if class < 0 {
+		class = 0
+	} else if class >= len(q.classes) {
+		class = len(q.classes) - 1
+	}
+
+	c := q.classes[class]
+	items, exist := c.groups[group]
+	if !exist {
+		c.order = append(c.order, group)
+	}
+	c.groups[group] = append(items, &priorityItem{item: item, addTime: time.Now()})
+	c.count++
+	q.count++
+}
+
+// Next Remove the item with the highest priority and return it.
+// Returns nil when there are no items left in queue.
+func (q *PriorityQueue) Next() interface{} {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.count == 0 {
+		return nil
+	}
+
+	if class, group := q.findTimeoutItem(); class >= 0 {
+		return q.take(class, group)
+	}
+
+	highest := -1
+	lower := -1
+	for i, c := range q.classes {
+		if c.count == 0 {
+			continue
+		}
+		if highest < 0 {
+			highest = i
+		} else {
+			lower = i
+			break
+		}
+	}
+
+	class := highest
+	switch {
+	case lower < 0:
+		q.served = 0
+	case q.starvationLimit > 0 && q.served >= q.starvationLimit:
+		class = lower
+		q.served = 0
+	default:
+		q.served++
+	}
+	return q.take(class, q.classes[class].nextGroup())
+}
+
+// findTimeoutItem find the oldest group head which has waited longer than maxWaitTime
+func (q *PriorityQueue) findTimeoutItem() (class int, group string) {
+	class = -1
+	if q.maxWaitTime <= 0 {
+		return class, group
+	}
+	var oldest time.Time
+	deadline := time.Now().Add(-q.maxWaitTime)
+	for i, c := range q.classes {
+		for g, items := range c.groups {
+			if len(items) == 0 {
+				continue
+			}
+			addTime := items[0].addTime
+			if addTime.Before(deadline) && (class < 0 || addTime.Before(oldest)) {
+				class, group, oldest = i, g, addTime
+			}
+		}
+	}
+	return class, group
+}
+
+func (q *PriorityQueue) take(class int, group string) interface{} {
+	c := q.classes[class]
+	items := c.groups[group]
+	head := items[0]
+	items[0] = nil
+	if len(items) == 1 {
+		c.removeGroup(group)
+	} else {
+		c.groups[group] = items[1:]
+	}
+	c.count--
+	q.count--
+	return head.item
+}
+
+// nextGroup get next non empty group round robin
+func (c *priorityClass) nextGroup() string {
+	if c.next >= len(c.order) {
+		c.next = 0
+	}
+	group := c.order[c.next]
+	c.next++
+	return group
+}
+
+func (c *priorityClass) removeGroup(group string) {
+	delete(c.groups, group)
+	for i, g := range c.order {
+		if g == group {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			if i < c.next {
+				c.next--
+			}
+			break
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package fifo
+
+import (
+	"testing"
+	"time"
+)
+
+type priorityAdd struct {
+	item  string
+	class int
+	group string
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	tests := []struct {
+		name            string
+		classCount      int
+		starvationLimit int
+		adds            []priorityAdd
+		want            []string
+	}{
+		{
+			name:       "fifo in one group",
+			classCount: 1,
+			adds:       []priorityAdd{{"a1", 0, "a"}, {"a2", 0, "a"}, {"a3", 0, "a"}},
+			want:       []string{"a1", "a2", "a3"},
+		},
+		{
+			name:       "round robin among groups",
+			classCount: 1,
+			adds:       []priorityAdd{{"a1", 0, "a"}, {"a2", 0, "a"}, {"a3", 0, "a"}, {"b1", 0, "b"}, {"c1", 0, "c"}, {"c2", 0, "c"}},
+			want:       []string{"a1", "b1", "c1", "a2", "c2", "a3"},
+		},
+		{
+			name:       "higher class first",
+			classCount: 3,
+			adds:       []priorityAdd{{"l1", 2, "a"}, {"n1", 1, "a"}, {"h1", 0, "a"}, {"n2", 1, "a"}},
+			want:       []string{"h1", "n1", "n2", "l1"},
+		},
+		{
+			name:       "class out of range",
+			classCount: 2,
+			adds:       []priorityAdd{{"l1", 5, "a"}, {"h1", -1, "a"}},
+			want:       []string{"h1", "l1"},
+		},
+		{
+			name:            "starvation promotion",
+			classCount:      2,
+			starvationLimit: 2,
+			adds:            []priorityAdd{{"h1", 0, "a"}, {"h2", 0, "a"}, {"h3", 0, "a"}, {"h4", 0, "a"}, {"h5", 0, "a"}, {"l1", 1, "a"}, {"l2", 1, "a"}},
+			want:            []string{"h1", "h2", "l1", "h3", "h4", "l2", "h5"},
+		},
+		{
+			name:            "starvation promotion of the next non empty class",
+			classCount:      3,
+			starvationLimit: 1,
+			adds:            []priorityAdd{{"h1", 0, "a"}, {"h2", 0, "a"}, {"l1", 2, "a"}, {"l2", 2, "a"}},
+			want:            []string{"h1", "l1", "h2", "l2"},
+		},
+		{
+			name:       "no starvation promotion if disabled",
+			classCount: 2,
+			adds:       []priorityAdd{{"h1", 0, "a"}, {"h2", 0, "a"}, {"h3", 0, "a"}, {"l1", 1, "a"}},
+			want:       []string{"h1", "h2", "h3", "l1"},
+		},
+	}
+	for _, tt := range tests {
+		q := NewPriorityQueue(tt.classCount, tt.starvationLimit, 0)
+		for _, add := range tt.adds {
+			q.Add(add.item, add.class, add.group)
+		}
+		if q.Len() != len(tt.adds) {
+			t.Errorf("%v: queue length is %v, want %v", tt.name, q.Len(), len(tt.adds))
+		}
+		for i, want := range tt.want {
+			have := q.Next()
+			if have != want {
+				t.Errorf("%v: item %v is %v, want %v", tt.name, i, have, want)
+			}
+		}
+		if have := q.Next(); have != nil {
+			t.Errorf("%v: item of empty queue is %v, want nil", tt.name, have)
+		}
+	}
+}
+
+func TestPriorityQueueMaxWait(t *testing.T) {
+	maxWait := 20 * time.Millisecond
+	tests := []struct {
+		name    string
+		maxWait time.Duration
+		want    []string
+	}{
+		{"promote item waiting too long", maxWait, []string{"l1", "h1", "h2", "l2"}},
+		{"no promotion if disabled", 0, []string{"h1", "h2", "l1", "l2"}},
+	}
+	for _, tt := range tests {
+		q := NewPriorityQueue(2, 0, tt.maxWait)
+		q.Add("l1", 1, "a")
+		time.Sleep(2 * maxWait)
+		q.Add("h1", 0, "a")
+		q.Add("h2", 0, "a")
+		q.Add("l2", 1, "a")
+		if q.LenOfClass(0) != 2 || q.LenOfClass(1) != 2 {
+			t.Errorf("%v: class length is %v and %v, want 2 and 2", tt.name, q.LenOfClass(0), q.LenOfClass(1))
+		}
+		for i, want := range tt.want {
+			have := q.Next()
+			if have != want {
+				t.Errorf("%v: item %v is %v, want %v", tt.name, i, have, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package tracing
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
+)
+
+// otlp span kind and status code
+const (
+	spanKindInternal = 1
+	statusCodeOk     = 1
+	statusCodeError  = 2
+)
+
+var (
+	exporterOnce sync.Once
+	spanQueue    chan *otlpSpan
+
+	droppedSpans uint64
+
+	exportTimeout = 10 // seconds
+)
+
+// OTLP/HTTP json encoding of trace export request
+type exportRequest struct {
+	ResourceSpans []*resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource      `json:"resource"`
+	ScopeSpans []*scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []*keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope       `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type scope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	Name              string      `json:"name"`
+	Kind              int         `json:"kind"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+	Attributes        []*keyValue `json:"attributes,omitempty"`
+	Status            spanStatus  `json:"status"`
+}
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type spanStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func startExporter() {
+	exporterOnce.Do(func() {
+		cfg := params.GetTracingConfig()
+		spanQueue = make(chan *otlpSpan, cfg.GetMaxQueueSize())
+		utils.TopWaitGroup.Add(1)
+		go runExporter(cfg)
+	})
+}
+
+func queueSpan(span *otlpSpan) {
+	select {
+	case spanQueue <- span:
+	default:
+		if dropped := atomic.AddUint64(&droppedSpans, 1); dropped%1000 == 1 {
+			log.Warn("[tracing] span queue is full, drop span", "dropped", dropped)
+		}
+	}
+}
+
+func runExporter(cfg *params.TracingConfig) {
+	defer utils.TopWaitGroup.Done()
+	log.Info("[tracing] start span exporter", "endpoint", cfg.Endpoint, "serviceName", cfg.GetServiceName())
+
+	batchSize := cfg.GetBatchSize()
+	batch := make([]*otlpSpan, 0, batchSize)
+	ticker := time.NewTicker(time.Duration(cfg.GetFlushInterval()) * time.Second)
+	defer ticker.Stop()
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := exportSpans(cfg, batch); err != nil {
+			log.Warn("[tracing] export spans failed", "count", len(batch), "err", err)
+		}
+		batch = make([]*otlpSpan, 0, batchSize)
+	}
+
+	for {
+		select {
+		case <-utils.CleanupChan:
+			for {
+				select {
+				case span := <-spanQueue:
+					batch = append(batch, span)
+					if len(batch) >= batchSize {
+						flush()
+					}
+				default:
+					flush()
+					log.Info("[tracing] stop span exporter")
+					return
+				}
+			}
+		case span := <-spanQueue:
+			batch = append(batch, span)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func exportSpans(cfg *params.TracingConfig, spans []*otlpSpan) error {
+	hostname, _ := os.Hostname()
+	req := &exportRequest{
+		ResourceSpans: []*resourceSpans{
+			{
+				Resource: resource{
+					Attributes: []*keyValue{
+						{Key: "service.name", Value: anyValue{StringValue: cfg.GetServiceName()}},
+						{Key: "service.version", Value: anyValue{StringValue: params.VersionWithMeta}},
+						{Key: "host.name", Value: anyValue{StringValue: hostname}},
+					},
+				},
+				ScopeSpans: []*scopeSpans{
+					{
+						Scope: scope{Name: "crosschain-router", Version: params.VersionWithMeta},
+						Spans: spans,
+					},
+				},
+			},
+		},
+	}
+	resp, err := client.HTTPPost(cfg.Endpoint, req, nil, cfg.Headers, exportTimeout)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("wrong response status %v", resp.StatusCode)
+	}
+	return nil
+}
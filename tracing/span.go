@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+// Span a timed operation of swap (eg. verify, sign, send).
+// the methods of Span are nil safe, and nil span is returned if tracing is disabled.
+type Span struct {
+	traceID   string
+	spanID    string
+	name      string
+	startTime time.Time
+	attrs     []*keyValue
+}
+
+// StartSpan start span of trace with key value pairs of attributes
+func StartSpan(traceID, name string, attrs ...interface{}) *Span {
+	if traceID == "" || params.GetTracingConfig() == nil {
+		return nil
+	}
+	startExporter()
+	s := &Span{
+		traceID:   traceID,
+		spanID:    newSpanID(),
+		name:      name,
+		startTime: time.Now(),
+	}
+	s.SetAttributes(attrs...)
+	return s
+}
+
+// SetAttributes set key value pairs of attributes
+func (s *Span) SetAttributes(attrs ...interface{}) {
+	if s == nil {
+		return
+	}
+	for k := 0; k+2 <= len(attrs); k += 2 {
+		s.attrs = append(s.attrs, &keyValue{
+			Key:   fmt.Sprint(attrs[k]),
+			Value: anyValue{StringValue: fmt.Sprint(attrs[k+1])},
+		})
+	}
+}
+
+// End end span and queue it to export, the span is marked as failed if err is not nil
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	span := &otlpSpan{
+		TraceID:           s.traceID,
+		SpanID:            s.spanID,
+		Name:              s.name,
+		Kind:              spanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(s.startTime.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(time.Now().UnixNano(), 10),
+		Attributes:        s.attrs,
+		Status:            spanStatus{Code: statusCodeOk},
+	}
+	if err != nil {
+		span.Status = spanStatus{Code: statusCodeError, Message: err.Error()}
+	}
+	queueSpan(span)
+}
@@ -0,0 +1,15 @@
+// Package tracing exports spans of swaps to opentelemetry collector,
+// so that the journey of a swap can be reconstructed across router
+// and oracle nodes. trace IDs of swaps are derived in package tokens.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+func newSpanID() string {
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
@@ -79,10 +79,13 @@ func (tx *RPCTransaction) GetAccountNonce() uint64 {
 
 // RPCLog struct
 type RPCLog struct {
-	Address *common.Address `json:"address"`
-	Topics  []common.Hash   `json:"topics"`
-	Data    *hexutil.Bytes  `json:"data"`
-	Removed *bool           `json:"removed"`
+	Address     *common.Address `json:"address"`
+	Topics      []common.Hash   `json:"topics"`
+	Data        *hexutil.Bytes  `json:"data"`
+	Removed     *bool           `json:"removed"`
+	TxHash      *common.Hash    `json:"transactionHash,omitempty"`
+	BlockNumber *hexutil.Uint64 `json:"blockNumber,omitempty"`
+	LogIndex    *hexutil.Uint   `json:"logIndex,omitempty"`
 }
 
 // RPCTxReceipt struct
@@ -97,6 +100,8 @@ type RPCTxReceipt struct {
 	Recipient   *common.Address `json:"to"`
 	GasUsed     *hexutil.Uint64 `json:"gasUsed"`
 	Logs        []*RPCLog       `json:"logs"`
+
+	EffectiveGasPrice *hexutil.Big `json:"effectiveGasPrice,omitempty"`
 }
 
 // IsStatusOk is status ok
@@ -38,6 +38,9 @@ var (
 	errIdentifierMismatch = errors.New("cross chain bridge identifier mismatch")
 	errInitiatorMismatch  = errors.New("initiator mismatch")
 	errWrongMsgContext    = errors.New("wrong msg context")
+
+	errCancelValidSwap     = errors.New("forbid cancel valid swap")
+	errCancelNonceMismatch = errors.New("cancel tx nonce mismatch with accepted swap tx")
 )
 
 // StartAcceptSignJob accept job
@@ -189,7 +192,7 @@ func checkAndUpdateCachedAcceptInfoMap(keyID string) (ok bool) {
 	return true
 }
 
-func processAcceptInfo(mpcConfig *mpc.Config, info *mpc.SignInfoData) error {
+func processAcceptInfo(mpcConfig *mpc.Config, info *mpc.SignInfoData) (err error) {
 	keyID := info.Key
 	if !checkAndUpdateCachedAcceptInfoMap(keyID) {
 		return nil
@@ -207,7 +210,11 @@ func processAcceptInfo(mpcConfig *mpc.Config, info *mpc.SignInfoData) error {
 		"keyID", keyID,
 	}
 	if args != nil {
+		span := startSwapSpan("accept", args, "keyID", keyID)
+		defer func() { span.End(err) }()
+
 		ctx = append(ctx,
+			"traceID", args.GetTraceID(),
 			"identifier", args.Identifier,
 			"swapType", args.SwapType.String(),
 			"fromChainID", args.FromChainID,
@@ -264,7 +271,7 @@ func processAcceptInfo(mpcConfig *mpc.Config, info *mpc.SignInfoData) error {
 	}
 	ctx = append(ctx, "result", agreeResult)
 
-	logWorker("accept", "accept sign start", "keyID", keyID, "result", agreeResult)
+	logWorker("accept", "accept sign start", ctx...)
 	res, err := mpcConfig.DoAcceptSign(keyID, agreeResult, info.MsgHash, aggreeMsgContext)
 	if err != nil {
 		ctx = append(ctx, "rpcResult", res)
@@ -283,9 +290,8 @@ func filterSignInfo(signInfo *mpc.SignInfoData) (*tokens.BuildTxArgs, error) {
 	if err != nil {
 		return nil, errWrongMsgContext
 	}
-	switch args.Identifier {
-	case params.GetIdentifier():
-	default:
+	if !tokens.IsSwapTypeEnabled(args.SwapType) ||
+		args.Identifier != params.GetSwapTypeIdentifier(args.SwapType.String()) {
 		return nil, errIdentifierMismatch
 	}
 	return &args, err
@@ -329,6 +335,7 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 
 	ctx := []interface{}{
 		"keyID", keyID,
+		"traceID", args.GetTraceID(),
 		"identifier", args.Identifier,
 		"swapType", args.SwapType.String(),
 		"fromChainID", args.FromChainID,
@@ -338,6 +345,13 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 		"tokenID", args.GetTokenID(),
 	}
 
+	if args.IsCancelTx() {
+		return rebuildAndVerifyCancelMsgHash(srcBridge, dstBridge, msgHash, args, ctx)
+	}
+	if args.Refund {
+		return rebuildAndVerifyRefundMsgHash(srcBridge, msgHash, args, ctx)
+	}
+
 	txid := args.SwapID
 	logIndex := args.LogIndex
 	verifyArgs := &tokens.VerifyArgs{
@@ -360,7 +374,7 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 	buildTxArgs := &tokens.BuildTxArgs{
 		SwapArgs: tokens.SwapArgs{
 			SwapInfo:    swapInfo.SwapInfo,
-			Identifier:  params.GetIdentifier(),
+			Identifier:  params.GetSwapTypeIdentifier(swapInfo.SwapType.String()),
 			SwapID:      swapInfo.Hash,
 			SwapType:    swapInfo.SwapType,
 			Bind:        swapInfo.Bind,
@@ -392,6 +406,53 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 	return nil
 }
 
+// only agree to cancel swap tx if the swap is verified to be invalid
+func rebuildAndVerifyCancelMsgHash(srcBridge, dstBridge tokens.IBridge, msgHash []string, args *tokens.BuildTxArgs, ctx []interface{}) error {
+	canceler, ok := dstBridge.(tokens.TxCanceler)
+	if !ok {
+		return tokens.ErrNotImplemented
+	}
+	verifyArgs := &tokens.VerifyArgs{
+		SwapType:      args.SwapType,
+		LogIndex:      args.LogIndex,
+		AllowUnstable: false,
+	}
+	_, err := srcBridge.VerifyTransaction(args.SwapID, verifyArgs)
+	switch {
+	case err == nil:
+		return errCancelValidSwap
+	case errors.Is(err, tokens.ErrTxNotStable),
+		errors.Is(err, tokens.ErrTxNotFound),
+		tokens.IsRPCQueryOrNotFoundError(err):
+		return err
+	}
+	logWorker("accept", "verify cancel swap tx", append(ctx, "verifyErr", err)...)
+
+	err = checkCancelTxNonce(dstBridge, args)
+	if err != nil {
+		logWorkerError("accept", "check cancel tx nonce failed", err, ctx...)
+		return err
+	}
+
+	buildTxArgs := &tokens.BuildTxArgs{
+		SwapArgs: args.SwapArgs,
+		From:     args.From,
+		Extra:    args.Extra,
+	}
+	rawTx, err := canceler.BuildCancelTransaction(buildTxArgs)
+	if err != nil {
+		logWorkerError("accept", "build cancel tx failed", err, ctx...)
+		return err
+	}
+	err = dstBridge.VerifyMsgHash(rawTx, msgHash)
+	if err != nil {
+		logWorkerError("accept", "verify cancel message hash failed", err, ctx...)
+		return err
+	}
+	logWorker("accept", "verify cancel message hash success", ctx...)
+	return nil
+}
+
 func saveAcceptRecord(bridge tokens.IBridge, keyID string, args *tokens.BuildTxArgs, rawTx interface{}, ctx []interface{}) {
 	impl, ok := bridge.(interface {
 		GetSignedTxHashOfKeyID(sender, keyID string, rawTx interface{}) (txHash string, err error)
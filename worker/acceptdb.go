@@ -122,6 +122,31 @@ func CheckAcceptRecord(args *tokens.BuildTxArgs) (err error) {
 	return nil
 }
 
+// checkCancelTxNonce only agree to cancel the pending swap tx accepted before,
+// the cancel tx must use the same nonce as one of the accepted swap txs.
+func checkCancelTxNonce(toBridge tokens.IBridge, args *tokens.BuildTxArgs) error {
+	cancelNonce := args.GetTxNonce()
+	if lvldbHandle == nil || cancelNonce == 0 {
+		return errCancelNonceMismatch
+	}
+
+	prefix := []byte(getSwapKeyPrefix(args))
+	prefixLen := len(prefix)
+	iter := lvldbHandle.NewIterator(prefix, nil)
+	defer iter.Release()
+	for iter.Next() {
+		oldSwapTx := string(iter.Key())[prefixLen:]
+		tx, err := toBridge.GetTransaction(oldSwapTx)
+		if err != nil {
+			continue
+		}
+		if etx, ok := tx.(*types.RPCTransaction); ok && etx.GetAccountNonce() == cancelNonce {
+			return nil
+		}
+	}
+	return errCancelNonceMismatch
+}
+
 func getLeveldbPath() string {
 	dataDir := params.GetDataDir()
 	identifier := params.GetIdentifier()
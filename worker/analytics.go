@@ -0,0 +1,218 @@
+package worker
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var analyticsStarter sync.Once
+
+type volumeRollup struct {
+	*mongodb.MgoSwapVolumeRollup
+	latencies []int64
+}
+
+type swapRollups struct {
+	day      string
+	volumes  map[string]*volumeRollup
+	statuses map[mongodb.SwapStatus]int64
+	senders  map[string]*mongodb.MgoSwapSenderRollup
+	decimals map[string]*big.Float // key is chainID:token
+}
+
+// StartAnalyticsJob start job of computing daily swap rollups
+func StartAnalyticsJob() {
+	if !params.IsAnalyticsEnabled() {
+		logWorker("analytics", "stop analytics job as disabled")
+		return
+	}
+	analyticsStarter.Do(func() {
+		mongodb.MgoWaitGroup.Add(1)
+		go startAnalyticsJob()
+	})
+}
+
+func startAnalyticsJob() {
+	defer mongodb.MgoWaitGroup.Done()
+	logWorker("analytics", "start analytics job")
+	for {
+		today := time.Now().UTC()
+		for i := params.GetAnalyticsRecomputeDays() - 1; i >= 0; i-- {
+			if utils.IsCleanuping() {
+				break
+			}
+			day := today.AddDate(0, 0, -i)
+			err := computeSwapRollups(day)
+			if err != nil {
+				logWorkerError("analytics", "compute swap rollups failed", err, "day", day.Format(mongodb.SwapRollupDayLayout))
+			}
+		}
+		if utils.IsCleanuping() {
+			logWorker("analytics", "stop analytics job")
+			return
+		}
+		restInJob(time.Duration(params.GetAnalyticsRollupInterval()) * time.Second)
+	}
+}
+
+func computeSwapRollups(day time.Time) error {
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+
+	rollups := &swapRollups{
+		day:      start.Format(mongodb.SwapRollupDayLayout),
+		volumes:  make(map[string]*volumeRollup),
+		statuses: make(map[mongodb.SwapStatus]int64),
+		senders:  make(map[string]*mongodb.MgoSwapSenderRollup),
+		decimals: make(map[string]*big.Float),
+	}
+
+	// inittime is in milliseconds
+	err := mongodb.ForEachRouterSwapResultInTimeRange(start.UnixNano()/1e6, end.UnixNano()/1e6, rollups.add)
+	if err != nil {
+		return err
+	}
+
+	volumes := make([]*mongodb.MgoSwapVolumeRollup, 0, len(rollups.volumes))
+	for _, v := range rollups.volumes {
+		v.LatencyMedian = getPercentile(v.latencies, 50)
+		v.LatencyP95 = getPercentile(v.latencies, 95)
+		volumes = append(volumes, v.MgoSwapVolumeRollup)
+	}
+	statuses := make([]*mongodb.MgoSwapStatusRollup, 0, len(rollups.statuses))
+	for status, count := range rollups.statuses {
+		statuses = append(statuses, &mongodb.MgoSwapStatusRollup{
+			Key:    mongodb.GetSwapRollupKey(rollups.day, status.String()),
+			Day:    rollups.day,
+			Status: status,
+			Count:  count,
+		})
+	}
+	senders := make([]*mongodb.MgoSwapSenderRollup, 0, len(rollups.senders))
+	for _, v := range rollups.senders {
+		senders = append(senders, v)
+	}
+
+	if err = mongodb.UpdateSwapVolumeRollups(rollups.day, volumes); err != nil {
+		return err
+	}
+	if err = mongodb.UpdateSwapStatusRollups(rollups.day, statuses); err != nil {
+		return err
+	}
+	if err = mongodb.UpdateSwapSenderRollups(rollups.day, senders); err != nil {
+		return err
+	}
+	logWorker("analytics", "compute swap rollups success", "day", rollups.day,
+		"volumes", len(volumes), "statuses", len(statuses), "senders", len(senders))
+	return nil
+}
+
+func (r *swapRollups) add(res *mongodb.MgoSwapResult) error {
+	tokenID := res.GetTokenID()
+	volume := r.getNormalizedValue(res)
+
+	r.statuses[res.Status]++
+
+	key := mongodb.GetSwapRollupKey(r.day, tokenID, res.FromChainID, res.ToChainID)
+	v, exist := r.volumes[key]
+	if !exist {
+		v = &volumeRollup{
+			MgoSwapVolumeRollup: &mongodb.MgoSwapVolumeRollup{
+				Key:         key,
+				Day:         r.day,
+				TokenID:     tokenID,
+				FromChainID: res.FromChainID,
+				ToChainID:   res.ToChainID,
+			},
+		}
+		r.volumes[key] = v
+	}
+	v.SwapCount++
+	v.Volume += volume
+	v.ReplaceCount += int64(len(res.OldSwapTxs))
+	switch {
+	case res.Status == mongodb.MatchTxStable:
+		v.SuccessCount++
+	case res.Status.IsFailedStatus():
+		v.FailedCount++
+	}
+	// swaptime is the block time in seconds
+	if res.SwapTime > 0 && !res.Status.IsRefundStatus() {
+		latency := int64(res.SwapTime) - res.InitTime/1000
+		if latency < 0 {
+			latency = 0
+		}
+		v.latencies = append(v.latencies, latency)
+	}
+
+	from := strings.ToLower(res.From)
+	key = mongodb.GetSwapRollupKey(r.day, tokenID, from)
+	sender, exist := r.senders[key]
+	if !exist {
+		sender = &mongodb.MgoSwapSenderRollup{
+			Key:     key,
+			Day:     r.day,
+			TokenID: tokenID,
+			From:    from,
+		}
+		r.senders[key] = sender
+	}
+	sender.SwapCount++
+	sender.Volume += volume
+	return nil
+}
+
+// getNormalizedValue get swap value normalized by decimals (only for erc20 swaps)
+func (r *swapRollups) getNormalizedValue(res *mongodb.MgoSwapResult) float64 {
+	if res.ERC20SwapInfo == nil || tokens.SwapType(res.SwapType) != tokens.ERC20SwapType {
+		return 0
+	}
+	value, ok := new(big.Float).SetString(res.Value)
+	if !ok {
+		return 0
+	}
+	decimals := r.getDecimals(res.FromChainID, res.ERC20SwapInfo.Token)
+	if decimals == nil {
+		return 0
+	}
+	result, _ := value.Quo(value, decimals).Float64()
+	return result
+}
+
+func (r *swapRollups) getDecimals(chainID, token string) *big.Float {
+	key := strings.ToLower(chainID + ":" + token)
+	if decimals, exist := r.decimals[key]; exist {
+		return decimals
+	}
+	var decimals *big.Float
+	if bridge := router.GetBridgeByChainID(chainID); bridge != nil {
+		if tokenCfg := bridge.GetTokenConfig(token); tokenCfg != nil {
+			decimals = new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(tokenCfg.Decimals)), nil))
+		}
+	}
+	r.decimals[key] = decimals
+	return decimals
+}
+
+// getPercentile get percentile of values by nearest rank method
+func getPercentile(values []int64, percent float64) int64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	rank := int(math.Ceil(percent / 100 * float64(len(values))))
+	if rank < 1 {
+		rank = 1
+	}
+	return values[rank-1]
+}
@@ -0,0 +1,108 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var errAnyCallBudgetExhausted = errors.New("anycall budget exhausted")
+
+func getAnyCallFromAndDApp(swapInfo *mongodb.SwapInfo) (callFrom, dapp string) {
+	switch {
+	case swapInfo.AnyCallSwapInfo != nil:
+		callFrom = swapInfo.AnyCallSwapInfo.CallFrom
+		if len(swapInfo.AnyCallSwapInfo.CallTo) > 0 {
+			dapp = swapInfo.AnyCallSwapInfo.CallTo[0]
+		}
+	case swapInfo.CurveAnyCallSwapInfo != nil:
+		callFrom = swapInfo.CurveAnyCallSwapInfo.CallFrom
+		dapp = swapInfo.CurveAnyCallSwapInfo.CallTo
+	}
+	return callFrom, dapp
+}
+
+// checkAnyCallBudget refuse anycall execution if the prepaid budget
+// can not afford the estimated execution fee on the destination chain
+func checkAnyCallBudget(dstBridge tokens.IBridge, swap *mongodb.MgoSwap) error {
+	if !params.IsAnyCallBudgetEnabled() || swap.SwapType != uint32(tokens.AnyCallSwapType) {
+		return nil
+	}
+	callFrom, _ := getAnyCallFromAndDApp(&swap.SwapInfo)
+	ledger, err := mongodb.FindAnyCallLedger(swap.ToChainID, mongodb.AnyCallLedgerCallFrom, callFrom)
+	if err != nil && !errors.Is(err, mongodb.ErrItemNotFound) {
+		return err
+	}
+	execFee, err := estimateAnyCallExecFee(dstBridge, swap.ToChainID)
+	if err != nil {
+		return err
+	}
+	if ledger == nil || ledger.GetRemaining().Sign() <= 0 || ledger.GetRemaining().Cmp(execFee) < 0 {
+		err = fmt.Errorf("%w: callFrom %v on chain %v, estimated fee %v", errAnyCallBudgetExhausted, callFrom, swap.ToChainID, execFee)
+		_ = updateSwapMemo(swap.FromChainID, swap.TxID, swap.LogIndex, err)
+		return err
+	}
+	return nil
+}
+
+// estimateAnyCallExecFee estimate the max execution fee of anycall tx
+// by gas limit (max gas limit if configed) multiplied by gas price.
+func estimateAnyCallExecFee(dstBridge tokens.IBridge, toChainID string) (*big.Int, error) {
+	gasLimit := params.GetMaxGasLimit(toChainID)
+	if gasLimit == 0 {
+		gasLimit = params.GetDefaultGasLimit(toChainID)
+	}
+	gasPrice := params.GetFixedGasPrice(toChainID)
+	if gasPrice == nil {
+		priceGetter, ok := dstBridge.(interface {
+			SuggestPrice() (*big.Int, error)
+		})
+		if !ok {
+			return big.NewInt(0), nil
+		}
+		var err error
+		gasPrice, err = priceGetter.SuggestPrice()
+		if err != nil {
+			return nil, fmt.Errorf("estimate anycall fee failed, %w", err)
+		}
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), gasPrice), nil
+}
+
+// recordAnyCallFee record execution fee of anycall swap tx on chain
+func recordAnyCallFee(resBridge tokens.IBridge, swap *mongodb.MgoSwapResult, txStatus *tokens.TxStatus) {
+	if swap.SwapType != uint32(tokens.AnyCallSwapType) {
+		return
+	}
+	feeGetter, ok := resBridge.(tokens.TxFeeGetter)
+	if !ok {
+		return
+	}
+	ctx := []interface{}{"fromChainID", swap.FromChainID, "txid", swap.TxID, "logIndex", swap.LogIndex, "toChainID", swap.ToChainID, "swaptx", swap.SwapTx}
+	gasUsed, gasPrice, err := feeGetter.GetTxFee(swap.SwapTx, txStatus)
+	if err != nil {
+		logWorkerError("stable", "get anycall tx fee failed", err, ctx...)
+		return
+	}
+	callFrom, dapp := getAnyCallFromAndDApp(&swap.SwapInfo)
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), gasPrice)
+	err = mongodb.AddAnyCallFee(&mongodb.MgoAnyCallFee{
+		FromChainID: swap.FromChainID,
+		ToChainID:   swap.ToChainID,
+		TxID:        swap.TxID,
+		LogIndex:    swap.LogIndex,
+		SwapTx:      swap.SwapTx,
+		CallFrom:    callFrom,
+		DApp:        dapp,
+		GasUsed:     gasUsed,
+		GasPrice:    gasPrice.String(),
+		Fee:         fee.String(),
+	})
+	if err != nil && !errors.Is(err, mongodb.ErrItemIsDup) {
+		logWorkerError("stable", "record anycall fee failed", err, ctx...)
+	}
+}
@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var errAnyCallFallbackNotAllowed = errors.New("anycall swap tx is not decided to fallback")
+
+const (
+	defMaxAnyCallRetryCount   = 3
+	defAnyCallRetryGasPercent = uint64(50)
+)
+
+func getMaxAnyCallRetryCount() int {
+	if count := params.GetRouterServerConfig().MaxAnyCallRetryCount; count > 0 {
+		return count
+	}
+	return defMaxAnyCallRetryCount
+}
+
+func getAnyCallRetryGasPercent() uint64 {
+	if percent := params.GetRouterServerConfig().AnyCallRetryGasPercent; percent > 0 {
+		return percent
+	}
+	return defAnyCallRetryGasPercent
+}
+
+// getAnyCallRetryGasLimit get the raised gas limit when retry out of gas anycall swap
+func getAnyCallRetryGasLimit(res *mongodb.MgoSwapResult) uint64 {
+	if res.Status != mongodb.Reswapping || len(res.AnyCallAttempts) == 0 {
+		return 0
+	}
+	lastAttempt := res.AnyCallAttempts[len(res.AnyCallAttempts)-1]
+	if lastAttempt.Action != mongodb.AnyCallActionRetry {
+		return 0
+	}
+	return lastAttempt.NextGasLimit
+}
+
+// processAnyCallFailure process onchain failed anycall swap.
+// retry with higher gas limit if it's out of gas and retryable,
+// otherwise trigger the fallback call on the source chain.
+func processAnyCallFailure(resBridge tokens.IBridge, swap *mongodb.MgoSwapResult, txStatus *tokens.TxStatus) {
+	if swap.SwapType != uint32(tokens.AnyCallSwapType) {
+		return
+	}
+	retryCount := 0
+	for _, attempt := range swap.AnyCallAttempts {
+		if attempt.SwapTx == swap.SwapTx {
+			return // already processed
+		}
+		if attempt.Action == mongodb.AnyCallActionRetry {
+			retryCount++
+		}
+	}
+	revertInfoGetter, ok := resBridge.(tokens.TxRevertInfoGetter)
+	if !ok {
+		return
+	}
+
+	ctx := []interface{}{"fromChainID", swap.FromChainID, "txid", swap.TxID, "logIndex", swap.LogIndex, "toChainID", swap.ToChainID, "swaptx", swap.SwapTx}
+	revertInfo, err := revertInfoGetter.GetTxRevertInfo(swap.SwapTx, txStatus)
+	if err != nil {
+		logWorkerError("anycall", "get anycall revert info failed", err, ctx...)
+		return
+	}
+	attempt := &mongodb.AnyCallAttempt{
+		SwapTx:    swap.SwapTx,
+		SwapNonce: swap.SwapNonce,
+		GasLimit:  revertInfo.GasLimit,
+		GasUsed:   revertInfo.GasUsed,
+		Reason:    revertInfo.Reason,
+		Action:    mongodb.AnyCallActionGiveUp,
+	}
+	var fallbackSwap string
+	if revertInfo.IsOutOfGas {
+		nextGasLimit := revertInfo.GasLimit + revertInfo.GasLimit*getAnyCallRetryGasPercent()/100
+		if maxGasLimit := params.GetMaxGasLimit(swap.ToChainID); maxGasLimit > 0 && nextGasLimit > maxGasLimit {
+			nextGasLimit = maxGasLimit
+		}
+		if retryCount < getMaxAnyCallRetryCount() && nextGasLimit > revertInfo.GasLimit {
+			attempt.Action = mongodb.AnyCallActionRetry
+			attempt.NextGasLimit = nextGasLimit
+		}
+	}
+	// fallback if not retryable (including out of gas which has used up its retries)
+	if attempt.Action != mongodb.AnyCallActionRetry {
+		fallbackSwap, err = addAnyCallFallbackSwap(resBridge, swap)
+		if err == nil {
+			attempt.Action = mongodb.AnyCallActionFallback
+		} else {
+			logWorkerWarn("anycall", "add anycall fallback swap failed", append(ctx, "err", err)...)
+		}
+	}
+	logWorker("anycall", "process anycall failure", append(ctx, "reason", attempt.Reason, "gasLimit", attempt.GasLimit, "gasUsed", attempt.GasUsed, "action", attempt.Action)...)
+
+	err = mongodb.AddAnyCallAttempt(swap.FromChainID, swap.TxID, swap.LogIndex, attempt)
+	if err != nil {
+		return
+	}
+	switch attempt.Action {
+	case mongodb.AnyCallActionRetry:
+		err = mongodb.UpdateRouterSwapResultStatus(swap.FromChainID, swap.TxID, swap.LogIndex, mongodb.Reswapping, now(), nil)
+		if err == nil {
+			_ = mongodb.UpdateRouterSwapStatus(swap.FromChainID, swap.TxID, swap.LogIndex, mongodb.TxNotSwapped, now(), nil)
+		}
+	case mongodb.AnyCallActionFallback:
+		_ = mongodb.UpdateAnyCallFallbackSwap(swap.FromChainID, swap.TxID, swap.LogIndex, fallbackSwap)
+	}
+}
+
+// addAnyCallFallbackSwap add the failed anycall swaptx as a new swap,
+// which calls `anyFallback` on the source chain
+func addAnyCallFallbackSwap(resBridge tokens.IBridge, swap *mongodb.MgoSwapResult) (string, error) {
+	verifyArgs := &tokens.VerifyArgs{
+		SwapType:      tokens.AnyCallSwapType,
+		LogIndex:      0,
+		AllowUnstable: true,
+	}
+	swapInfo, err := resBridge.VerifyTransaction(swap.SwapTx, verifyArgs)
+	if err != nil {
+		return "", err
+	}
+	err = AddInitialSwap(swapInfo, mongodb.TxNotStable, nil)
+	if err != nil && !errors.Is(err, mongodb.ErrItemIsDup) {
+		return "", err
+	}
+	return mongodb.GetRouterSwapKey(swapInfo.FromChainID.String(), swapInfo.Hash, swapInfo.LogIndex), nil
+}
+
+// checkAnyCallFallbackSwap only allow the fallback of failed anycall swap tx
+// which is decided to fallback (eg. not retried with higher gas limit)
+func checkAnyCallFallbackSwap(swap *mongodb.MgoSwap) error {
+	if swap.SwapType != uint32(tokens.AnyCallSwapType) {
+		return nil
+	}
+	parent, err := mongodb.FindAnyCallParentSwapOfTx(swap.FromChainID, swap.TxID)
+	if err != nil {
+		if errors.Is(err, mongodb.ErrItemNotFound) {
+			return nil // not a swap tx sent by router
+		}
+		return err
+	}
+	for _, attempt := range parent.AnyCallAttempts {
+		if strings.EqualFold(attempt.SwapTx, swap.TxID) && attempt.Action == mongodb.AnyCallActionFallback {
+			return nil
+		}
+	}
+	return errAnyCallFallbackNotAllowed
+}
@@ -16,6 +16,43 @@ type MatchTx struct {
 	SwapTime   uint64
 	SwapValue  string
 	SwapNonce  uint64
+	Lease      *tokens.ChainLease // fence the update in cluster mode
+}
+
+// AddInitialSwap add initial swap
+// the memo and error code are recorded from verifyErr if it is not nil
+func AddInitialSwap(swapInfo *tokens.SwapTxInfo, status mongodb.SwapStatus, verifyErr error) (err error) {
+	valueStr := "0"
+	if swapInfo.Value != nil {
+		valueStr = swapInfo.Value.String()
+	}
+	var memo string
+	if verifyErr != nil {
+		memo = verifyErr.Error()
+	}
+	swap := &mongodb.MgoSwap{
+		SwapType:    uint32(swapInfo.SwapType),
+		TxID:        swapInfo.Hash,
+		TxTo:        swapInfo.TxTo,
+		From:        swapInfo.From,
+		Bind:        swapInfo.Bind,
+		Value:       valueStr,
+		LogIndex:    swapInfo.LogIndex,
+		FromChainID: swapInfo.FromChainID.String(),
+		ToChainID:   swapInfo.ToChainID.String(),
+		Status:      status,
+		Timestamp:   now(),
+		Memo:        memo,
+		ErrCode:     tokens.GetErrorCodeValue(verifyErr),
+	}
+	swap.SwapInfo = mongodb.ConvertToSwapInfo(&swapInfo.SwapInfo)
+	err = mongodb.AddRouterSwap(swap)
+	if err != nil {
+		logWorkerWarn("add", "addInitialSwap failed", "swap", swap, "err", err)
+	} else {
+		logWorker("add", "addInitialSwap success", "swap", swap)
+	}
+	return err
 }
 
 // AddInitialSwapResult add initial result
@@ -60,6 +97,7 @@ func updateRouterSwapResult(fromChainID, txid string, logIndex int, mtx *MatchTx
 	updates := &mongodb.SwapResultUpdateItems{
 		Status:    mongodb.KeepStatus,
 		Timestamp: now(),
+		Lease:     mtx.Lease,
 	}
 	if mtx.SwapHeight == 0 {
 		updates.SwapValue = mtx.SwapValue
@@ -96,10 +134,13 @@ func updateRouterSwapResult(fromChainID, txid string, logIndex int, mtx *MatchTx
 	return err
 }
 
-func updateSwapMemo(fromChainID, txid string, logIndex int, memo string) (err error) {
+// updateSwapMemo record the swap error to the memo and error code of swap result
+func updateSwapMemo(fromChainID, txid string, logIndex int, swapErr error) (err error) {
+	memo := swapErr.Error()
 	updates := &mongodb.SwapResultUpdateItems{
 		Status:    mongodb.KeepStatus,
 		Memo:      memo,
+		ErrCode:   tokens.GetErrorCodeValue(swapErr),
 		Timestamp: now(),
 	}
 	err = mongodb.UpdateRouterSwapResult(fromChainID, txid, logIndex, updates)
@@ -125,11 +166,12 @@ func updateSwapTimestamp(fromChainID, txid string, logIndex int) (err error) {
 	return err
 }
 
-func updateSwapTx(fromChainID, txid string, logIndex int, swapTx string) (err error) {
+func updateSwapTx(fromChainID, txid string, logIndex int, swapTx string, lease *tokens.ChainLease) (err error) {
 	updates := &mongodb.SwapResultUpdateItems{
 		Status:    mongodb.KeepStatus,
 		SwapTx:    swapTx,
 		Timestamp: now(),
+		Lease:     lease,
 	}
 	err = mongodb.UpdateRouterSwapResult(fromChainID, txid, logIndex, updates)
 	if err != nil {
@@ -143,8 +185,7 @@ func updateSwapTx(fromChainID, txid string, logIndex int, swapTx string) (err er
 func markSwapResultUnstable(fromChainID, txid string, logIndex int) (err error) {
 	status := mongodb.MatchTxNotStable
 	timestamp := now()
-	memo := "" // unchange
-	err = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, status, timestamp, memo)
+	err = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, status, timestamp, nil) // memo unchange
 	if err != nil {
 		logWorkerError("checkfailedswap", "markSwapResultUnstable failed", err, "chainid", fromChainID, "txid", txid, "logIndex", logIndex)
 	} else {
@@ -156,8 +197,7 @@ func markSwapResultUnstable(fromChainID, txid string, logIndex int) (err error)
 func markSwapResultStable(fromChainID, txid string, logIndex int) (err error) {
 	status := mongodb.MatchTxStable
 	timestamp := now()
-	memo := "" // unchange
-	err = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, status, timestamp, memo)
+	err = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, status, timestamp, nil) // memo unchange
 	if err != nil {
 		logWorkerError("stable", "markSwapResultStable failed", err, "chainid", fromChainID, "txid", txid, "logIndex", logIndex)
 	} else {
@@ -169,8 +209,7 @@ func markSwapResultStable(fromChainID, txid string, logIndex int) (err error) {
 func markSwapResultFailed(fromChainID, txid string, logIndex int) (err error) {
 	status := mongodb.MatchTxFailed
 	timestamp := now()
-	memo := "" // unchange
-	err = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, status, timestamp, memo)
+	err = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, status, timestamp, nil) // memo unchange
 	if err != nil {
 		logWorkerError("stable", "markSwapResultFailed failed", err, "chainid", fromChainID, "txid", txid, "logIndex", logIndex)
 	} else {
@@ -192,6 +231,11 @@ func sendSignedTransaction(bridge tokens.IBridge, signedTx interface{}, args *to
 
 SENDTX_LOOP:
 	for loop := 0; loop < retrySendTxLoops; loop++ {
+		// check chain lease right before sending
+		err = checkChainLease(args.ToChainID.String(), args.ChainLease)
+		if err != nil {
+			break SENDTX_LOOP
+		}
 		for i := 0; i < 3; i++ {
 			txHash, err = bridge.SendTransaction(signedTx)
 			if err == nil {
@@ -246,11 +290,17 @@ func sendTxLoopUntilSuccess(bridge tokens.IBridge, txHash string, signedTx inter
 				SwapTx:     txHash,
 				SwapHeight: txStatus.BlockHeight,
 				SwapTime:   txStatus.BlockTime,
+				Lease:      args.ChainLease,
 			}
 			_ = updateRouterSwapResult(args.FromChainID.String(), args.SwapID, args.LogIndex, matchTx)
 			break
 		}
 
+		err = checkChainLease(toChainID, args.ChainLease)
+		if err != nil {
+			logWorkerError("sendtx", "stop send tx in loop", err, "swapID", args.SwapID, "txHash", txHash, "loop", loop)
+			break
+		}
 		txHash, err = bridge.SendTransaction(signedTx)
 		if err != nil {
 			logWorkerError("sendtx", "send tx in loop failed", err, "swapID", args.SwapID, "txHash", txHash, "loop", loop)
@@ -0,0 +1,231 @@
+package worker
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+var (
+	drainLock      sync.Mutex
+	drainGlobal    bool
+	drainChainIDs  = make(map[string]struct{})
+	drainStartTime int64
+	drainDeadline  int64
+
+	// in flight swap tasks which may sign with mpc (key is toChainID)
+	inFlightSwapTasks = make(map[string]int)
+
+	defDrainTimeout = int64(600) // seconds
+
+	errServerDraining = errors.New("server is draining")
+)
+
+// DrainInfo drain progress info
+type DrainInfo struct {
+	Global    bool           `json:"global"`
+	ChainIDs  []string       `json:"chainIDs,omitempty"`
+	StartTime int64          `json:"startTime"`
+	Deadline  int64          `json:"deadline"`
+	InFlight  map[string]int `json:"inFlight"`
+	Drained   bool           `json:"drained"`
+	TimedOut  bool           `json:"timedOut"`
+}
+
+func getDrainStateKey() string {
+	if nodeID := params.GetClusterNodeID(); nodeID != "" {
+		return params.GetIdentifier() + ":" + nodeID
+	}
+	return params.GetIdentifier()
+}
+
+// loadDrainState load persisted drain state, so the server keeps drained after restart
+func loadDrainState() {
+	state, err := mongodb.FindDrainState(getDrainStateKey())
+	if err != nil {
+		if !errors.Is(err, mongodb.ErrItemNotFound) {
+			logWorkerError("drain", "load drain state failed", err)
+		}
+		return
+	}
+	drainLock.Lock()
+	drainGlobal = state.Global
+	for _, chainID := range state.ChainIDs {
+		drainChainIDs[chainID] = struct{}{}
+	}
+	drainStartTime = state.StartTime
+	drainDeadline = state.Deadline
+	isDraining := isDrainingLocked()
+	drainLock.Unlock()
+
+	if isDraining {
+		logWorker("drain", "load drain state success", "global", state.Global, "chainIDs", state.ChainIDs)
+	}
+}
+
+func saveDrainStateLocked() error {
+	state := &mongodb.MgoDrainState{
+		Key:       getDrainStateKey(),
+		Global:    drainGlobal,
+		ChainIDs:  getDrainChainIDsLocked(),
+		StartTime: drainStartTime,
+		Deadline:  drainDeadline,
+	}
+	return mongodb.UpdateDrainState(state)
+}
+
+func isDrainingLocked() bool {
+	return drainGlobal || len(drainChainIDs) > 0
+}
+
+func isChainDrainingLocked(chainID string) bool {
+	if drainGlobal {
+		return true
+	}
+	_, exist := drainChainIDs[chainID]
+	return exist
+}
+
+func getDrainChainIDsLocked() []string {
+	chainIDs := make([]string, 0, len(drainChainIDs))
+	for chainID := range drainChainIDs {
+		chainIDs = append(chainIDs, chainID)
+	}
+	sort.Strings(chainIDs)
+	return chainIDs
+}
+
+// IsChainDraining is chain draining (stop accepting new swap tasks)
+func IsChainDraining(chainID string) bool {
+	drainLock.Lock()
+	defer drainLock.Unlock()
+	return isChainDrainingLocked(chainID)
+}
+
+// beginSwapTask begin in flight swap task which may sign with mpc.
+// manual tasks are allowed when draining.
+func beginSwapTask(chainID string, isManual bool) error {
+	drainLock.Lock()
+	defer drainLock.Unlock()
+	if !isManual && isChainDrainingLocked(chainID) {
+		return errServerDraining
+	}
+	inFlightSwapTasks[chainID]++
+	return nil
+}
+
+func endSwapTask(chainID string) {
+	drainLock.Lock()
+	defer drainLock.Unlock()
+	inFlightSwapTasks[chainID]--
+	if inFlightSwapTasks[chainID] <= 0 {
+		delete(inFlightSwapTasks, chainID)
+	}
+}
+
+// StartDrain stop accepting new swap tasks of chains (all chains if chainIDs is empty),
+// and wait the in flight swap tasks to finish until timeout.
+func StartDrain(chainIDs []string, timeout int64) error {
+	if timeout <= 0 {
+		timeout = defDrainTimeout
+	}
+	drainLock.Lock()
+	defer drainLock.Unlock()
+
+	wasDraining := isDrainingLocked()
+	if len(chainIDs) == 0 {
+		drainGlobal = true
+	}
+	for _, chainID := range chainIDs {
+		drainChainIDs[chainID] = struct{}{}
+	}
+	drainStartTime = now()
+	drainDeadline = drainStartTime + timeout
+
+	err := saveDrainStateLocked()
+	if err != nil {
+		return err
+	}
+	logWorker("drain", "start drain", "global", drainGlobal, "chainIDs", getDrainChainIDsLocked(), "timeout", timeout)
+	if !wasDraining {
+		go watchDrainProgress()
+	}
+	return nil
+}
+
+// StopDrain resume accepting new swap tasks of chains (all chains if chainIDs is empty)
+func StopDrain(chainIDs []string) error {
+	drainLock.Lock()
+	defer drainLock.Unlock()
+
+	if len(chainIDs) == 0 {
+		drainGlobal = false
+		drainChainIDs = make(map[string]struct{})
+	}
+	for _, chainID := range chainIDs {
+		delete(drainChainIDs, chainID)
+	}
+	if !isDrainingLocked() {
+		drainStartTime = 0
+		drainDeadline = 0
+	}
+
+	err := saveDrainStateLocked()
+	if err != nil {
+		return err
+	}
+	logWorker("drain", "stop drain", "global", drainGlobal, "chainIDs", getDrainChainIDsLocked())
+	return nil
+}
+
+// GetDrainInfo get drain progress info (nil if not draining)
+func GetDrainInfo() *DrainInfo {
+	drainLock.Lock()
+	defer drainLock.Unlock()
+
+	if !isDrainingLocked() {
+		return nil
+	}
+	info := &DrainInfo{
+		Global:    drainGlobal,
+		ChainIDs:  getDrainChainIDsLocked(),
+		StartTime: drainStartTime,
+		Deadline:  drainDeadline,
+		InFlight:  make(map[string]int),
+	}
+	for chainID, count := range inFlightSwapTasks {
+		if isChainDrainingLocked(chainID) {
+			info.InFlight[chainID] = count
+		}
+	}
+	info.Drained = len(info.InFlight) == 0
+	info.TimedOut = !info.Drained && now() > drainDeadline
+	return info
+}
+
+func watchDrainProgress() {
+	for {
+		if utils.IsCleanuping() {
+			return
+		}
+		info := GetDrainInfo()
+		switch {
+		case info == nil:
+			return
+		case info.Drained:
+			logWorker("drain", "drain finished, it's safe to restart now", "global", info.Global, "chainIDs", info.ChainIDs)
+			return
+		case info.TimedOut:
+			logWorkerWarn("drain", "drain timeout with in flight swap tasks", "global", info.Global, "chainIDs", info.ChainIDs, "inFlight", info.InFlight)
+			return
+		default:
+			logWorker("drain", "wait in flight swap tasks", "global", info.Global, "chainIDs", info.ChainIDs, "inFlight", info.InFlight, "deadline", info.Deadline)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !windows
+// +build !windows
+
+package worker
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// startDrainSignalHandler start draining all chains when receive SIGUSR1
+func startDrainSignalHandler() {
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGUSR1)
+	go func() {
+		for sig := range signalChan {
+			logWorker("drain", "receive drain signal", "signal", sig)
+			if err := StartDrain(nil, 0); err != nil {
+				logWorkerError("drain", "start drain failed", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,4 @@
+package worker
+
+// startDrainSignalHandler is not supported on windows, use admin command instead
+func startDrainSignalHandler() {}
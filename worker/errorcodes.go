@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"sync"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var registerWorkerErrorCodesOnce sync.Once
+
+// registerWorkerErrorCodes register error codes of worker errors (2000-2999)
+func registerWorkerErrorCodes() {
+	registerWorkerErrorCodesOnce.Do(func() {
+		tokens.RegisterErrorCode(errAlreadySwapped, 2001, tokens.ErrCategorySecurity, false)
+		tokens.RegisterErrorCode(errSendTxWithDiffHash, 2002, tokens.ErrCategorySecurity, false)
+		tokens.RegisterErrorCode(errChainIsPaused, 2003, tokens.ErrCategoryConfig, true)
+		tokens.RegisterErrorCode(errBigSwapValue, 2004, tokens.ErrCategorySecurity, true)
+		tokens.RegisterErrorCode(errIdentifierMismatch, 2005, tokens.ErrCategorySecurity, false)
+		tokens.RegisterErrorCode(errInitiatorMismatch, 2006, tokens.ErrCategorySecurity, false)
+		tokens.RegisterErrorCode(errWrongMsgContext, 2007, tokens.ErrCategorySecurity, false)
+		tokens.RegisterErrorCode(errCancelValidSwap, 2008, tokens.ErrCategorySecurity, false)
+		tokens.RegisterErrorCode(errSignedTxNotSupported, 2009, tokens.ErrCategoryConfig, false)
+		tokens.RegisterErrorCode(errAnyCallBudgetExhausted, 2010, tokens.ErrCategoryUser, true)
+		tokens.RegisterErrorCode(errServerDraining, 2011, tokens.ErrCategoryInfra, true)
+		tokens.RegisterErrorCode(errNotChainLeaseOwner, 2012, tokens.ErrCategoryInfra, true)
+		tokens.RegisterErrorCode(errRefundValidSwap, 2013, tokens.ErrCategorySecurity, false)
+		tokens.RegisterErrorCode(errCancelNonceMismatch, 2014, tokens.ErrCategorySecurity, false)
+		tokens.RegisterErrorCode(errAnyCallFallbackNotAllowed, 2015, tokens.ErrCategorySecurity, true)
+	})
+}
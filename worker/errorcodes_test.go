@@ -0,0 +1,42 @@
+package worker
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+func TestWorkerErrorCodes(t *testing.T) {
+	registerWorkerErrorCodes()
+	registerWorkerErrorCodes() // register only once
+
+	tests := []struct {
+		err       error
+		code      int
+		category  tokens.ErrorCategory
+		retryable bool
+	}{
+		{errAlreadySwapped, 2001, tokens.ErrCategorySecurity, false},
+		{errSendTxWithDiffHash, 2002, tokens.ErrCategorySecurity, false},
+		{errChainIsPaused, 2003, tokens.ErrCategoryConfig, true},
+		{errBigSwapValue, 2004, tokens.ErrCategorySecurity, true},
+		{errIdentifierMismatch, 2005, tokens.ErrCategorySecurity, false},
+		{errInitiatorMismatch, 2006, tokens.ErrCategorySecurity, false},
+		{errWrongMsgContext, 2007, tokens.ErrCategorySecurity, false},
+		{errCancelValidSwap, 2008, tokens.ErrCategorySecurity, false},
+		{errSignedTxNotSupported, 2009, tokens.ErrCategoryConfig, false},
+		{errAnyCallBudgetExhausted, 2010, tokens.ErrCategoryUser, true},
+		{errServerDraining, 2011, tokens.ErrCategoryInfra, true},
+		{errNotChainLeaseOwner, 2012, tokens.ErrCategoryInfra, true},
+		{errRefundValidSwap, 2013, tokens.ErrCategorySecurity, false},
+	}
+	for _, tt := range tests {
+		for _, err := range []error{tt.err, fmt.Errorf("%w: with details", tt.err)} {
+			errCode := tokens.GetErrorCode(err)
+			if errCode == nil || errCode.Code != tt.code || errCode.Category != tt.category || errCode.Retryable != tt.retryable {
+				t.Errorf("error '%v' has code %+v, want code %v category %v retryable %v", err, errCode, tt.code, tt.category, tt.retryable)
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package worker
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	chainLeaseStarter sync.Once
+
+	heldChainLeases = new(sync.Map) // key is chainID, value is *heldChainLease
+
+	errNotChainLeaseOwner = errors.New("not chain lease owner")
+)
+
+type heldChainLease struct {
+	token    uint64
+	expireAt int64 // local expire time, earlier than the lease in database
+}
+
+// StartChainLeaseJob acquire and renew chain leases in cluster mode.
+// several servers can run hot-hot and each chain is processed by one server only,
+// the chains of failed server are taken over by others after its leases expired.
+func StartChainLeaseJob() {
+	if !params.IsClusterEnabled() {
+		return
+	}
+	chainLeaseStarter.Do(func() {
+		logWorker("lease", "start chain lease job", "nodeID", params.GetClusterNodeID(), "ttl", params.GetClusterLeaseTTL())
+		updateChainLeases()
+
+		mongodb.MgoWaitGroup.Add(1)
+		go startChainLeaseJob()
+	})
+}
+
+func startChainLeaseJob() {
+	defer mongodb.MgoWaitGroup.Done()
+	interval := time.Duration(params.GetClusterLeaseTTL()) * time.Second / 3
+	for {
+		restInJob(interval)
+		if utils.IsCleanuping() {
+			releaseChainLeases()
+			logWorker("lease", "stop chain lease job")
+			return
+		}
+		updateChainLeases()
+	}
+}
+
+// IsChainLeaseOwner is this server the owner of chain lease (always true if not in cluster mode)
+func IsChainLeaseOwner(chainID string) bool {
+	if !params.IsClusterEnabled() {
+		return true
+	}
+	return getChainLeaseToken(chainID) != 0
+}
+
+func getChainLeaseToken(chainID string) uint64 {
+	if v, exist := heldChainLeases.Load(chainID); exist {
+		lease := v.(*heldChainLease)
+		if lease.expireAt > now() {
+			return lease.token
+		}
+	}
+	return 0
+}
+
+// getChainLease get the held chain lease to fence the swap writes (nil if not in cluster mode)
+func getChainLease(chainID string) *tokens.ChainLease {
+	if !params.IsClusterEnabled() {
+		return nil
+	}
+	return &tokens.ChainLease{
+		Owner: params.GetClusterNodeID(),
+		Token: getChainLeaseToken(chainID),
+	}
+}
+
+// checkChainLease check the fencing token in database before signing, writing or sending swap tx
+func checkChainLease(chainID string, lease *tokens.ChainLease) error {
+	if lease == nil {
+		return nil
+	}
+	if lease.Token == 0 {
+		return errNotChainLeaseOwner
+	}
+	return mongodb.CheckChainLease(chainID, lease.Owner, lease.Token)
+}
+
+func updateChainLeases() {
+	nodeID := params.GetClusterNodeID()
+	ttl := params.GetClusterLeaseTTL()
+
+	err := mongodb.UpdateClusterNodeHeartbeat(nodeID, ttl)
+	if err != nil {
+		logWorkerError("lease", "update node heartbeat failed", err, "nodeID", nodeID)
+	}
+	nodes, err := mongodb.FindActiveClusterNodes()
+	if err != nil {
+		// do not balance or acquire leases without knowing the active nodes
+		logWorkerError("lease", "find active nodes failed", err)
+		renewHeldChainLeases(nodeID, ttl)
+		return
+	}
+	nodeCount := len(nodes)
+	if nodeCount == 0 {
+		nodeCount = 1
+	}
+
+	chainIDs := make([]string, 0, 10)
+	router.RouterBridges.Range(func(k, v interface{}) bool {
+		chainIDs = append(chainIDs, k.(string))
+		return true
+	})
+	sort.Strings(chainIDs)
+	maxHeld := (len(chainIDs) + nodeCount - 1) / nodeCount
+
+	// renew held leases
+	held := make([]string, 0, maxHeld)
+	for _, chainID := range chainIDs {
+		if _, exist := heldChainLeases.Load(chainID); !exist {
+			continue
+		}
+		if acquireChainLease(chainID, nodeID, ttl) {
+			held = append(held, chainID)
+		}
+	}
+
+	// release one lease a time if holding too many leases (eg. new node joined)
+	if len(held) > maxHeld {
+		chainID := held[len(held)-1]
+		heldChainLeases.Delete(chainID)
+		err = mongodb.ReleaseChainLease(chainID, nodeID)
+		logWorker("lease", "release chain lease for balance", "chainID", chainID, "held", len(held), "maxHeld", maxHeld, "err", err)
+		return
+	}
+
+	// acquire free or expired leases
+	for _, chainID := range chainIDs {
+		if len(held) >= maxHeld {
+			break
+		}
+		if _, exist := heldChainLeases.Load(chainID); exist {
+			continue
+		}
+		if acquireChainLease(chainID, nodeID, ttl) {
+			held = append(held, chainID)
+		}
+	}
+}
+
+func renewHeldChainLeases(nodeID string, ttl int64) {
+	held := make([]string, 0, 10)
+	heldChainLeases.Range(func(k, v interface{}) bool {
+		held = append(held, k.(string))
+		return true
+	})
+	for _, chainID := range held {
+		_ = acquireChainLease(chainID, nodeID, ttl)
+	}
+}
+
+func acquireChainLease(chainID, nodeID string, ttl int64) bool {
+	lease, err := mongodb.AcquireChainLease(chainID, nodeID, ttl)
+	if err != nil {
+		if _, exist := heldChainLeases.LoadAndDelete(chainID); exist {
+			logWorkerError("lease", "lost chain lease", err, "chainID", chainID, "nodeID", nodeID)
+		} else if !errors.Is(err, mongodb.ErrItemIsDup) {
+			logWorkerError("lease", "acquire chain lease failed", err, "chainID", chainID, "nodeID", nodeID)
+		}
+		return false
+	}
+	if v, exist := heldChainLeases.Load(chainID); !exist || v.(*heldChainLease).token != lease.Token {
+		logWorker("lease", "acquire chain lease success", "chainID", chainID, "nodeID", nodeID, "token", lease.Token)
+	}
+	// lease time is database time, convert to local time to avoid clock skew
+	heldChainLeases.Store(chainID, &heldChainLease{
+		token:    lease.Token,
+		expireAt: now() + lease.ExpireAt - lease.Timestamp - ttl/3,
+	})
+	return true
+}
+
+func releaseChainLeases() {
+	nodeID := params.GetClusterNodeID()
+	heldChainLeases.Range(func(k, v interface{}) bool {
+		chainID := k.(string)
+		heldChainLeases.Delete(chainID)
+		err := mongodb.ReleaseChainLease(chainID, nodeID)
+		logWorker("lease", "release chain lease", "chainID", chainID, "nodeID", nodeID, "err", err)
+		return true
+	})
+}
@@ -0,0 +1,186 @@
+package worker
+
+import (
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	liquidityMonitorStarter sync.Once
+
+	defLiquidityMonitorInterval = int64(300) // seconds
+)
+
+// StartLiquidityMonitorJob liquidity monitor job
+func StartLiquidityMonitorJob() {
+	if !params.IsLiquidityMonitorEnabled() {
+		logWorker("liquidity", "stop liquidity monitor job as disabled")
+		return
+	}
+	liquidityMonitorStarter.Do(func() {
+		mongodb.MgoWaitGroup.Add(1)
+		go startLiquidityMonitorJob()
+	})
+}
+
+func startLiquidityMonitorJob() {
+	defer mongodb.MgoWaitGroup.Done()
+	logWorker("liquidity", "start liquidity monitor job")
+	for {
+		router.RouterBridges.Range(func(k, v interface{}) bool {
+			if utils.IsCleanuping() {
+				return false
+			}
+			checkChainLiquidity(k.(string), v.(tokens.IBridge))
+			return true
+		})
+		if utils.IsCleanuping() {
+			logWorker("liquidity", "stop liquidity monitor job")
+			return
+		}
+		restInJob(getLiquidityMonitorInterval())
+	}
+}
+
+func getLiquidityMonitorInterval() time.Duration {
+	interval := params.GetRouterServerConfig().LiquidityMonitorInterval
+	if interval <= 0 {
+		interval = defLiquidityMonitorInterval
+	}
+	return time.Duration(interval) * time.Second
+}
+
+func checkChainLiquidity(chainID string, bridge tokens.IBridge) {
+	pendings, err := getPendingSwapValues(chainID)
+	if err != nil {
+		logWorkerError("liquidity", "find pending swaps failed", err, "chainID", chainID)
+	}
+
+	mpcs := make(map[string]struct{})
+	if routerInfo := router.GetRouterInfo(bridge.GetChainConfig().RouterContract, chainID); routerInfo != nil {
+		mpcs[routerInfo.RouterMPC] = struct{}{}
+	}
+
+	getter, _ := bridge.(tokens.LiquidityGetter)
+	for _, tokenID := range router.AllTokenIDs {
+		multichainToken := router.GetCachedMultichainToken(tokenID, chainID)
+		if multichainToken == "" {
+			continue
+		}
+		tokenCfg := bridge.GetTokenConfig(multichainToken)
+		if tokenCfg == nil {
+			continue
+		}
+		mpc, errf := router.GetRouterMPC(tokenID, chainID)
+		if errf != nil || mpc == "" {
+			continue
+		}
+		mpcs[mpc] = struct{}{}
+		if getter == nil {
+			continue
+		}
+		holder, liquidity, errf := getter.GetTokenLiquidity(tokenCfg, mpc)
+		if errf == nil && liquidity == nil {
+			continue // no liquidity limit
+		}
+		info := &router.LiquidityInfo{
+			ChainID:   chainID,
+			TokenID:   tokenID,
+			Token:     multichainToken,
+			Holder:    holder,
+			Timestamp: now(),
+		}
+		updateLiquidityInfo(info, liquidity, errf,
+			pendings[strings.ToLower(tokenID)],
+			params.GetTokenLowWaterMark(tokenID, chainID))
+	}
+
+	for mpc := range mpcs {
+		balance, errf := bridge.GetBalance(mpc)
+		info := &router.LiquidityInfo{
+			ChainID:   chainID,
+			Holder:    mpc,
+			Timestamp: now(),
+		}
+		updateLiquidityInfo(info, balance, errf, nil, params.GetNativeLowWaterMark(chainID))
+	}
+}
+
+func updateLiquidityInfo(info *router.LiquidityInfo, balance *big.Int, err error, pending, lowWaterMark *big.Int) {
+	if err != nil || balance == nil {
+		if err != nil {
+			info.Error = err.Error()
+		}
+		router.SetLiquidityInfo(info)
+		logWorkerError("liquidity", "get liquidity failed", err, "chainID", info.ChainID, "tokenID", info.TokenID, "holder", info.Holder)
+		return
+	}
+	info.Balance = balance.String()
+	if pending != nil {
+		info.Pending = pending.String()
+		info.IsLow = balance.Cmp(pending) < 0
+	}
+	if lowWaterMark != nil {
+		info.LowWaterMark = lowWaterMark.String()
+		info.IsLow = info.IsLow || balance.Cmp(lowWaterMark) < 0
+	}
+	router.SetLiquidityInfo(info)
+	if info.IsLow {
+		logWorkerWarn("liquidity", "liquidity alert: balance is low", "chainID", info.ChainID, "tokenID", info.TokenID, "holder", info.Holder,
+			"balance", info.Balance, "pending", info.Pending, "lowWaterMark", info.LowWaterMark)
+	}
+}
+
+// getPendingSwapValues sum the value of not sent swaps to chain.
+// key is tokenID (lower case), value is in the decimals of the dest token.
+func getPendingSwapValues(toChainID string) (map[string]*big.Int, error) {
+	res, err := mongodb.FindRouterSwapResultsPending(toChainID)
+	if err != nil {
+		return nil, err
+	}
+	bigValueSwaps, err := mongodb.FindRouterSwapsPendingBigValue(toChainID)
+	if err != nil {
+		return nil, err
+	}
+	for _, swap := range bigValueSwaps {
+		res = append(res, swap.ToSwapResult())
+	}
+	pendings := make(map[string]*big.Int)
+	for _, swap := range res {
+		if swap.ERC20SwapInfo == nil {
+			continue
+		}
+		tokenID := swap.ERC20SwapInfo.TokenID
+		fromBridge := router.GetBridgeByChainID(swap.FromChainID)
+		toBridge := router.GetBridgeByChainID(toChainID)
+		if fromBridge == nil || toBridge == nil {
+			continue
+		}
+		fromTokenCfg := fromBridge.GetTokenConfig(swap.ERC20SwapInfo.Token)
+		toTokenCfg := toBridge.GetTokenConfig(router.GetCachedMultichainToken(tokenID, toChainID))
+		if fromTokenCfg == nil || toTokenCfg == nil {
+			continue
+		}
+		value, errf := common.GetBigIntFromStr(swap.Value)
+		if errf != nil {
+			continue
+		}
+		swapValue := tokens.CalcSwapValue(tokenID, swap.FromChainID, toChainID, value, fromTokenCfg.Decimals, toTokenCfg.Decimals, swap.From, swap.TxTo)
+		key := strings.ToLower(tokenID)
+		if pending, exist := pendings[key]; exist {
+			pending.Add(pending, swapValue)
+		} else {
+			pendings[key] = swapValue
+		}
+	}
+	return pendings, nil
+}
@@ -2,8 +2,10 @@ package worker
 
 import (
 	"errors"
+	"math/big"
 
 	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/mongodb"
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
@@ -26,7 +28,7 @@ func StartPassBigValueJob() {
 		logWorker("passbigval", "stop pass big value job as disabled")
 		return
 	}
-	if !tokens.IsERC20Router() {
+	if !tokens.IsSwapTypeEnabled(tokens.ERC20SwapType) {
 		logWorker("passbigval", "stop pass big value job as non erc20 swap")
 		return
 	}
@@ -67,6 +69,11 @@ func doPassBigValueJob() {
 	}
 }
 
+// FindPendingBigValueSwaps find big value swaps waiting to pass
+func FindPendingBigValueSwaps() ([]*mongodb.MgoSwap, error) {
+	return findBigValRouterSwaps()
+}
+
 func findBigValRouterSwaps() ([]*mongodb.MgoSwap, error) {
 	septime := getSepTimeInFind(maxPassBigValueLifetime)
 	return mongodb.FindRouterSwapsWithStatus(mongodb.TxWithBigValue, septime)
@@ -76,7 +83,10 @@ func processPassBigValRouterSwap(swap *mongodb.MgoSwap) (err error) {
 	if swap.Status != mongodb.TxWithBigValue {
 		return nil
 	}
-	if swap.InitTime > getSepTimeInFind(passBigValueTimeRequired)*1000 { // init time is milli seconds
+	if swap.BigValueHeld {
+		return nil
+	}
+	if GetBigValueReleaseTime(swap) > now() {
 		return nil
 	}
 	if getSepTimeInFind(minTimeIntervalToPassBigValue) < swap.Timestamp {
@@ -114,3 +124,39 @@ func processPassBigValRouterSwap(swap *mongodb.MgoSwap) (err error) {
 	_ = AddInitialSwapResult(swapInfo, mongodb.MatchTxEmpty)
 	return nil
 }
+
+// GetBigValueReleaseTime get the time (unix seconds) when big value swap is passed automatically.
+// the delay is decided by the timelock schedule according to the swap value.
+func GetBigValueReleaseTime(swap *mongodb.MgoSwap) int64 {
+	initTime := swap.InitTime / 1000 // init time is milli seconds
+	return initTime + getBigValueTimelockDelay(swap)
+}
+
+func getBigValueTimelockDelay(swap *mongodb.MgoSwap) int64 {
+	timelocks := params.GetBigValueTimelocks()
+	if len(timelocks) == 0 || swap.ERC20SwapInfo == nil {
+		return passBigValueTimeRequired
+	}
+	bridge := router.GetBridgeByChainID(swap.FromChainID)
+	if bridge == nil {
+		return passBigValueTimeRequired
+	}
+	tokenCfg := bridge.GetTokenConfig(swap.ERC20SwapInfo.Token)
+	if tokenCfg == nil {
+		return passBigValueTimeRequired
+	}
+	value, err := common.GetBigIntFromStr(swap.Value)
+	if err != nil {
+		return passBigValueTimeRequired
+	}
+	threshold := tokens.GetBigValueThreshold(swap.ERC20SwapInfo.TokenID, swap.FromChainID, swap.ToChainID, tokenCfg.Decimals)
+	// timelocks is sorted by threshold percent desc
+	for _, timelock := range timelocks {
+		lockValue := new(big.Int).Mul(threshold, new(big.Int).SetUint64(timelock.ThresholdPercent))
+		lockValue.Div(lockValue, big.NewInt(100))
+		if value.Cmp(lockValue) > 0 {
+			return timelock.Delay
+		}
+	}
+	return passBigValueTimeRequired
+}
@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tools/fifo"
+)
+
+// swap priority classes, ordered from high to low
+var swapPriorityClasses = []string{
+	params.SwapPriorityHigh,
+	params.SwapPriorityNormal,
+	params.SwapPriorityLow,
+}
+
+const (
+	swapPriorityHigh   = 0
+	swapPriorityNormal = 1
+	swapPriorityLow    = 2
+)
+
+func newSwapTaskQueue() *fifo.PriorityQueue {
+	cfg := params.GetSwapPriorityConfig()
+	if cfg == nil {
+		return fifo.NewPriorityQueue(len(swapPriorityClasses), 0, 0)
+	}
+	maxWaitTime := time.Duration(cfg.MaxWaitTime) * time.Second
+	return fifo.NewPriorityQueue(len(swapPriorityClasses), cfg.StarvationLimit, maxWaitTime)
+}
+
+func getSwapPriorityClass(priority string) int {
+	switch priority {
+	case params.SwapPriorityHigh:
+		return swapPriorityHigh
+	case params.SwapPriorityLow:
+		return swapPriorityLow
+	default:
+		return swapPriorityNormal
+	}
+}
+
+// getSwapPriority get priority class and fairness group of swap task.
+// all tasks are in the same class and group (fifo) if swap priority is disabled.
+func getSwapPriority(args *tokens.BuildTxArgs, initTime int64) (class int, group string) {
+	cfg := params.GetSwapPriorityConfig()
+	if cfg == nil {
+		return swapPriorityNormal, ""
+	}
+
+	group = args.SwapType.String()
+	if args.ERC20SwapInfo != nil {
+		group = args.ERC20SwapInfo.TokenID
+	}
+
+	class = swapPriorityNormal
+	if priority, exist := cfg.SwapTypePriority[args.SwapType.String()]; exist {
+		class = getSwapPriorityClass(priority)
+	}
+
+	usdValue := getSwapUSDValue(cfg, args)
+	switch {
+	case cfg.IsWhitelistSender(args.OriginFrom),
+		cfg.HighPriorityAge > 0 && initTime > 0 && now()-initTime/1000 >= cfg.HighPriorityAge,
+		usdValue != nil && cfg.HighValueUSD > 0 && usdValue.Cmp(big.NewFloat(cfg.HighValueUSD)) >= 0:
+		class = swapPriorityHigh
+	case class == swapPriorityNormal && usdValue != nil && usdValue.Cmp(big.NewFloat(cfg.LowValueUSD)) < 0:
+		class = swapPriorityLow
+	}
+	return class, group
+}
+
+// getSwapUSDValue calc USD value of swap by token decimals and price.
+// returns nil if it can not be calculated.
+func getSwapUSDValue(cfg *params.SwapPriorityConfig, args *tokens.BuildTxArgs) *big.Float {
+	if args.ERC20SwapInfo == nil || args.OriginValue == nil {
+		return nil
+	}
+	price, exist := cfg.TokenPriceUSD[args.ERC20SwapInfo.TokenID]
+	if !exist {
+		return nil
+	}
+	bridge := router.GetBridgeByChainID(args.FromChainID.String())
+	if bridge == nil {
+		return nil
+	}
+	tokenCfg := bridge.GetTokenConfig(args.ERC20SwapInfo.Token)
+	if tokenCfg == nil {
+		return nil
+	}
+	decimals := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(tokenCfg.Decimals)), nil)
+	value := new(big.Float).Quo(new(big.Float).SetInt(args.OriginValue), new(big.Float).SetInt(decimals))
+	return value.Mul(value, big.NewFloat(price))
+}
+
+// GetSwapQueueDepths get depths of swap task queues of priority classes.
+// the result is keyed by chainID and then priority class.
+func GetSwapQueueDepths(chainID string) map[string]map[string]int {
+	result := make(map[string]map[string]int)
+	router.RouterBridges.Range(func(k, v interface{}) bool {
+		toChainID := k.(string)
+		if chainID != "" && chainID != toChainID {
+			return true
+		}
+		depths := make(map[string]int, len(swapPriorityClasses))
+		for _, priority := range swapPriorityClasses {
+			depths[priority] = 0
+		}
+		for _, swapType := range tokens.GetRouterSwapTypes() {
+			taskQueue, exist := swapTaskQueues[getSwapTaskQueueKey(swapType, toChainID)]
+			if !exist {
+				continue
+			}
+			for class, priority := range swapPriorityClasses {
+				depths[priority] += taskQueue.LenOfClass(class)
+			}
+		}
+		result[toChainID] = depths
+		return true
+	})
+	return result
+}
@@ -0,0 +1,176 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	defWaitTimeToRebroadcast = int64(120) // seconds
+	defMaxRebroadcastCount   = 100
+
+	errSignedTxNotSupported = errors.New("bridge does not support persisting signed tx")
+)
+
+// StartRebroadcastJob rebroadcast job
+func StartRebroadcastJob() {
+	cfg := params.GetRouterServerConfig()
+	if cfg == nil || !cfg.EnableRebroadcastSwap {
+		logWorker("rebroadcast", "stop rebroadcast swap job as disabled")
+		return
+	}
+	mongodb.MgoWaitGroup.Add(1)
+	go startRebroadcastJob()
+}
+
+func startRebroadcastJob() {
+	defer mongodb.MgoWaitGroup.Done()
+	logWorker("rebroadcast", "start router swap rebroadcast job")
+	for {
+		septime := getSepTimeInFind(maxRebroadcastLifetime)
+		res, err := mongodb.FindRouterSwapResultsWithStatus(mongodb.MatchTxNotStable, septime)
+		if err != nil {
+			logWorkerError("rebroadcast", "find out router swap error", err)
+		}
+		for _, swap := range res {
+			if utils.IsCleanuping() {
+				logWorker("rebroadcast", "stop router swap rebroadcast job")
+				return
+			}
+			if swap.SwapHeight != 0 || swap.SwapTx == "" {
+				continue
+			}
+			if getSepTimeInFind(getWaitTimeToRebroadcast()) < swap.Timestamp {
+				continue
+			}
+			err = rebroadcastSwapTxs(swap, false)
+			if err != nil && !errors.Is(err, errSignedTxNotSupported) {
+				logWorkerError("rebroadcast", "rebroadcast swap failed", err, "fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex)
+			}
+		}
+		if utils.IsCleanuping() {
+			logWorker("rebroadcast", "stop router swap rebroadcast job")
+			return
+		}
+		restInJob(restIntervalInRebroadcastJob)
+	}
+}
+
+func getWaitTimeToRebroadcast() int64 {
+	if wait := params.GetRouterServerConfig().WaitTimeToRebroadcast; wait > 0 {
+		return wait
+	}
+	return defWaitTimeToRebroadcast
+}
+
+func getMaxRebroadcastCount() int {
+	if count := params.GetRouterServerConfig().MaxRebroadcastCount; count > 0 {
+		return count
+	}
+	return defMaxRebroadcastCount
+}
+
+// RebroadcastRouterSwap rebroadcast persisted signed txs of swap
+func RebroadcastRouterSwap(fromChainID, txid string, logIndex int) error {
+	res, err := mongodb.FindRouterSwapResult(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if res.Status != mongodb.MatchTxNotStable {
+		return fmt.Errorf("swap result status is %v, can not rebroadcast", res.Status.String())
+	}
+	return rebroadcastSwapTxs(res, true)
+}
+
+func rebroadcastSwapTxs(res *mongodb.MgoSwapResult, isManual bool) error {
+	resBridge := router.GetBridgeByChainID(res.ToChainID)
+	if resBridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	marshaler, ok := resBridge.(tokens.SignedTxMarshaler)
+	if !ok {
+		return errSignedTxNotSupported
+	}
+	signedTxs, err := mongodb.FindSignedTxs(res.FromChainID, res.TxID, res.LogIndex)
+	if err != nil {
+		return err
+	}
+	if len(signedTxs) == 0 {
+		return errors.New("no persisted signed tx")
+	}
+	maxRebroadcastCount := getMaxRebroadcastCount()
+	var sentCount int
+	for _, stx := range signedTxs {
+		if stx.SwapNonce != res.SwapNonce {
+			continue
+		}
+		if !isManual && stx.Rebroadcast >= maxRebroadcastCount {
+			continue
+		}
+		signedTx, errf := marshaler.UnmarshalSignedTx(common.FromHex(stx.RawTx))
+		if errf != nil {
+			logWorkerError("rebroadcast", "unmarshal signed tx failed", errf, "toChainID", stx.ToChainID, "swaptx", stx.SwapTx)
+			continue
+		}
+		txHash, errf := resBridge.SendTransaction(signedTx)
+		_ = mongodb.UpdateSignedTxRebroadcast(stx.Key)
+		if errf != nil {
+			logWorkerWarn("rebroadcast", "rebroadcast swap tx failed", "fromChainID", res.FromChainID, "toChainID", res.ToChainID, "txid", res.TxID, "logIndex", res.LogIndex, "swaptx", stx.SwapTx, "swapNonce", stx.SwapNonce, "err", errf)
+			continue
+		}
+		sentCount++
+		logWorker("rebroadcast", "rebroadcast swap tx success", "fromChainID", res.FromChainID, "toChainID", res.ToChainID, "txid", res.TxID, "logIndex", res.LogIndex, "swaptx", txHash, "swapNonce", stx.SwapNonce, "count", stx.Rebroadcast+1)
+	}
+	if isManual && sentCount == 0 {
+		return errors.New("no signed tx is rebroadcasted")
+	}
+	return nil
+}
+
+func recordSignedTx(resBridge tokens.IBridge, signedTx interface{}, txHash string, args *tokens.BuildTxArgs) {
+	marshaler, ok := resBridge.(tokens.SignedTxMarshaler)
+	if !ok {
+		return
+	}
+	raw, err := marshaler.MarshalSignedTx(signedTx)
+	if err != nil {
+		logWorkerError("rebroadcast", "marshal signed tx failed", err, "fromChainID", args.FromChainID, "toChainID", args.ToChainID, "txid", args.SwapID, "logIndex", args.LogIndex, "swaptx", txHash)
+		return
+	}
+	mt := &mongodb.MgoSignedTx{
+		FromChainID: args.FromChainID.String(),
+		ToChainID:   args.ToChainID.String(),
+		TxID:        args.SwapID,
+		LogIndex:    args.LogIndex,
+		SwapTx:      txHash,
+		SwapNonce:   args.GetTxNonce(),
+		ReplaceNum:  args.GetReplaceNum(),
+		MPC:         args.From,
+		KeyID:       args.MPCKeyID,
+		RawTx:       common.ToHex(raw),
+		Timestamp:   now(),
+	}
+	if args.Extra != nil && args.Extra.EthExtra != nil {
+		extra := args.Extra.EthExtra
+		if extra.Gas != nil {
+			mt.Gas = *extra.Gas
+		}
+		if extra.GasPrice != nil {
+			mt.GasPrice = extra.GasPrice.String()
+		}
+		if extra.GasTipCap != nil {
+			mt.GasTipCap = extra.GasTipCap.String()
+		}
+		if extra.GasFeeCap != nil {
+			mt.GasFeeCap = extra.GasFeeCap.String()
+		}
+	}
+	_ = mongodb.AddSignedTx(mt)
+}
@@ -0,0 +1,223 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var errRefundValidSwap = errors.New("refund valid swap")
+
+// StartRefundJob refund job
+func StartRefundJob() {
+	logWorker("refund", "start router swap refund job")
+
+	mongodb.MgoWaitGroup.Add(1)
+	go doRefundJob()
+}
+
+func doRefundJob() {
+	defer mongodb.MgoWaitGroup.Done()
+	for {
+		res, err := findRouterSwapsToRefund()
+		if err != nil {
+			logWorkerError("refund", "find refund swaps error", err)
+		}
+		if len(res) > 0 {
+			logWorker("refund", "find refund swaps", "count", len(res))
+		}
+		for _, swap := range res {
+			if utils.IsCleanuping() {
+				logWorker("refund", "stop router swap refund job")
+				return
+			}
+
+			if swapTasksInQueue.Contains(swap.Key) || cachedSwapTasks.Contains(swap.Key) {
+				logWorkerTrace("refund", "ignore swap in queue or cache", "key", swap.Key)
+				continue
+			}
+
+			if !IsChainLeaseOwner(swap.FromChainID) {
+				continue
+			}
+
+			if IsChainDraining(swap.FromChainID) {
+				logWorkerTrace("refund", "ignore swap as chain is draining", "key", swap.Key)
+				continue
+			}
+
+			err = processRouterSwapRefund(swap)
+			ctx := []interface{}{"fromChainID", swap.FromChainID, "txid", swap.TxID, "logIndex", swap.LogIndex}
+			switch {
+			case err == nil:
+				logWorker("refund", "process router swap refund success", ctx...)
+			case errors.Is(err, errAlreadySwapped),
+				errors.Is(err, errChainIsPaused):
+				ctx = append(ctx, "err", err)
+				logWorkerTrace("refund", "process router swap refund error", ctx...)
+			default:
+				logWorkerError("refund", "process router swap refund error", err, ctx...)
+			}
+		}
+		if utils.IsCleanuping() {
+			logWorker("refund", "stop router swap refund job")
+			return
+		}
+		restInJob(restIntervalInRefundJob)
+	}
+}
+
+func findRouterSwapsToRefund() ([]*mongodb.MgoSwap, error) {
+	septime := getSepTimeInFind(maxRefundLifetime)
+	return mongodb.FindRouterSwapsWithStatus(mongodb.TxRefundApproved, septime)
+}
+
+// processRouterSwapRefund dispatch refund task to the swap queue of the source chain
+func processRouterSwapRefund(swap *mongodb.MgoSwap) (err error) {
+	fromChainID := swap.FromChainID
+	if router.IsChainIDPaused(fromChainID) {
+		return errChainIsPaused
+	}
+
+	txid := swap.TxID
+	logIndex := swap.LogIndex
+
+	res, err := mongodb.FindRouterSwapResult(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	if res.Status != mongodb.RefundTxEmpty || res.ToChainID != fromChainID {
+		return errAlreadySwapped
+	}
+
+	logWorker("refund", "start process router swap refund", "fromChainID", fromChainID, "txid", txid, "logIndex", logIndex, "from", swap.From, "value", res.Value)
+
+	err = preventReswap(res)
+	if err != nil {
+		return err
+	}
+
+	biFromChainID, biToChainID, biValue, err := getFromToChainIDAndValue(fromChainID, fromChainID, res.Value)
+	if err != nil {
+		return err
+	}
+
+	routerMPC, err := router.GetRouterMPC(swap.GetTokenID(), fromChainID)
+	if err != nil {
+		return err
+	}
+
+	args := &tokens.BuildTxArgs{
+		SwapArgs: tokens.SwapArgs{
+			Identifier:  params.GetSwapTypeIdentifier(tokens.SwapType(swap.SwapType).String()),
+			SwapID:      txid,
+			SwapType:    tokens.SwapType(swap.SwapType),
+			Bind:        swap.From,
+			LogIndex:    logIndex,
+			FromChainID: biFromChainID,
+			ToChainID:   biToChainID,
+			Refund:      true,
+			TraceID:     tokens.GetTraceIDOfSwapKey(swap.Key),
+		},
+		From:        routerMPC,
+		OriginFrom:  swap.From,
+		OriginTxTo:  swap.TxTo,
+		OriginValue: biValue,
+	}
+	args.SwapInfo, err = mongodb.ConvertFromSwapInfo(&swap.SwapInfo)
+	if err != nil {
+		return err
+	}
+
+	return dispatchSwapTask(args, swap.InitTime)
+}
+
+// buildRawTransaction build swap tx, or refund tx if it's a refund
+func buildRawTransaction(bridge tokens.IBridge, args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	if !args.Refund {
+		return bridge.BuildRawTransaction(args)
+	}
+	refunder, ok := bridge.(tokens.TxRefunder)
+	if !ok {
+		return nil, tokens.ErrNotImplemented
+	}
+	return refunder.BuildRefundTransaction(args)
+}
+
+// getProcessedStatus get swap status after the swap (or refund) tx is built
+func getProcessedStatus(isRefund bool) mongodb.SwapStatus {
+	if isRefund {
+		return mongodb.TxRefunded
+	}
+	return mongodb.TxProcessed
+}
+
+// only agree to refund swap tx if the swap is verified to be unswappable
+func rebuildAndVerifyRefundMsgHash(srcBridge tokens.IBridge, msgHash []string, args *tokens.BuildTxArgs, ctx []interface{}) error {
+	refunder, ok := srcBridge.(tokens.TxRefunder)
+	if !ok {
+		return tokens.ErrNotImplemented
+	}
+	verifyArgs := &tokens.VerifyArgs{
+		SwapType:      args.SwapType,
+		LogIndex:      args.LogIndex,
+		AllowUnstable: false,
+	}
+	swapInfo, err := srcBridge.VerifyTransaction(args.SwapID, verifyArgs)
+	if err != nil && !tokens.IsRefundableError(err) {
+		return err
+	}
+	if swapInfo == nil || swapInfo.FromChainID == nil || swapInfo.ToChainID == nil {
+		return tokens.ErrTxNotFound
+	}
+	if err == nil && !router.IsBlacklistSwap(swapInfo) {
+		return errRefundValidSwap
+	}
+	logWorker("accept", "verify refund swap tx", append(ctx, "verifyErr", err)...)
+	if IsRefundBlacked(swapInfo.FromChainID.String(), swapInfo.From) {
+		return tokens.ErrSwapInBlacklist
+	}
+	if args.FromChainID.Cmp(swapInfo.FromChainID) != 0 || args.ToChainID.Cmp(swapInfo.FromChainID) != 0 {
+		return tokens.ErrToChainIDMismatch
+	}
+	if !strings.EqualFold(args.Bind, swapInfo.From) {
+		return fmt.Errorf("refund receiver mismatch: '%v' != '%v'", args.Bind, swapInfo.From)
+	}
+
+	buildTxArgs := &tokens.BuildTxArgs{
+		SwapArgs: tokens.SwapArgs{
+			SwapInfo:    swapInfo.SwapInfo,
+			Identifier:  params.GetSwapTypeIdentifier(swapInfo.SwapType.String()),
+			SwapID:      swapInfo.Hash,
+			SwapType:    swapInfo.SwapType,
+			Bind:        swapInfo.From,
+			LogIndex:    swapInfo.LogIndex,
+			FromChainID: swapInfo.FromChainID,
+			ToChainID:   swapInfo.FromChainID,
+			Refund:      true,
+		},
+		From:        args.From,
+		OriginFrom:  swapInfo.From,
+		OriginTxTo:  swapInfo.TxTo,
+		OriginValue: swapInfo.Value,
+		Extra:       args.Extra,
+	}
+	rawTx, err := refunder.BuildRefundTransaction(buildTxArgs)
+	if err != nil {
+		logWorkerError("accept", "build refund tx failed", err, ctx...)
+		return err
+	}
+	err = srcBridge.VerifyMsgHash(rawTx, msgHash)
+	if err != nil {
+		logWorkerError("accept", "verify refund message hash failed", err, ctx...)
+		return err
+	}
+	logWorker("accept", "verify refund message hash success", ctx...)
+	return nil
+}
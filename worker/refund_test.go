@@ -0,0 +1,221 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+const (
+	tRefundTxID    = "0x1111111111111111111111111111111111111111111111111111111111111111"
+	tRefundFrom    = "0x2222222222222222222222222222222222222222"
+	tRefundMPC     = "0x3333333333333333333333333333333333333333"
+	tRefundTokenID = "REFUNDTEST"
+)
+
+// refundTestBridge mock source bridge, only the methods used by refund are implemented
+type refundTestBridge struct {
+	tokens.IBridge
+
+	verifyErr error
+}
+
+func (b *refundTestBridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+	return &tokens.SwapTxInfo{
+		SwapInfo:    tokens.SwapInfo{ERC20SwapInfo: &tokens.ERC20SwapInfo{TokenID: tRefundTokenID}},
+		Hash:        txHash,
+		LogIndex:    args.LogIndex,
+		SwapType:    args.SwapType,
+		From:        tRefundFrom,
+		TxTo:        tRefundFrom,
+		Bind:        tRefundFrom,
+		Value:       big.NewInt(1000),
+		FromChainID: big.NewInt(1),
+		ToChainID:   big.NewInt(2),
+	}, b.verifyErr
+}
+
+func (b *refundTestBridge) BuildRefundTransaction(args *tokens.BuildTxArgs) (interface{}, error) {
+	return refundMsgHash(args.SwapID, args.Bind, args.ToChainID, args.OriginValue), nil
+}
+
+func (b *refundTestBridge) VerifyMsgHash(rawTx interface{}, msgHash []string) error {
+	if len(msgHash) != 1 || rawTx.(string) != msgHash[0] {
+		return tokens.ErrMsgHashMismatch
+	}
+	return nil
+}
+
+// refundTestNonRefunder mock bridge which does not implement tokens.TxRefunder
+type refundTestNonRefunder struct {
+	tokens.IBridge
+}
+
+func refundMsgHash(swapID, bind string, toChainID, value *big.Int) string {
+	return fmt.Sprintf("%v:%v:%v:%v", swapID, bind, toChainID, value)
+}
+
+func newRefundArgs(bind string, toChainID int64) *tokens.BuildTxArgs {
+	return &tokens.BuildTxArgs{
+		SwapArgs: tokens.SwapArgs{
+			SwapID:      tRefundTxID,
+			SwapType:    tokens.ERC20SwapType,
+			Bind:        bind,
+			FromChainID: big.NewInt(1),
+			ToChainID:   big.NewInt(toChainID),
+			Refund:      true,
+		},
+		From: tRefundMPC,
+	}
+}
+
+func TestRebuildAndVerifyRefundMsgHash(t *testing.T) {
+	goodHash := []string{refundMsgHash(tRefundTxID, tRefundFrom, big.NewInt(1), big.NewInt(1000))}
+
+	tests := []struct {
+		name        string
+		bridge      tokens.IBridge
+		args        *tokens.BuildTxArgs
+		msgHash     []string
+		blackToken  bool
+		blackSender bool
+		wantErr     error
+	}{
+		{
+			name:    "not refunder",
+			bridge:  &refundTestNonRefunder{},
+			args:    newRefundArgs(tRefundFrom, 1),
+			msgHash: goodHash,
+			wantErr: tokens.ErrNotImplemented,
+		},
+		{
+			name:    "refund valid swap",
+			bridge:  &refundTestBridge{},
+			args:    newRefundArgs(tRefundFrom, 1),
+			msgHash: goodHash,
+			wantErr: errRefundValidSwap,
+		},
+		{
+			name:    "non refundable verify error",
+			bridge:  &refundTestBridge{verifyErr: tokens.ErrTxWithWrongReceiver},
+			args:    newRefundArgs(tRefundFrom, 1),
+			msgHash: goodHash,
+			wantErr: tokens.ErrTxWithWrongReceiver,
+		},
+		{
+			name:    "refundable verify error",
+			bridge:  &refundTestBridge{verifyErr: tokens.ErrTxWithWrongValue},
+			args:    newRefundArgs(tRefundFrom, 1),
+			msgHash: goodHash,
+		},
+		{
+			name:       "blacklisted swap",
+			bridge:     &refundTestBridge{},
+			args:       newRefundArgs(tRefundFrom, 1),
+			msgHash:    goodHash,
+			blackToken: true,
+		},
+		{
+			name:        "blacklisted sender",
+			bridge:      &refundTestBridge{verifyErr: tokens.ErrTxWithWrongValue},
+			args:        newRefundArgs(tRefundFrom, 1),
+			msgHash:     goodHash,
+			blackSender: true,
+			wantErr:     tokens.ErrSwapInBlacklist,
+		},
+		{
+			name:    "refund to other chain",
+			bridge:  &refundTestBridge{verifyErr: tokens.ErrTxWithWrongValue},
+			args:    newRefundArgs(tRefundFrom, 2),
+			msgHash: goodHash,
+			wantErr: tokens.ErrToChainIDMismatch,
+		},
+		{
+			name:    "refund to other receiver",
+			bridge:  &refundTestBridge{verifyErr: tokens.ErrTxWithWrongValue},
+			args:    newRefundArgs(tRefundMPC, 1),
+			msgHash: goodHash,
+			wantErr: errors.New("refund receiver mismatch"),
+		},
+		{
+			name:    "msg hash mismatch",
+			bridge:  &refundTestBridge{verifyErr: tokens.ErrTxWithWrongValue},
+			args:    newRefundArgs(tRefundFrom, 1),
+			msgHash: []string{refundMsgHash(tRefundTxID, tRefundMPC, big.NewInt(1), big.NewInt(1000))},
+			wantErr: tokens.ErrMsgHashMismatch,
+		},
+	}
+	for _, tt := range tests {
+		params.AddOrRemoveTokenIDBlackList([]string{tRefundTokenID}, tt.blackToken)
+		params.AddOrRemoveAccountBlackList([]string{tRefundFrom}, tt.blackSender)
+
+		err := rebuildAndVerifyRefundMsgHash(tt.bridge, tt.msgHash, tt.args, nil)
+		switch {
+		case tt.wantErr == nil:
+			if err != nil {
+				t.Errorf("%v: want no error, have %v", tt.name, err)
+			}
+		case err == nil:
+			t.Errorf("%v: want error %v, have nil", tt.name, tt.wantErr)
+		case errors.Is(err, tt.wantErr):
+		case err.Error() == tt.wantErr.Error() || len(err.Error()) > len(tt.wantErr.Error()) &&
+			err.Error()[:len(tt.wantErr.Error())] == tt.wantErr.Error():
+		default:
+			t.Errorf("%v: want error %v, have %v", tt.name, tt.wantErr, err)
+		}
+	}
+	params.AddOrRemoveTokenIDBlackList([]string{tRefundTokenID}, false)
+	params.AddOrRemoveAccountBlackList([]string{tRefundFrom}, false)
+}
+
+func TestRefundStatusTransitions(t *testing.T) {
+	tests := []struct {
+		status         mongodb.SwapStatus
+		refundable     bool
+		isRefundResult bool
+	}{
+		{mongodb.TxWithWrongValue, true, false},
+		{mongodb.TxWithWrongPath, true, false},
+		{mongodb.MissTokenConfig, true, false},
+		{mongodb.NoUnderlyingToken, true, false},
+		{mongodb.SwapInBlacklist, true, false},
+		{mongodb.TxNotStable, false, false},
+		{mongodb.TxVerifyFailed, false, false},
+		{mongodb.TxNotSwapped, false, false},
+		{mongodb.TxProcessed, false, false},
+		{mongodb.TxWithBigValue, false, false},
+		{mongodb.BigValueCanceled, false, false},
+		{mongodb.SwapInScreeningReview, false, false},
+		{mongodb.TxRefundApproved, false, false},
+		{mongodb.TxRefunded, false, false},
+		{mongodb.MatchTxEmpty, false, false},
+		{mongodb.MatchTxFailed, false, false},
+		{mongodb.RefundTxEmpty, false, true},
+		{mongodb.RefundTxNotStable, false, true},
+		{mongodb.RefundTxStable, false, true},
+		{mongodb.RefundTxFailed, false, true},
+	}
+	for _, tt := range tests {
+		if have := tt.status.IsRefundable(); have != tt.refundable {
+			t.Errorf("status %v: IsRefundable is %v, want %v", tt.status, have, tt.refundable)
+		}
+		if have := tt.status.IsRefundStatus(); have != tt.isRefundResult {
+			t.Errorf("status %v: IsRefundStatus is %v, want %v", tt.status, have, tt.isRefundResult)
+		}
+		if tt.isRefundResult && !tt.status.IsResultStatus() {
+			t.Errorf("status %v: refund status should be result status", tt.status)
+		}
+	}
+
+	if status := getProcessedStatus(true); status != mongodb.TxRefunded {
+		t.Errorf("processed status of refund is %v, want %v", status, mongodb.TxRefunded)
+	}
+	if status := getProcessedStatus(false); status != mongodb.TxProcessed {
+		t.Errorf("processed status of swap is %v, want %v", status, mongodb.TxProcessed)
+	}
+}
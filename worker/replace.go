@@ -26,6 +26,7 @@ var (
 
 	replaceTaskQueues   = make(map[string]*fifo.Queue) // key is toChainID
 	replaceTasksInQueue = mapset.NewSet()
+	giveUpReplaceSwaps  = mapset.NewSet()
 )
 
 // StartReplaceJob replace job
@@ -81,6 +82,15 @@ func startReplaceProducer() {
 				continue
 			}
 
+			if !IsChainLeaseOwner(swap.ToChainID) {
+				continue
+			}
+
+			if IsChainDraining(swap.ToChainID) {
+				logWorkerTrace("replace", "ignore swap as chain is draining", "key", swap.Key)
+				continue
+			}
+
 			err = dispatchSwapResultToReplace(swap)
 			ctx := []interface{}{"fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex}
 			if err == nil {
@@ -99,12 +109,13 @@ func startReplaceProducer() {
 
 func findRouterSwapResultToReplace() ([]*mongodb.MgoSwapResult, error) {
 	septime := getSepTimeInFind(maxReplaceSwapLifetime)
-	return mongodb.FindRouterSwapResultsWithStatus(mongodb.MatchTxNotStable, septime)
+	return findRouterSwapResultsWithNotStableStatus(septime)
 }
 
 func dispatchSwapResultToReplace(res *mongodb.MgoSwapResult) error {
-	waitTimeToReplace := serverCfg.WaitTimeToReplace
-	maxReplaceCount := serverCfg.MaxReplaceCount
+	strategy := params.GetReplaceStrategy(res.ToChainID)
+	waitTimeToReplace := strategy.WaitTimeToReplace
+	maxReplaceCount := strategy.MaxReplaceCount
 	if waitTimeToReplace == 0 {
 		waitTimeToReplace = defWaitTimeToReplace
 	}
@@ -112,6 +123,11 @@ func dispatchSwapResultToReplace(res *mongodb.MgoSwapResult) error {
 		maxReplaceCount = defMaxReplaceCount
 	}
 	if len(res.OldSwapTxs) > maxReplaceCount {
+		if !giveUpReplaceSwaps.Contains(res.Key) {
+			giveUpReplaceSwaps.Add(res.Key)
+			recordReplaceDecision(res, nil, mongodb.ReplaceActionGiveUp, false, "",
+				fmt.Sprintf("exceed max replace count %v", maxReplaceCount), nil)
+		}
 		checkAndRecycleSwapNonce(res)
 		return nil
 	}
@@ -189,6 +205,18 @@ func startReplaceConsumer(chainID string) {
 			continue
 		}
 
+		if !IsChainLeaseOwner(chainID) {
+			logWorkerTrace("doReplace", "ignore replace task as chain lease is lost", "chainID", chainID, "key", swap.Key)
+			replaceTasksInQueue.Remove(swap.Key)
+			continue
+		}
+
+		if IsChainDraining(chainID) {
+			logWorkerTrace("doReplace", "ignore replace task as chain is draining", "chainID", chainID, "key", swap.Key)
+			replaceTasksInQueue.Remove(swap.Key)
+			continue
+		}
+
 		ctx := []interface{}{"fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex}
 		err := ReplaceRouterSwap(swap, nil, false)
 		if err == nil {
@@ -212,6 +240,18 @@ func ReplaceRouterSwap(res *mongodb.MgoSwapResult, gasPrice *big.Int, isManual b
 	if resBridge == nil {
 		return tokens.ErrNoBridgeForChainID
 	}
+
+	err = beginSwapTask(res.ToChainID, isManual)
+	if err != nil {
+		return err
+	}
+	isSwapTaskEnded := false
+	defer func() {
+		if !isSwapTaskEnded {
+			endSwapTask(res.ToChainID)
+		}
+	}()
+
 	routerMPC, err := router.GetRouterMPC(swap.GetTokenID(), res.ToChainID)
 	if err != nil {
 		return err
@@ -225,6 +265,12 @@ func ReplaceRouterSwap(res *mongodb.MgoSwapResult, gasPrice *big.Int, isManual b
 		return err
 	}
 
+	chainLease := getChainLease(res.ToChainID)
+	err = checkChainLease(res.ToChainID, chainLease)
+	if err != nil {
+		return err
+	}
+
 	logWorker("replaceSwap", "process task", "swap", res)
 	_ = updateSwapTimestamp(res.FromChainID, res.TxID, res.LogIndex)
 
@@ -236,13 +282,15 @@ func ReplaceRouterSwap(res *mongodb.MgoSwapResult, gasPrice *big.Int, isManual b
 	}
 	args := &tokens.BuildTxArgs{
 		SwapArgs: tokens.SwapArgs{
-			Identifier:  params.GetIdentifier(),
+			Identifier:  params.GetSwapTypeIdentifier(tokens.SwapType(res.SwapType).String()),
 			SwapID:      txid,
 			SwapType:    tokens.SwapType(res.SwapType),
 			Bind:        res.Bind,
 			LogIndex:    res.LogIndex,
 			FromChainID: biFromChainID,
 			ToChainID:   biToChainID,
+			Refund:      res.Status.IsRefundStatus(),
+			TraceID:     tokens.GetTraceIDOfSwapKey(res.Key),
 		},
 		From:        res.MPC,
 		OriginFrom:  swap.From,
@@ -256,46 +304,208 @@ func ReplaceRouterSwap(res *mongodb.MgoSwapResult, gasPrice *big.Int, isManual b
 			Sequence:   &nonce,
 			ReplaceNum: replaceNum,
 		},
+		ChainLease: chainLease,
 	}
 	args.SwapInfo, err = mongodb.ConvertFromSwapInfo(&swap.SwapInfo)
 	if err != nil {
 		return err
 	}
-	rawTx, err := resBridge.BuildRawTransaction(args)
+	span := startSwapSpan("replace", args, "nonce", nonce, "replaceNum", replaceNum, "manual", isManual)
+	rawTx, err := buildRawTransaction(resBridge, args)
+	span.End(err)
 	if err != nil {
 		logWorkerError("replaceSwap", "build tx failed", err, "chainID", res.ToChainID, "txid", txid, "logIndex", res.LogIndex)
+		recordReplaceDecision(res, args, mongodb.ReplaceActionReplace, isManual, "", "", err)
 		return err
 	}
-	go signAndSendReplaceTx(resBridge, rawTx, args, res)
+	isSwapTaskEnded = true // end in sign and send routine
+	go func() {
+		defer endSwapTask(res.ToChainID)
+		signAndSendReplaceTx(resBridge, rawTx, args, res, isManual)
+	}()
 	return nil
 }
 
-func signAndSendReplaceTx(resBridge tokens.IBridge, rawTx interface{}, args *tokens.BuildTxArgs, res *mongodb.MgoSwapResult) {
+func signAndSendReplaceTx(resBridge tokens.IBridge, rawTx interface{}, args *tokens.BuildTxArgs, res *mongodb.MgoSwapResult, isManual bool) {
+	signSpan := startSwapSpan("sign", args, "swapNonce", res.SwapNonce, "replace", true)
 	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
+	signSpan.End(err)
 	if err != nil {
 		logWorkerError("replaceSwap", "mpc sign tx failed", err, "fromChainID", res.FromChainID, "toChainID", res.ToChainID, "txid", res.TxID, "nonce", res.SwapNonce, "logIndex", res.LogIndex)
+		recordReplaceDecision(res, args, mongodb.ReplaceActionReplace, isManual, "", "", err)
 		if errors.Is(err, mpc.ErrGetSignStatusHasDisagree) {
 			reverifySwap(args)
 		}
 		return
 	}
+	recordReplaceDecision(res, args, mongodb.ReplaceActionReplace, isManual, txHash, "", nil)
 
 	fromChainID := res.FromChainID
 	txid := res.TxID
 	logIndex := res.LogIndex
 
-	err = mongodb.UpdateRouterOldSwapTxs(fromChainID, txid, logIndex, txHash)
+	// recheck chain lease before update db
+	err = checkChainLease(res.ToChainID, args.ChainLease)
+	if err != nil {
+		logWorkerError("replaceSwap", "check chain lease failed", err, "fromChainID", fromChainID, "toChainID", res.ToChainID, "txid", txid, "nonce", res.SwapNonce, "logIndex", logIndex)
+		return
+	}
+	err = mongodb.UpdateRouterOldSwapTxs(fromChainID, txid, logIndex, txHash, args.ChainLease)
 	if err != nil {
 		return
 	}
+	recordSignedTx(resBridge, signedTx, txHash, args)
 
+	sendSpan := startSwapSpan("send", args, "txHash", txHash, "swapNonce", res.SwapNonce, "replace", true)
 	sentTxHash, err := sendSignedTransaction(resBridge, signedTx, args)
+	sendSpan.End(err)
 	if err == nil && txHash != sentTxHash {
 		logWorkerError("replaceSwap", "send tx success but with different hash", errSendTxWithDiffHash,
 			"fromChainID", fromChainID, "toChainID", res.ToChainID, "txid", txid, "nonce", res.SwapNonce,
 			"logIndex", logIndex, "txHash", txHash, "sentTxHash", sentTxHash)
-		_ = mongodb.UpdateRouterOldSwapTxs(fromChainID, txid, logIndex, sentTxHash)
+		_ = mongodb.UpdateRouterOldSwapTxs(fromChainID, txid, logIndex, sentTxHash, args.ChainLease)
+	}
+}
+
+// CancelRouterSwap cancel pending swap tx by sending zero value to self with the same nonce
+func CancelRouterSwap(res *mongodb.MgoSwapResult, reason string, isManual bool) (err error) {
+	if !isManual && !params.GetReplaceStrategy(res.ToChainID).EnableCancelTx {
+		return errors.New("cancel swap tx is disabled")
+	}
+	swap, err := verifyReplaceSwap(res, isManual)
+	if err != nil {
+		return err
+	}
+	if res.SwapTx == "" || res.SwapNonce == 0 || res.Status != mongodb.MatchTxNotStable {
+		return errors.New("no pending swap tx to cancel")
+	}
+
+	resBridge := router.GetBridgeByChainID(res.ToChainID)
+	if resBridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	canceler, ok := resBridge.(tokens.TxCanceler)
+	if !ok {
+		return tokens.ErrNotImplemented
+	}
+
+	err = beginSwapTask(res.ToChainID, isManual)
+	if err != nil {
+		return err
+	}
+	defer endSwapTask(res.ToChainID)
+
+	biFromChainID, biToChainID, _, err := getFromToChainIDAndValue(res.FromChainID, res.ToChainID, res.Value)
+	if err != nil {
+		return err
+	}
+
+	chainLease := getChainLease(res.ToChainID)
+	err = checkChainLease(res.ToChainID, chainLease)
+	if err != nil {
+		return err
+	}
+
+	logWorker("cancelSwap", "process task", "swap", res, "reason", reason)
+
+	nonce := res.SwapNonce
+	args := &tokens.BuildTxArgs{
+		SwapArgs: tokens.SwapArgs{
+			Identifier:  params.GetSwapTypeIdentifier(tokens.SwapType(res.SwapType).String()),
+			SwapID:      res.TxID,
+			SwapType:    tokens.SwapType(res.SwapType),
+			Bind:        res.Bind,
+			LogIndex:    res.LogIndex,
+			FromChainID: biFromChainID,
+			ToChainID:   biToChainID,
+			TraceID:     tokens.GetTraceIDOfSwapKey(res.Key),
+		},
+		From: res.MPC,
+		Extra: &tokens.AllExtras{
+			EthExtra: &tokens.EthExtraArgs{
+				Nonce: &nonce,
+			},
+			Sequence:   &nonce,
+			ReplaceNum: uint64(len(res.OldSwapTxs)) + 1,
+			IsCancel:   true,
+		},
+		ChainLease: chainLease,
+	}
+	args.SwapInfo, err = mongodb.ConvertFromSwapInfo(&swap.SwapInfo)
+	if err != nil {
+		return err
+	}
+	span := startSwapSpan("cancel", args, "nonce", nonce, "reason", reason, "manual", isManual)
+	defer func() { span.End(err) }()
+	rawTx, err := canceler.BuildCancelTransaction(args)
+	if err != nil {
+		logWorkerError("cancelSwap", "build cancel tx failed", err, "chainID", res.ToChainID, "txid", res.TxID, "logIndex", res.LogIndex)
+		recordReplaceDecision(res, args, mongodb.ReplaceActionCancel, isManual, "", reason, err)
+		return err
+	}
+	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
+	if err != nil {
+		logWorkerError("cancelSwap", "mpc sign cancel tx failed", err, "chainID", res.ToChainID, "txid", res.TxID, "logIndex", res.LogIndex)
+		recordReplaceDecision(res, args, mongodb.ReplaceActionCancel, isManual, "", reason, err)
+		return err
+	}
+	recordReplaceDecision(res, args, mongodb.ReplaceActionCancel, isManual, txHash, reason, nil)
+
+	// recheck chain lease before send cancel tx
+	err = checkChainLease(res.ToChainID, args.ChainLease)
+	if err != nil {
+		return err
+	}
+	recordSignedTx(resBridge, signedTx, txHash, args)
+
+	// do not record cancel tx as swap tx, as it is not a swap
+	_, err = sendSignedTransaction(resBridge, signedTx, args)
+	if err != nil {
+		return err
+	}
+	return mongodb.UpdateRouterSwapResultCanceled(res.FromChainID, res.TxID, res.LogIndex, txHash, args.ChainLease)
+}
+
+func recordReplaceDecision(res *mongodb.MgoSwapResult, args *tokens.BuildTxArgs, action string, isManual bool, newSwapTx, reason string, err error) {
+	strategy := params.GetReplaceStrategy(res.ToChainID)
+	md := &mongodb.MgoReplaceDecision{
+		FromChainID: res.FromChainID,
+		ToChainID:   res.ToChainID,
+		TxID:        res.TxID,
+		LogIndex:    res.LogIndex,
+		Action:      action,
+		IsManual:    isManual,
+		SwapNonce:   res.SwapNonce,
+		OldSwapTx:   res.SwapTx,
+		NewSwapTx:   newSwapTx,
+		Reason:      reason,
+		Timestamp:   now(),
+	}
+	if strategy != nil {
+		md.Mode = strategy.Mode
+	}
+	if err != nil {
+		md.Error = err.Error()
+	}
+	if args != nil {
+		md.ReplaceNum = args.GetReplaceNum()
+		if strategy != nil {
+			md.PlusPercent = strategy.GetReplacePlusPercent(md.ReplaceNum)
+		}
+		if args.Extra != nil && args.Extra.EthExtra != nil {
+			extra := args.Extra.EthExtra
+			if extra.GasPrice != nil {
+				md.GasPrice = extra.GasPrice.String()
+			}
+			if extra.GasTipCap != nil {
+				md.GasTipCap = extra.GasTipCap.String()
+			}
+			if extra.GasFeeCap != nil {
+				md.GasFeeCap = extra.GasFeeCap.String()
+			}
+		}
 	}
+	_ = mongodb.AddReplaceDecision(md)
 }
 
 func verifyReplaceSwap(res *mongodb.MgoSwapResult, isManual bool) (*mongodb.MgoSwap, error) {
@@ -310,7 +520,7 @@ func verifyReplaceSwap(res *mongodb.MgoSwapResult, isManual bool) (*mongodb.MgoS
 	if res.SwapNonce == 0 && !isManual {
 		return nil, errors.New("swap nonce is zero")
 	}
-	if res.Status != mongodb.MatchTxNotStable {
+	if res.Status != mongodb.MatchTxNotStable && res.Status != mongodb.RefundTxNotStable {
 		return nil, errors.New("swap result status is not 'MatchTxNotStable'")
 	}
 	if res.SwapHeight != 0 && !isManual {
@@ -0,0 +1,138 @@
+package worker
+
+import (
+	"sync/atomic"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router/bridge"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	defReverifyHorizon      = int64(7 * 24 * 3600) // seconds
+	defReverifyBaseInterval = int64(600)           // seconds
+	defReverifyMaxInterval  = int64(24 * 3600)     // seconds
+
+	// set after router config is reloaded to retry config related failures at once
+	isRouterConfigReloaded int32
+)
+
+// StartReverifyJob reverify job
+func StartReverifyJob() {
+	cfg := params.GetRouterServerConfig()
+	if cfg == nil || !cfg.EnableReverifySwap {
+		logWorker("reverify", "stop reverify swap job as disabled")
+		return
+	}
+	bridge.AddAfterReloadCallback(func() {
+		atomic.StoreInt32(&isRouterConfigReloaded, 1)
+	})
+	mongodb.MgoWaitGroup.Add(1)
+	go startReverifyJob()
+}
+
+func startReverifyJob() {
+	defer mongodb.MgoWaitGroup.Done()
+	logWorker("reverify", "start router swap reverify job")
+	for {
+		if atomic.CompareAndSwapInt32(&isRouterConfigReloaded, 1, 0) {
+			err := mongodb.ResetNextReverifyTimeOfStatus([]mongodb.SwapStatus{mongodb.MissTokenConfig, mongodb.NoUnderlyingToken})
+			if err != nil {
+				logWorkerError("reverify", "reset reverify time after reload config failed", err)
+			}
+		}
+		septime := getSepTimeInFind(getReverifyHorizon())
+		res, err := mongodb.FindRouterSwapsToReverify(septime, now())
+		if err != nil {
+			logWorkerError("reverify", "find router swap error", err)
+		}
+		for _, swap := range res {
+			if utils.IsCleanuping() {
+				logWorker("reverify", "stop router swap reverify job")
+				return
+			}
+			ctx := []interface{}{"fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex, "status", swap.Status, "reverifycount", swap.ReverifyCount}
+			if !isReverifiable(swap) {
+				// check again after the maximum interval in case of code changes
+				_ = mongodb.UpdateRouterSwapNextReverifyTime(swap.FromChainID, swap.TxID, swap.LogIndex, now()+getReverifyMaxInterval())
+				continue
+			}
+			err = reverifyRouterSwap(swap)
+			if err != nil {
+				logWorkerError("reverify", "reverify router swap failed", err, ctx...)
+			} else {
+				logWorker("reverify", "reverify router swap success", ctx...)
+			}
+		}
+		if utils.IsCleanuping() {
+			logWorker("reverify", "stop router swap reverify job")
+			return
+		}
+		restInJob(restIntervalInReverifyJob)
+	}
+}
+
+// isReverifiable config related failures are always retried,
+// while verify failures are only retried if the error is retryable (eg. rpc error)
+func isReverifiable(swap *mongodb.MgoSwap) bool {
+	switch swap.Status {
+	case mongodb.MissTokenConfig, mongodb.NoUnderlyingToken:
+		return true
+	case mongodb.TxVerifyFailed:
+		errCode := tokens.GetErrorCodeInfo(swap.ErrCode)
+		return errCode != nil && errCode.Retryable && errCode.Code != tokens.ErrCodeUnknown
+	default:
+		return false
+	}
+}
+
+func reverifyRouterSwap(swap *mongodb.MgoSwap) error {
+	nextTime := now() + getReverifyInterval(swap.ReverifyCount)
+	err := mongodb.ResetRouterSwapToReverify(swap.FromChainID, swap.TxID, swap.LogIndex, nextTime)
+	if err != nil {
+		return err
+	}
+	DeleteCachedVerifyingSwap(swap.Key)
+	return nil
+}
+
+// ReverifyRouterSwap reverify verify failed swap (by admin)
+func ReverifyRouterSwap(fromChainID, txid string, logIndex int) error {
+	swap, err := mongodb.FindRouterSwap(fromChainID, txid, logIndex)
+	if err != nil {
+		return err
+	}
+	return reverifyRouterSwap(swap)
+}
+
+// getReverifyInterval exponential backoff of reverify interval
+func getReverifyInterval(reverifyCount int) int64 {
+	interval := defReverifyBaseInterval
+	maxInterval := getReverifyMaxInterval()
+	if cfg := params.GetRouterServerConfig(); cfg != nil && cfg.ReverifyBaseInterval > 0 {
+		interval = cfg.ReverifyBaseInterval
+	}
+	for i := 0; i < reverifyCount && interval < maxInterval; i++ {
+		interval *= 2
+	}
+	if interval > maxInterval {
+		interval = maxInterval
+	}
+	return interval
+}
+
+func getReverifyMaxInterval() int64 {
+	if cfg := params.GetRouterServerConfig(); cfg != nil && cfg.ReverifyMaxInterval > 0 {
+		return cfg.ReverifyMaxInterval
+	}
+	return defReverifyMaxInterval
+}
+
+func getReverifyHorizon() int64 {
+	if cfg := params.GetRouterServerConfig(); cfg != nil && cfg.ReverifyHorizon > 0 {
+		return cfg.ReverifyHorizon
+	}
+	return defReverifyHorizon
+}
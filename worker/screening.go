@@ -0,0 +1,51 @@
+package worker
+
+import (
+	"errors"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/screening"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+// screenRouterSwap screen the addresses related to the swap.
+// the swap is set to screening review status if any address is flagged.
+// screening service error is ignored unless it's configed as fail closed.
+func screenRouterSwap(swap *mongodb.MgoSwap) error {
+	if swap.ScreeningApproved || !screening.IsEnabled() {
+		return nil
+	}
+	err := screening.ScreenAddresses(swap.FromChainID, swap.From, swap.TxTo)
+	if err == nil {
+		err = screening.ScreenAddresses(swap.ToChainID, swap.Bind)
+	}
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, tokens.ErrSwapFlaggedByScreen):
+		logWorkerWarn("screening", "swap is flagged by address screening", "fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex, "err", err)
+		dbErr := mongodb.UpdateRouterSwapStatus(swap.FromChainID, swap.TxID, swap.LogIndex, mongodb.SwapInScreeningReview, now(), err)
+		if dbErr != nil {
+			logWorkerError("screening", "update screening review status failed", dbErr, "fromChainID", swap.FromChainID, "txid", swap.TxID, "logIndex", swap.LogIndex)
+		}
+		return err
+	case screening.IsFailClosed():
+		return err
+	default:
+		logWorkerWarn("screening", "ignore address screening error", "fromChainID", swap.FromChainID, "txid", swap.TxID, "logIndex", swap.LogIndex, "err", err)
+		return nil
+	}
+}
+
+// screenSwapBeforeSign screen the swap again right before building and signing,
+// as the swap may wait long in the priority queue after it is dispatched.
+func screenSwapBeforeSign(args *tokens.BuildTxArgs) error {
+	if args.Refund || !screening.IsEnabled() {
+		return nil
+	}
+	swap, err := mongodb.FindRouterSwap(args.FromChainID.String(), args.SwapID, args.LogIndex)
+	if err != nil {
+		return err
+	}
+	return screenRouterSwap(swap)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 	"github.com/anyswap/CrossChain-Router/v3/tools/fifo"
+	"github.com/anyswap/CrossChain-Router/v3/tracing"
 	mapset "github.com/deckarep/golang-set"
 )
 
@@ -64,6 +65,10 @@ func startStableProducer() {
 				continue
 			}
 
+			if !IsChainLeaseOwner(swap.ToChainID) {
+				continue
+			}
+
 			err = dispatchSwapResultToStable(swap)
 			if err != nil {
 				logWorkerError("stable", "process router swap stable error", err, "chainID", swap.FromChainID, "txid", swap.TxID, "logIndex", swap.LogIndex, "toChainID", swap.ToChainID)
@@ -79,7 +84,28 @@ func startStableProducer() {
 
 func findRouterSwapResultsToStable() ([]*mongodb.MgoSwapResult, error) {
 	septime := getSepTimeInFind(maxStableLifetime)
-	return mongodb.FindRouterSwapResultsWithStatus(mongodb.MatchTxNotStable, septime)
+	res, err := findRouterSwapResultsWithNotStableStatus(septime)
+	if err != nil {
+		return res, err
+	}
+	canceled, err := mongodb.FindRouterSwapResultsWithStatus(mongodb.MatchTxCanceled, septime)
+	if err != nil {
+		return res, err
+	}
+	return append(res, canceled...), nil
+}
+
+// findRouterSwapResultsWithNotStableStatus find not stable swap and refund results
+func findRouterSwapResultsWithNotStableStatus(septime int64) ([]*mongodb.MgoSwapResult, error) {
+	res, err := mongodb.FindRouterSwapResultsWithStatus(mongodb.MatchTxNotStable, septime)
+	if err != nil {
+		return nil, err
+	}
+	refunds, err := mongodb.FindRouterSwapResultsWithStatus(mongodb.RefundTxNotStable, septime)
+	if err != nil {
+		return res, err
+	}
+	return append(res, refunds...), nil
 }
 
 func isTxOnChain(txStatus *tokens.TxStatus) bool {
@@ -156,6 +182,12 @@ func startStableConsumer(chainID string) {
 			continue
 		}
 
+		if !IsChainLeaseOwner(chainID) {
+			logWorkerTrace("doStable", "ignore stable task as chain lease is lost", "chainID", chainID, "key", swap.Key)
+			stableTasksInQueue.Remove(swap.Key)
+			continue
+		}
+
 		ctx := []interface{}{"fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex}
 		err := processRouterSwapStable(swap)
 		if err == nil {
@@ -174,6 +206,9 @@ func processRouterSwapStable(swap *mongodb.MgoSwapResult) (err error) {
 	if resBridge == nil {
 		return tokens.ErrNoBridgeForChainID
 	}
+	if swap.Status == mongodb.MatchTxCanceled {
+		return processRouterSwapCanceled(resBridge, swap)
+	}
 	txStatus := getSwapTxStatus(resBridge, swap)
 	if txStatus == nil || txStatus.BlockHeight == 0 {
 		if swap.SwapHeight != 0 {
@@ -186,24 +221,60 @@ func processRouterSwapStable(swap *mongodb.MgoSwapResult) (err error) {
 		if txStatus.Confirmations < resBridge.GetChainConfig().Confirmations {
 			return nil
 		}
+		span := startStableSpan(swap, "stable", txStatus)
+		defer func() { span.End(err) }()
 		if swap.SwapTx != oldSwapTx {
-			_ = updateSwapTx(swap.FromChainID, swap.TxID, swap.LogIndex, swap.SwapTx)
+			_ = updateSwapTx(swap.FromChainID, swap.TxID, swap.LogIndex, swap.SwapTx, getChainLease(swap.ToChainID))
 		}
+		recordAnyCallFee(resBridge, swap, txStatus)
 		if txStatus.IsSwapTxOnChainAndFailed() {
 			logWorker("stable", "mark swap result onchain failed",
 				"fromChainID", swap.FromChainID, "txid", swap.TxID, "logIndex", swap.LogIndex,
 				"swaptime", swap.Timestamp, "nowtime", now())
-			return markSwapResultFailed(swap.FromChainID, swap.TxID, swap.LogIndex)
+			err = markSwapResultFailed(swap.FromChainID, swap.TxID, swap.LogIndex)
+			if err == nil {
+				processAnyCallFailure(resBridge, swap, txStatus)
+			}
+			return err
 		}
 		return markSwapResultStable(swap.FromChainID, swap.TxID, swap.LogIndex)
 	}
 
+	span := startStableSpan(swap, "onchain", txStatus)
+	defer func() { span.End(err) }()
+
 	matchTx := &MatchTx{
 		SwapHeight: txStatus.BlockHeight,
 		SwapTime:   txStatus.BlockTime,
+		Lease:      getChainLease(swap.ToChainID),
 	}
 	if swap.SwapTx != oldSwapTx {
 		matchTx.SwapTx = swap.SwapTx
 	}
 	return updateRouterSwapResult(swap.FromChainID, swap.TxID, swap.LogIndex, matchTx)
 }
+
+// canceled swap result keeps its status unless one of its swap txs
+// is packed instead of the cancel tx, then it is processed as usual.
+func processRouterSwapCanceled(resBridge tokens.IBridge, swap *mongodb.MgoSwapResult) error {
+	if swap.CancelTx != "" {
+		cancelStatus, err := resBridge.GetTransactionStatus(swap.CancelTx)
+		if err == nil && isTxOnChain(cancelStatus) {
+			return nil
+		}
+	}
+	txStatus := getSwapTxStatus(resBridge, swap)
+	if txStatus == nil || txStatus.BlockHeight == 0 {
+		return nil
+	}
+	logWorker("stable", "canceled swap tx is on chain",
+		"fromChainID", swap.FromChainID, "txid", swap.TxID, "logIndex", swap.LogIndex,
+		"swaptx", swap.SwapTx, "canceltx", swap.CancelTx)
+	return mongodb.UpdateRouterSwapResultStatus(swap.FromChainID, swap.TxID, swap.LogIndex, mongodb.MatchTxNotStable, now(), nil)
+}
+
+func startStableSpan(swap *mongodb.MgoSwapResult, name string, txStatus *tokens.TxStatus) *tracing.Span {
+	return tracing.StartSpan(tokens.GetTraceIDOfSwapKey(swap.Key), name,
+		"fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex,
+		"swapTx", swap.SwapTx, "blockHeight", txStatus.BlockHeight, "confirmations", txStatus.Confirmations)
+}
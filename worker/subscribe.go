@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+// StartSubscribeSwapJob subscribe swap txs and register them instantly
+func StartSubscribeSwapJob() {
+	router.RouterBridges.Range(func(k, v interface{}) bool {
+		chainID := k.(string)
+		subscriber, ok := v.(tokens.SwapTxSubscriber)
+		if !ok || !subscriber.IsSwapTxSubscribeEnabled() {
+			return true
+		}
+
+		mongodb.MgoWaitGroup.Add(1)
+		go startSubscribeSwapJob(chainID, subscriber)
+
+		return true
+	})
+}
+
+func startSubscribeSwapJob(chainID string, subscriber tokens.SwapTxSubscriber) {
+	defer mongodb.MgoWaitGroup.Done()
+	logWorker("subscribe", "start subscribe swap job", "chainID", chainID)
+	for {
+		err := subscriber.SubscribeSwapTxs(utils.CleanupChan, func(txHash string) {
+			registerSubscribedSwap(chainID, txHash)
+		})
+		if utils.IsCleanuping() {
+			logWorker("subscribe", "stop subscribe swap job", "chainID", chainID)
+			return
+		}
+		logWorkerError("subscribe", "subscribe swap txs failed, resubscribe later", err, "chainID", chainID)
+		restInJob(restIntervalInSubscribeJob)
+	}
+}
+
+func registerSubscribedSwap(chainID, txHash string) {
+	bridge := router.GetBridgeByChainID(chainID)
+	if bridge == nil {
+		return
+	}
+	if _, registeredOk := mongodb.GetRegisteredRouterSwap(chainID, txHash, 0); registeredOk {
+		logWorkerTrace("subscribe", "ignore registered swap", "chainID", chainID, "txid", txHash)
+		return
+	}
+	for _, swapType := range tokens.GetRouterSwapTypes() {
+		registerArgs := &tokens.RegisterArgs{
+			SwapType: swapType,
+		}
+		swapInfos, errs := bridge.RegisterSwap(txHash, registerArgs)
+		for i, swapInfo := range swapInfos {
+			verifyErr := errs[i]
+			if !tokens.ShouldRegisterRouterSwapForError(verifyErr) {
+				logWorkerTrace("subscribe", "ignore swap with verify error", "chainID", chainID, "txid", txHash, "swapType", swapType.String(), "err", verifyErr)
+				continue
+			}
+			if _, registeredOk := mongodb.GetRegisteredRouterSwap(chainID, txHash, swapInfo.LogIndex); registeredOk {
+				continue
+			}
+			newStatus := mongodb.GetRouterSwapStatusByVerifyError(verifyErr)
+			if err := AddInitialSwap(swapInfo, newStatus, verifyErr); err == nil {
+				logWorker("subscribe", "register subscribed swap success", "chainID", chainID, "txid", txHash, "logIndex", swapInfo.LogIndex, "status", newStatus)
+			}
+		}
+	}
+}
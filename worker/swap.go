@@ -28,9 +28,13 @@ var (
 	cachedSwapTasks    = mapset.NewSet()
 	maxCachedSwapTasks = 1000
 
-	swapTaskQueues   = make(map[string]*fifo.Queue) // key is toChainID
+	swapTaskQueues   = make(map[string]*fifo.PriorityQueue) // key is swapType:toChainID
 	swapTasksInQueue = mapset.NewSet()
 
+	// swap types share the nonce of the same mpc on the same chain
+	mpcSwapLocks     = make(map[string]*sync.Mutex) // key is toChainID:mpc
+	mpcSwapLocksLock sync.Mutex
+
 	errAlreadySwapped     = errors.New("already swapped")
 	errSendTxWithDiffHash = errors.New("send tx with different hash")
 	errChainIsPaused      = errors.New("from or to chain is paused")
@@ -39,23 +43,27 @@ var (
 // StartSwapJob swap job
 func StartSwapJob() {
 	// init all swap task queue
-	router.RouterBridges.Range(func(k, v interface{}) bool {
-		chainID := k.(string)
-		if _, exist := swapTaskQueues[chainID]; !exist {
-			swapTaskQueues[chainID] = fifo.NewQueue()
-		}
-		return true
-	})
+	for _, swapType := range tokens.GetRouterSwapTypes() {
+		router.RouterBridges.Range(func(k, v interface{}) bool {
+			queueKey := getSwapTaskQueueKey(swapType, k.(string))
+			if _, exist := swapTaskQueues[queueKey]; !exist {
+				swapTaskQueues[queueKey] = newSwapTaskQueue()
+			}
+			return true
+		})
+	}
 
 	// start comsumers
-	router.RouterBridges.Range(func(k, v interface{}) bool {
-		chainID := k.(string)
+	for _, swapType := range tokens.GetRouterSwapTypes() {
+		router.RouterBridges.Range(func(k, v interface{}) bool {
+			chainID := k.(string)
 
-		mongodb.MgoWaitGroup.Add(1)
-		go startSwapConsumer(chainID)
+			mongodb.MgoWaitGroup.Add(1)
+			go startSwapConsumer(swapType, chainID)
 
-		return true
-	})
+			return true
+		})
+	}
 
 	// start producer
 	go startSwapProducer()
@@ -88,6 +96,15 @@ func startSwapProducer() {
 				continue
 			}
 
+			if !IsChainLeaseOwner(swap.ToChainID) {
+				continue
+			}
+
+			if IsChainDraining(swap.ToChainID) {
+				logWorkerTrace("swap", "ignore swap as chain is draining", "key", swap.Key)
+				continue
+			}
+
 			err = processRouterSwap(swap)
 			ctx := []interface{}{"fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex}
 			switch {
@@ -133,14 +150,14 @@ func processRouterSwap(swap *mongodb.MgoSwap) (err error) {
 		logWorkerTrace("swap", "swap is in black list", "txid", txid, "logIndex", logIndex,
 			"fromChainID", fromChainID, "toChainID", toChainID, "token", swap.GetToken(), "tokenID", swap.GetTokenID())
 		err = tokens.ErrSwapInBlacklist
-		_ = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.SwapInBlacklist, now(), err.Error())
+		_ = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.SwapInBlacklist, now(), err)
 		return nil
 	}
 
 	res, err := mongodb.FindRouterSwapResult(fromChainID, txid, logIndex)
 	if err != nil {
 		if errors.Is(err, mongodb.ErrItemNotFound) {
-			_ = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxNotStable, now(), "")
+			_ = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxNotStable, now(), nil)
 		}
 		return err
 	}
@@ -161,6 +178,23 @@ func processRouterSwap(swap *mongodb.MgoSwap) (err error) {
 		return err
 	}
 
+	err = checkAnyCallBudget(dstBridge, swap)
+	if err != nil {
+		return err
+	}
+
+	err = checkAnyCallFallbackSwap(swap)
+	if err != nil {
+		return err
+	}
+
+	// screen again as the screening list may be updated since verify,
+	// and it is screened once more before signing (see screenSwapBeforeSign)
+	err = screenRouterSwap(swap)
+	if err != nil {
+		return err
+	}
+
 	biFromChainID, biToChainID, biValue, err := getFromToChainIDAndValue(fromChainID, toChainID, res.Value)
 	if err != nil {
 		return err
@@ -173,7 +207,7 @@ func processRouterSwap(swap *mongodb.MgoSwap) (err error) {
 
 	args := &tokens.BuildTxArgs{
 		SwapArgs: tokens.SwapArgs{
-			Identifier:  params.GetIdentifier(),
+			Identifier:  params.GetSwapTypeIdentifier(tokens.SwapType(swap.SwapType).String()),
 			SwapID:      txid,
 			SwapType:    tokens.SwapType(swap.SwapType),
 			Bind:        bind,
@@ -181,6 +215,7 @@ func processRouterSwap(swap *mongodb.MgoSwap) (err error) {
 			FromChainID: biFromChainID,
 			ToChainID:   biToChainID,
 			Reswapping:  res.Status == mongodb.Reswapping,
+			TraceID:     tokens.GetTraceIDOfSwapKey(swap.Key),
 		},
 		From:        routerMPC,
 		OriginFrom:  swap.From,
@@ -191,8 +226,11 @@ func processRouterSwap(swap *mongodb.MgoSwap) (err error) {
 	if err != nil {
 		return err
 	}
+	if gasLimit := getAnyCallRetryGasLimit(res); gasLimit > 0 {
+		args.Extra = &tokens.AllExtras{EthExtra: &tokens.EthExtraArgs{Gas: &gasLimit}}
+	}
 
-	return dispatchSwapTask(args)
+	return dispatchSwapTask(args, swap.InitTime)
 }
 
 func getFromToChainIDAndValue(fromChainIDStr, toChainIDStr, valueStr string) (fromChainID, toChainID, value *big.Int, err error) {
@@ -224,18 +262,18 @@ func preventReswap(res *mongodb.MgoSwapResult) (err error) {
 
 func processNonEmptySwapResult(res *mongodb.MgoSwapResult) error {
 	if res.SwapNonce > 0 ||
-		!(res.Status == mongodb.MatchTxEmpty || res.Status == mongodb.Reswapping) ||
+		!(res.Status == mongodb.MatchTxEmpty || res.Status == mongodb.Reswapping || res.Status == mongodb.RefundTxEmpty) ||
 		res.SwapTx != "" ||
 		res.SwapHeight != 0 ||
 		len(res.OldSwapTxs) > 0 {
-		_ = mongodb.UpdateRouterSwapStatus(res.FromChainID, res.TxID, res.LogIndex, mongodb.TxProcessed, now(), "")
+		_ = mongodb.UpdateRouterSwapStatus(res.FromChainID, res.TxID, res.LogIndex, getProcessedStatus(res.Status.IsRefundStatus()), now(), nil)
 		return errAlreadySwapped
 	}
 	return nil
 }
 
 func processHistory(res *mongodb.MgoSwapResult) error {
-	if (res.Status == mongodb.MatchTxEmpty || res.Status == mongodb.Reswapping) && res.SwapNonce == 0 {
+	if (res.Status == mongodb.MatchTxEmpty || res.Status == mongodb.Reswapping || res.Status == mongodb.RefundTxEmpty) && res.SwapNonce == 0 {
 		return nil
 	}
 	chainID := res.FromChainID
@@ -245,24 +283,29 @@ func processHistory(res *mongodb.MgoSwapResult) error {
 	if history == nil {
 		return nil
 	}
-	_ = mongodb.UpdateRouterSwapStatus(chainID, txid, logIndex, mongodb.TxProcessed, now(), "")
+	_ = mongodb.UpdateRouterSwapStatus(chainID, txid, logIndex, getProcessedStatus(res.Status.IsRefundStatus()), now(), nil)
 	logWorker("swap", "ignore swapped router swap", "fromChainID", res.FromChainID, "toChainID", res.ToChainID, "txid", txid, "logIndex", logIndex, "matchTx", history.matchTx)
 	return errAlreadySwapped
 }
 
-func dispatchSwapTask(args *tokens.BuildTxArgs) error {
+func dispatchSwapTask(args *tokens.BuildTxArgs, initTime int64) error {
 	if !args.SwapType.IsValidType() {
 		return fmt.Errorf("unknown router swap type %d", args.SwapType)
 	}
+	if !tokens.IsSwapTypeEnabled(args.SwapType) {
+		return fmt.Errorf("router swap type %v is not enabled", args.SwapType.String())
+	}
 
-	taskQueue, exist := swapTaskQueues[args.ToChainID.String()]
+	taskQueue, exist := swapTaskQueues[getSwapTaskQueueKey(args.SwapType, args.ToChainID.String())]
 	if !exist {
-		return fmt.Errorf("no task queue for chainID '%v'", args.ToChainID)
+		return fmt.Errorf("no task queue for chainID '%v' and swap type '%v'", args.ToChainID, args.SwapType.String())
 	}
 
-	logWorker("doSwap", "dispatch router swap task", "fromChainID", args.FromChainID, "toChainID", args.ToChainID, "txid", args.SwapID, "logIndex", args.LogIndex, "value", args.OriginValue, "swapNonce", args.GetTxNonce(), "queue", taskQueue.Len())
+	class, group := getSwapPriority(args, initTime)
+
+	logWorker("doSwap", "dispatch router swap task", "fromChainID", args.FromChainID, "toChainID", args.ToChainID, "txid", args.SwapID, "logIndex", args.LogIndex, "value", args.OriginValue, "swapNonce", args.GetTxNonce(), "priority", swapPriorityClasses[class], "queue", taskQueue.Len())
 
-	taskQueue.Add(args)
+	taskQueue.Add(args, class, group)
 
 	cacheKey := mongodb.GetRouterSwapKey(args.FromChainID.String(), args.SwapID, args.LogIndex)
 	swapTasksInQueue.Add(cacheKey)
@@ -270,13 +313,29 @@ func dispatchSwapTask(args *tokens.BuildTxArgs) error {
 	return nil
 }
 
-func startSwapConsumer(chainID string) {
+func getSwapTaskQueueKey(swapType tokens.SwapType, chainID string) string {
+	return swapType.String() + ":" + chainID
+}
+
+func getMPCSwapLock(chainID, mpcAddress string) *sync.Mutex {
+	key := strings.ToLower(chainID + ":" + mpcAddress)
+	mpcSwapLocksLock.Lock()
+	defer mpcSwapLocksLock.Unlock()
+	lock, exist := mpcSwapLocks[key]
+	if !exist {
+		lock = new(sync.Mutex)
+		mpcSwapLocks[key] = lock
+	}
+	return lock
+}
+
+func startSwapConsumer(swapType tokens.SwapType, chainID string) {
 	defer mongodb.MgoWaitGroup.Done()
-	logWorker("doSwap", "start process swap task", "chainID", chainID)
+	logWorker("doSwap", "start process swap task", "chainID", chainID, "swapType", swapType.String())
 
-	taskQueue, exist := swapTaskQueues[chainID]
+	taskQueue, exist := swapTaskQueues[getSwapTaskQueueKey(swapType, chainID)]
 	if !exist {
-		log.Fatal("no task queue", "chainID", chainID)
+		log.Fatal("no task queue", "chainID", chainID, "swapType", swapType.String())
 	}
 
 	i := 0
@@ -303,6 +362,18 @@ func startSwapConsumer(chainID string) {
 			logWorkerWarn("doSwap", "ignore swap task as toChainID mismatch", "want", chainID, "args", args)
 			continue
 		}
+
+		cacheKey := mongodb.GetRouterSwapKey(args.FromChainID.String(), args.SwapID, args.LogIndex)
+		if !IsChainLeaseOwner(chainID) {
+			logWorkerTrace("doSwap", "ignore swap task as chain lease is lost", "chainID", chainID, "key", cacheKey)
+			swapTasksInQueue.Remove(cacheKey)
+			continue
+		}
+		if IsChainDraining(chainID) {
+			logWorkerTrace("doSwap", "ignore swap task as chain is draining", "chainID", chainID, "key", cacheKey)
+			swapTasksInQueue.Remove(cacheKey)
+			continue
+		}
 		logWorker("doSwap", "process router swap start", "args", args)
 		ctx := []interface{}{"fromChainID", args.FromChainID, "toChainID", args.ToChainID, "txid", args.SwapID, "logIndex", args.LogIndex}
 		err := doSwap(args)
@@ -310,6 +381,7 @@ func startSwapConsumer(chainID string) {
 		case err == nil:
 			logWorker("doSwap", "process router swap success", ctx...)
 		case errors.Is(err, errAlreadySwapped),
+			errors.Is(err, errServerDraining),
 			errors.Is(err, tokens.ErrNoBridgeForChainID):
 			ctx = append(ctx, "err", err)
 			logWorkerTrace("doSwap", "process router swap failed", ctx...)
@@ -317,7 +389,6 @@ func startSwapConsumer(chainID string) {
 			logWorkerError("doSwap", "process router swap failed", err, ctx...)
 		}
 
-		cacheKey := mongodb.GetRouterSwapKey(args.FromChainID.String(), args.SwapID, args.LogIndex)
 		swapTasksInQueue.Remove(cacheKey)
 	}
 }
@@ -360,24 +431,61 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	}()
 
 	logWorker("doSwap", "start to process", "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "value", originValue)
+	span := startSwapSpan("swap", args, "value", originValue)
+	defer func() { span.End(err) }()
 
 	resBridge := router.GetBridgeByChainID(toChainID)
 	if resBridge == nil {
 		return tokens.ErrNoBridgeForChainID
 	}
 
-	rawTx, err := resBridge.BuildRawTransaction(args)
+	err = beginSwapTask(toChainID, false)
+	if err != nil {
+		return err
+	}
+	defer endSwapTask(toChainID)
+
+	args.ChainLease = getChainLease(toChainID)
+	err = checkChainLease(toChainID, args.ChainLease)
+	if err != nil {
+		return err
+	}
+
+	// scan swapins before locking, the check in lock only scans the new blocks
+	_, err = scanSwapIns(resBridge, args)
+	if err != nil {
+		return err
+	}
+
+	// serialize build, sign and send of all swap types with the same mpc
+	mpcSwapLock := getMPCSwapLock(toChainID, args.From)
+	mpcSwapLock.Lock()
+	defer mpcSwapLock.Unlock()
+
+	err = checkSwapInNotExist(resBridge, args)
+	if err != nil {
+		return err
+	}
+
+	err = screenSwapBeforeSign(args)
+	if err != nil {
+		return err
+	}
+
+	rawTx, err := buildRawTransaction(resBridge, args)
 	if err != nil {
 		logWorkerError("doSwap", "build tx failed", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex)
 		if errors.Is(err, tokens.ErrBuildTxErrorAndDelay) {
-			_ = updateSwapMemo(fromChainID, txid, logIndex, err.Error())
+			_ = updateSwapMemo(fromChainID, txid, logIndex, err)
 		}
 		return err
 	}
 	swapTxNonce := args.GetTxNonce() // assign after build tx
 	logWorker("doSwap", "build tx success", "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "swapNonce", swapTxNonce)
 
+	signSpan := startSwapSpan("sign", args, "swapNonce", swapTxNonce)
 	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
+	signSpan.End(err)
 	if err != nil {
 		logWorkerError("doSwap", "sign tx failed", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex)
 		if errors.Is(err, mpc.ErrGetSignStatusHasDisagree) {
@@ -387,7 +495,11 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	}
 	logWorker("doSwap", "sign tx success", "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "txHash", txHash, "swapNonce", swapTxNonce)
 
-	// recheck reswap before update db
+	// recheck chain lease and reswap before update db
+	err = checkChainLease(toChainID, args.ChainLease)
+	if err != nil {
+		return err
+	}
 	res, err := mongodb.FindRouterSwapResult(fromChainID, txid, logIndex)
 	if err != nil {
 		return err
@@ -399,10 +511,12 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 
 	// update database before sending transaction
 	addSwapHistory(fromChainID, txid, logIndex, txHash)
+	recordSignedTx(resBridge, signedTx, txHash, args)
 	matchTx := &MatchTx{
 		SwapTx:    txHash,
 		SwapNonce: swapTxNonce,
 		MPC:       args.From,
+		Lease:     args.ChainLease,
 	}
 	if args.SwapValue != nil {
 		matchTx.SwapValue = args.SwapValue.String()
@@ -414,18 +528,20 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	}
 	isCachedSwapProcessed = true
 
-	err = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxProcessed, now(), "")
+	err = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, getProcessedStatus(args.Refund), now(), nil)
 	if err != nil {
 		logWorkerError("doSwap", "update router swap status failed", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex)
 		return err
 	}
 
+	sendSpan := startSwapSpan("send", args, "txHash", txHash, "swapNonce", swapTxNonce)
 	sentTxHash, err := sendSignedTransaction(resBridge, signedTx, args)
+	sendSpan.End(err)
 	if err == nil && txHash != sentTxHash {
 		logWorkerError("doSwap", "send tx success but with different hash", errSendTxWithDiffHash,
 			"fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex,
 			"txHash", txHash, "sentTxHash", sentTxHash, "swapNonce", swapTxNonce)
-		_ = mongodb.UpdateRouterOldSwapTxs(fromChainID, txid, logIndex, sentTxHash)
+		_ = mongodb.UpdateRouterOldSwapTxs(fromChainID, txid, logIndex, sentTxHash, args.ChainLease)
 	} else if err == nil {
 		logWorker("doSwap", "send tx success",
 			"fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex,
@@ -457,23 +573,54 @@ func doSwapParallel(args *tokens.BuildTxArgs) (err error) {
 	}()
 
 	logWorker("doSwap", "start to process", "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "value", originValue)
+	span := startSwapSpan("swap", args, "value", originValue)
+	defer func() { span.End(err) }()
 
 	resBridge := router.GetBridgeByChainID(toChainID)
 	if resBridge == nil {
 		return tokens.ErrNoBridgeForChainID
 	}
 
-	rawTx, err := resBridge.BuildRawTransaction(args)
+	err = beginSwapTask(toChainID, false)
+	if err != nil {
+		return err
+	}
+	isSwapTaskEnded := false
+	defer func() {
+		if !isSwapTaskEnded {
+			endSwapTask(toChainID)
+		}
+	}()
+
+	args.ChainLease = getChainLease(toChainID)
+	err = checkChainLease(toChainID, args.ChainLease)
+	if err != nil {
+		return err
+	}
+
+	err = checkSwapInNotExist(resBridge, args)
+	if err != nil {
+		return err
+	}
+
+	err = screenSwapBeforeSign(args)
+	if err != nil {
+		return err
+	}
+
+	rawTx, err := buildRawTransaction(resBridge, args)
 	if err != nil {
 		logWorkerError("doSwap", "build tx failed", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex)
 		if errors.Is(err, tokens.ErrBuildTxErrorAndDelay) {
-			_ = updateSwapMemo(fromChainID, txid, logIndex, err.Error())
+			_ = updateSwapMemo(fromChainID, txid, logIndex, err)
 		}
 		return err
 	}
 
 	isCachedSwapProcessed = true
+	isSwapTaskEnded = true // end in sign and send routine
 	go func() {
+		defer endSwapTask(toChainID)
 		_ = signAndSendTx(rawTx, args)
 	}()
 	return nil
@@ -487,7 +634,9 @@ func signAndSendTx(rawTx interface{}, args *tokens.BuildTxArgs) error {
 	swapTxNonce := args.GetTxNonce()
 	resBridge := router.GetBridgeByChainID(toChainID)
 
+	signSpan := startSwapSpan("sign", args, "swapNonce", swapTxNonce)
 	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
+	signSpan.End(err)
 	if err != nil {
 		logWorkerError("doSwap", "sign tx failed", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "swapNonce", swapTxNonce)
 		if errors.Is(err, mpc.ErrGetSignStatusHasDisagree) {
@@ -496,16 +645,25 @@ func signAndSendTx(rawTx interface{}, args *tokens.BuildTxArgs) error {
 		return err
 	}
 
+	err = checkChainLease(toChainID, args.ChainLease)
+	if err != nil {
+		logWorkerError("doSwap", "check chain lease failed", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "swapNonce", swapTxNonce)
+		return err
+	}
+
 	// update database before sending transaction
 	addSwapHistory(fromChainID, txid, logIndex, txHash)
-	_ = updateSwapTx(fromChainID, txid, logIndex, txHash)
+	recordSignedTx(resBridge, signedTx, txHash, args)
+	_ = updateSwapTx(fromChainID, txid, logIndex, txHash, args.ChainLease)
 
+	sendSpan := startSwapSpan("send", args, "txHash", txHash, "swapNonce", swapTxNonce)
 	sentTxHash, err := sendSignedTransaction(resBridge, signedTx, args)
+	sendSpan.End(err)
 	if err == nil && txHash != sentTxHash {
 		logWorkerError("doSwap", "send tx success but with different hash", errSendTxWithDiffHash,
 			"fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex,
 			"txHash", txHash, "sentTxHash", sentTxHash, "swapNonce", swapTxNonce)
-		_ = mongodb.UpdateRouterOldSwapTxs(fromChainID, txid, logIndex, sentTxHash)
+		_ = mongodb.UpdateRouterOldSwapTxs(fromChainID, txid, logIndex, sentTxHash, args.ChainLease)
 	}
 	return err
 }
@@ -515,6 +673,9 @@ func reverifySwap(args *tokens.BuildTxArgs) {
 	toChainID := args.ToChainID.String()
 	txid := args.SwapID
 	logIndex := args.LogIndex
+	if args.Refund {
+		return // refund is verified to be unswappable already
+	}
 	verifyArgs := &tokens.VerifyArgs{
 		SwapType:      args.SwapType,
 		LogIndex:      logIndex,
@@ -532,8 +693,17 @@ func reverifySwap(args *tokens.BuildTxArgs) {
 		// ignore the above situations
 	default:
 		logWorkerWarn("doSwap", "reverify swap after get sign status has disagree", "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "err", err)
-		_ = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxNotStable, now(), "")
-		_ = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, mongodb.TxNotStable, now(), err.Error())
+		if params.GetReplaceStrategy(toChainID).EnableCancelTx {
+			res, errf := mongodb.FindRouterSwapResult(fromChainID, txid, logIndex)
+			if errf == nil && res.SwapTx != "" && res.SwapHeight == 0 {
+				errc := CancelRouterSwap(res, err.Error(), false)
+				if errc != nil {
+					logWorkerError("doSwap", "cancel invalid swap failed", errc, "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex)
+				}
+			}
+		}
+		_ = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxNotStable, now(), nil)
+		_ = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, mongodb.TxNotStable, now(), err)
 	}
 }
 
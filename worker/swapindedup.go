@@ -0,0 +1,329 @@
+package worker
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	swapInDedupAuditStarter sync.Once
+
+	swapInDedupCursorName = "swapindedup"
+
+	defSwapInDedupAuditInterval = int64(300) // seconds
+
+	// cached scanned swapins of swaps, key is toChainID:swapID
+	cachedSwapInScans   = new(sync.Map)
+	lastSwapInScanSweep int64
+
+	swapInScanCacheTTL      = int64(1800) // seconds
+	swapInScanSweepInterval = int64(60)   // seconds
+	swapInScanTimeTolerance = uint64(600) // seconds, tolerance of block time between chains
+)
+
+type swapInScan struct {
+	lock      sync.Mutex
+	records   []*tokens.SwapInRecord
+	scannedTo uint64
+	expireAt  int64
+}
+
+// checkSwapInNotExist refuse to sign swap tx if the swap is already swapped in on the destination chain.
+// this prevents paying a swap twice when more than one router deployments share the same mpc,
+// which can not be detected by 'preventReswap' as they do not share the same database.
+func checkSwapInNotExist(resBridge tokens.IBridge, args *tokens.BuildTxArgs) error {
+	// only scan the new blocks since the last scan
+	records, err := scanSwapIns(resBridge, args)
+	if err != nil {
+		return err
+	}
+	swapIns := 0
+	for _, record := range records {
+		if record.FromChainID != nil && record.FromChainID.Cmp(args.FromChainID) == 0 {
+			swapIns++
+		}
+	}
+	if swapIns == 0 {
+		return nil
+	}
+
+	fromChainID := args.FromChainID.String()
+	toChainID := args.ToChainID.String()
+
+	// a tx with multiple swapout logs has multiple swapins of the same swapID
+	swapped := 0
+	results, err := mongodb.FindRouterSwapResultsOfTxByKey(fromChainID, args.SwapID)
+	if err != nil {
+		return err
+	}
+	for _, res := range results {
+		if res.LogIndex != args.LogIndex && res.ToChainID == toChainID && res.SwapTx != "" {
+			swapped++
+		}
+	}
+	if swapIns <= swapped {
+		return nil
+	}
+
+	err = tokens.ErrSwapInAlreadyExist
+	logWorkerError("doSwap", "refuse to sign swap tx", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", args.SwapID, "logIndex", args.LogIndex, "swapIns", swapIns, "swapped", swapped)
+	_ = updateSwapMemo(fromChainID, args.SwapID, args.LogIndex, err)
+	return err
+}
+
+func getSwapInFinder(resBridge tokens.IBridge, args *tokens.BuildTxArgs) tokens.SwapInFinder {
+	if !params.IsSwapInDedupCheckEnabled() || args.Refund || args.SwapType != tokens.ERC20SwapType {
+		return nil
+	}
+	// only plain swapins emit 'LogAnySwapIn' log
+	if args.ERC20SwapInfo == nil || args.ERC20SwapInfo.CallProxy != "" || len(args.ERC20SwapInfo.Path) > 0 {
+		return nil
+	}
+	finder, _ := resBridge.(tokens.SwapInFinder)
+	return finder
+}
+
+// scanSwapIns scan swapins of the swap on the destination chain.
+// the scanned swapins are cached and the following scans only scan the new blocks,
+// so the heavy first scan can be done before taking the mpc swap lock.
+func scanSwapIns(resBridge tokens.IBridge, args *tokens.BuildTxArgs) ([]*tokens.SwapInRecord, error) {
+	finder := getSwapInFinder(resBridge, args)
+	if finder == nil {
+		return nil, nil
+	}
+	latest, err := resBridge.GetLatestBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	sweepSwapInScans()
+	key := strings.ToLower(args.ToChainID.String() + ":" + args.SwapID)
+	value, _ := cachedSwapInScans.LoadOrStore(key, &swapInScan{})
+	scan := value.(*swapInScan)
+	scan.lock.Lock()
+	defer scan.lock.Unlock()
+
+	start := scan.scannedTo + 1
+	if scan.scannedTo == 0 {
+		start = getSwapInScanStartBlock(finder, args, latest)
+	}
+	if start <= latest {
+		records, err := findSwapIns(finder, args.SwapID, start, latest)
+		if err != nil {
+			return nil, err
+		}
+		scan.records = append(scan.records, records...)
+		scan.scannedTo = latest
+	}
+	atomic.StoreInt64(&scan.expireAt, now()+swapInScanCacheTTL)
+	return scan.records, nil
+}
+
+// getSwapInScanStartBlock swapin must be after the source tx,
+// so start from the block at the source tx time, and bounded by the lookback config
+func getSwapInScanStartBlock(finder tokens.SwapInFinder, args *tokens.BuildTxArgs, latest uint64) uint64 {
+	toChainID := args.ToChainID.String()
+	var start uint64
+	if lookback := params.GetSwapInDedupLookbackBlocks(toChainID); latest > lookback {
+		start = latest - lookback
+	}
+	res, err := mongodb.FindRouterSwapResult(args.FromChainID.String(), args.SwapID, args.LogIndex)
+	if err != nil || res.TxTime <= swapInScanTimeTolerance {
+		return start
+	}
+	height, err := finder.GetBlockNumberByTime(res.TxTime-swapInScanTimeTolerance, start, latest)
+	if err != nil {
+		logWorkerWarn("doSwap", "get swapin scan start block failed", "toChainID", toChainID, "txid", args.SwapID, "txtime", res.TxTime, "err", err)
+		return start
+	}
+	return height
+}
+
+func sweepSwapInScans() {
+	nowTime := now()
+	lastSweep := atomic.LoadInt64(&lastSwapInScanSweep)
+	if lastSweep+swapInScanSweepInterval > nowTime ||
+		!atomic.CompareAndSwapInt64(&lastSwapInScanSweep, lastSweep, nowTime) {
+		return
+	}
+	cachedSwapInScans.Range(func(k, v interface{}) bool {
+		if expireAt := atomic.LoadInt64(&v.(*swapInScan).expireAt); expireAt > 0 && expireAt < nowTime {
+			cachedSwapInScans.Delete(k)
+		}
+		return true
+	})
+}
+
+// findSwapIns find swapins in block range [start, end] by splitting it into smaller ranges
+func findSwapIns(finder tokens.SwapInFinder, swapID string, start, end uint64) (records []*tokens.SwapInRecord, err error) {
+	step := params.GetSwapInDedupMaxBlocksPerQuery()
+	for from := start; from <= end; from += step {
+		to := from + step - 1
+		if to > end {
+			to = end
+		}
+		var result []*tokens.SwapInRecord
+		for i := 0; i < 3; i++ {
+			result, err = finder.FindSwapIns(swapID, from, to)
+			if err == nil {
+				break
+			}
+			time.Sleep(time.Second)
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, result...)
+	}
+	return records, nil
+}
+
+// StartSwapInDedupAuditJob audit duplicate swapins on destination chains
+func StartSwapInDedupAuditJob() {
+	if !params.IsSwapInDedupAuditEnabled() {
+		logWorker("swapindedup", "stop swapin dedup audit job as disabled")
+		return
+	}
+	swapInDedupAuditStarter.Do(func() {
+		mongodb.MgoWaitGroup.Add(1)
+		go startSwapInDedupAuditJob()
+	})
+}
+
+func startSwapInDedupAuditJob() {
+	defer mongodb.MgoWaitGroup.Done()
+	logWorker("swapindedup", "start swapin dedup audit job")
+	for {
+		router.RouterBridges.Range(func(k, v interface{}) bool {
+			if utils.IsCleanuping() {
+				return false
+			}
+			chainID := k.(string)
+			finder, ok := v.(tokens.SwapInFinder)
+			if !ok {
+				return true
+			}
+			err := auditSwapIns(chainID, v.(tokens.IBridge), finder)
+			if err != nil {
+				logWorkerError("swapindedup", "audit swapins failed", err, "chainID", chainID)
+			}
+			return true
+		})
+		if utils.IsCleanuping() {
+			logWorker("swapindedup", "stop swapin dedup audit job")
+			return
+		}
+		restInJob(getSwapInDedupAuditInterval())
+	}
+}
+
+func getSwapInDedupAuditInterval() time.Duration {
+	interval := params.GetSwapInDedupConfig().AuditInterval
+	if interval <= 0 {
+		interval = defSwapInDedupAuditInterval
+	}
+	return time.Duration(interval) * time.Second
+}
+
+func auditSwapIns(chainID string, bridge tokens.IBridge, finder tokens.SwapInFinder) error {
+	latest, err := bridge.GetLatestBlockNumber()
+	if err != nil {
+		return err
+	}
+	var start uint64
+	cursor, err := mongodb.FindSyncedCursor(chainID, swapInDedupCursorName)
+	switch {
+	case err == nil:
+		start = cursor.Cursor + 1
+	case errors.Is(err, mongodb.ErrItemNotFound):
+		if lookback := params.GetSwapInDedupLookbackBlocks(chainID); latest > lookback {
+			start = latest - lookback
+		}
+	default:
+		return err
+	}
+
+	step := params.GetSwapInDedupMaxBlocksPerQuery()
+	for i := 0; i < maxSwapInDedupAuditRounds && start <= latest; i++ {
+		if utils.IsCleanuping() {
+			return nil
+		}
+		end := start + step - 1
+		if end > latest {
+			end = latest
+		}
+		records, err := findSwapIns(finder, "", start, end)
+		if err != nil {
+			return err
+		}
+		// swap results of the swapins with the same swap ID are found only once
+		cachedResults := make(map[string][]*mongodb.MgoSwapResult)
+		for _, record := range records {
+			auditSwapIn(chainID, record, cachedResults)
+		}
+		err = mongodb.UpdateSyncedCursor(chainID, swapInDedupCursorName, end)
+		if err != nil {
+			return err
+		}
+		start = end + 1
+	}
+	return nil
+}
+
+// auditSwapIn alert if the swapin is not the swap tx recorded in database
+func auditSwapIn(chainID string, record *tokens.SwapInRecord, cachedResults map[string][]*mongodb.MgoSwapResult) {
+	if record.FromChainID == nil {
+		return
+	}
+	fromChainID := record.FromChainID.String()
+	ctx := []interface{}{"chainID", chainID, "fromChainID", fromChainID, "swapID", record.SwapID, "swapTx", record.TxHash, "height", record.BlockHeight, "token", record.Token, "receiver", record.Receiver, "amount", record.Amount}
+
+	cacheKey := strings.ToLower(fromChainID + ":" + record.SwapID)
+	results, exist := cachedResults[cacheKey]
+	if !exist {
+		var err error
+		results, err = findSwapResultsOfSwapIn(fromChainID, record.SwapID)
+		if err != nil {
+			logWorkerError("swapindedup", "find swap results failed", err, ctx...)
+			return
+		}
+		cachedResults[cacheKey] = results
+	}
+	if len(results) == 0 {
+		logWorkerWarn("swapindedup", "found swapin not in database", ctx...)
+		return
+	}
+	for _, res := range results {
+		if res.ToChainID != chainID {
+			continue
+		}
+		if strings.EqualFold(res.SwapTx, record.TxHash) {
+			return
+		}
+		for _, oldSwapTx := range res.OldSwapTxs {
+			if strings.EqualFold(oldSwapTx, record.TxHash) {
+				return
+			}
+		}
+	}
+	logWorkerError("swapindedup", "found duplicate swapin", tokens.ErrSwapInAlreadyExist, ctx...)
+}
+
+// findSwapResultsOfSwapIn the swap ID in swapin log is hex with '0x' prefix,
+// while the txid of non evm source chain is hex without '0x' prefix
+func findSwapResultsOfSwapIn(fromChainID, swapID string) ([]*mongodb.MgoSwapResult, error) {
+	results, err := mongodb.FindRouterSwapResultsOfTxByKey(fromChainID, swapID)
+	if err != nil || len(results) > 0 || !strings.HasPrefix(swapID, "0x") {
+		return results, err
+	}
+	return mongodb.FindRouterSwapResultsOfTxByKey(fromChainID, strings.TrimPrefix(swapID, "0x"))
+}
@@ -0,0 +1,235 @@
+package worker
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	tokenAuditStarter sync.Once
+)
+
+// StartTokenAuditJob token decimals and supply audit job
+func StartTokenAuditJob() {
+	if !params.IsTokenAuditEnabled() {
+		logWorker("tokenaudit", "stop token audit job as disabled")
+		return
+	}
+	tokenAuditStarter.Do(func() {
+		mongodb.MgoWaitGroup.Add(1)
+		go startTokenAuditJob()
+	})
+}
+
+func startTokenAuditJob() {
+	defer mongodb.MgoWaitGroup.Done()
+	logWorker("tokenaudit", "start token audit job")
+	for {
+		for _, tokenID := range router.AllTokenIDs {
+			if utils.IsCleanuping() {
+				break
+			}
+			if params.IsTokenAuditIgnored(tokenID) {
+				continue
+			}
+			auditToken(tokenID)
+		}
+		if utils.IsCleanuping() {
+			logWorker("tokenaudit", "stop token audit job")
+			return
+		}
+		restInJob(time.Duration(params.GetTokenAuditInterval()) * time.Second)
+	}
+}
+
+// auditToken check the configed decimals against the onchain decimals on every chain,
+// and reconcile the total supply of token on all chains with the locked underlying.
+// every token is backed by the underlying locked in the token contract of some chain,
+// so the total supply should not exceed the total locked underlying.
+func auditToken(tokenID string) {
+	report := &router.TokenAuditReport{
+		TokenID:   tokenID,
+		Timestamp: now(),
+	}
+
+	var (
+		isComplete  = true
+		hasLocked   bool
+		supplies    []*big.Int
+		locks       []*big.Int
+		decimalList []uint8
+	)
+
+	router.RouterBridges.Range(func(k, v interface{}) bool {
+		chainID := k.(string)
+		bridge := v.(tokens.IBridge)
+		multichainToken := router.GetCachedMultichainToken(tokenID, chainID)
+		if multichainToken == "" {
+			return true
+		}
+		tokenCfg := bridge.GetTokenConfig(multichainToken)
+		if tokenCfg == nil {
+			return true
+		}
+		auditor, ok := bridge.(tokens.TokenAuditor)
+		if !ok {
+			report.SkippedChainIDs = append(report.SkippedChainIDs, chainID)
+			isComplete = false
+			return true
+		}
+
+		decimalsAudit := auditTokenDecimals(auditor, chainID, tokenCfg)
+		report.Decimals = append(report.Decimals, decimalsAudit)
+		if decimalsAudit.IsMismatch {
+			discrepancy := fmt.Sprintf("decimals mismatch on chain %v: configed %v, onchain %v",
+				chainID, decimalsAudit.Decimals, decimalsAudit.OnchainDecimals)
+			if decimalsAudit.Underlying != "" {
+				discrepancy += fmt.Sprintf(", underlying %v", decimalsAudit.UnderlyingDecimals)
+			}
+			report.Discrepancies = append(report.Discrepancies, discrepancy)
+		}
+
+		supplyAudit, supply, locked := auditTokenSupply(auditor, chainID, tokenCfg)
+		report.Supplies = append(report.Supplies, supplyAudit)
+		if decimalsAudit.Error != "" || supplyAudit.Error != "" {
+			isComplete = false
+			return true
+		}
+		supplies = append(supplies, supply)
+		locks = append(locks, locked)
+		decimalList = append(decimalList, decimalsAudit.OnchainDecimals)
+		if locked != nil {
+			hasLocked = true
+		}
+		return true
+	})
+
+	sort.Strings(report.SkippedChainIDs)
+	sort.Slice(report.Decimals, func(i, j int) bool {
+		return report.Decimals[i].ChainID < report.Decimals[j].ChainID
+	})
+	sort.Slice(report.Supplies, func(i, j int) bool {
+		return report.Supplies[i].ChainID < report.Supplies[j].ChainID
+	})
+
+	// tokens without underlying are minted and burned on all chains, nothing to reconcile
+	if isComplete && hasLocked {
+		reconcileTokenSupply(report, supplies, locks, decimalList)
+	}
+
+	if report.HasDiscrepancy() {
+		logWorkerWarn("tokenaudit", "token audit alert: discrepancy found", "tokenID", tokenID, "discrepancies", report.Discrepancies)
+		if params.IsTokenAuditAutoPause() {
+			if !params.IsTokenIDInBlackList(tokenID) {
+				params.AddOrRemoveTokenIDBlackList([]string{tokenID}, true)
+				logWorkerWarn("tokenaudit", "pause token as discrepancy found", "tokenID", tokenID)
+			}
+			report.IsPaused = true
+		}
+	} else {
+		logWorker("tokenaudit", "audit token success", "tokenID", tokenID, "isReconciled", report.IsReconciled,
+			"totalSupply", report.TotalSupply, "totalLocked", report.TotalLocked, "decimals", report.NormalizedDecimals)
+	}
+	router.SetTokenAuditReport(report)
+}
+
+func auditTokenDecimals(auditor tokens.TokenAuditor, chainID string, tokenCfg *tokens.TokenConfig) *router.TokenDecimalsAudit {
+	audit := &router.TokenDecimalsAudit{
+		ChainID:    chainID,
+		Token:      tokenCfg.ContractAddress,
+		Decimals:   tokenCfg.Decimals,
+		Underlying: tokenCfg.GetUnderlying(),
+	}
+	decimals, err := auditor.GetErc20Decimals(tokenCfg.ContractAddress)
+	if err != nil {
+		logWorkerError("tokenaudit", "get token decimals failed", err, "chainID", chainID, "token", audit.Token)
+		audit.Error = err.Error()
+		return audit
+	}
+	audit.OnchainDecimals = decimals
+	audit.IsMismatch = decimals != tokenCfg.Decimals
+	if audit.Underlying != "" {
+		decimals, err = auditor.GetErc20Decimals(audit.Underlying)
+		if err != nil {
+			logWorkerError("tokenaudit", "get underlying decimals failed", err, "chainID", chainID, "underlying", audit.Underlying)
+			audit.Error = err.Error()
+			return audit
+		}
+		audit.UnderlyingDecimals = decimals
+		audit.IsMismatch = audit.IsMismatch || decimals != tokenCfg.Decimals
+	}
+	return audit
+}
+
+func auditTokenSupply(auditor tokens.TokenAuditor, chainID string, tokenCfg *tokens.TokenConfig) (audit *router.TokenSupplyAudit, supply, locked *big.Int) {
+	audit = &router.TokenSupplyAudit{
+		ChainID:    chainID,
+		Token:      tokenCfg.ContractAddress,
+		Underlying: tokenCfg.GetUnderlying(),
+	}
+	supply, err := auditor.GetErc20TotalSupply(tokenCfg.ContractAddress)
+	if err != nil {
+		logWorkerError("tokenaudit", "get token total supply failed", err, "chainID", chainID, "token", audit.Token)
+		audit.Error = err.Error()
+		return audit, nil, nil
+	}
+	audit.TotalSupply = supply.String()
+	if audit.Underlying != "" {
+		locked, err = auditor.GetErc20Balance(audit.Underlying, tokenCfg.ContractAddress)
+		if err != nil {
+			logWorkerError("tokenaudit", "get locked underlying failed", err, "chainID", chainID, "underlying", audit.Underlying)
+			audit.Error = err.Error()
+			return audit, nil, nil
+		}
+		audit.Locked = locked.String()
+	}
+	return audit, supply, locked
+}
+
+func reconcileTokenSupply(report *router.TokenAuditReport, supplies, locks []*big.Int, decimalList []uint8) {
+	var maxDecimals uint8
+	for _, decimals := range decimalList {
+		if decimals > maxDecimals {
+			maxDecimals = decimals
+		}
+	}
+	totalSupply := big.NewInt(0)
+	totalLocked := big.NewInt(0)
+	for i, decimals := range decimalList {
+		totalSupply.Add(totalSupply, scaleTokenValue(supplies[i], decimals, maxDecimals))
+		if locks[i] != nil {
+			totalLocked.Add(totalLocked, scaleTokenValue(locks[i], decimals, maxDecimals))
+		}
+	}
+	report.NormalizedDecimals = maxDecimals
+	report.TotalSupply = totalSupply.String()
+	report.TotalLocked = totalLocked.String()
+	report.IsReconciled = true
+
+	// allowed = totalLocked * (1 + tolerance / 100)
+	tolerance := new(big.Float).SetFloat64(1 + params.GetTokenAuditSupplyTolerancePercent()/100)
+	allowed, _ := new(big.Float).Mul(new(big.Float).SetInt(totalLocked), tolerance).Int(nil)
+	if totalSupply.Cmp(allowed) > 0 {
+		report.Discrepancies = append(report.Discrepancies,
+			fmt.Sprintf("total supply %v exceeds total locked underlying %v (decimals %v)",
+				report.TotalSupply, report.TotalLocked, maxDecimals))
+	}
+}
+
+// scaleTokenValue scale value from decimals to the not less toDecimals
+func scaleTokenValue(value *big.Int, decimals, toDecimals uint8) *big.Int {
+	if decimals == toDecimals {
+		return value
+	}
+	factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(toDecimals-decimals)), nil)
+	return new(big.Int).Mul(value, factor)
+}
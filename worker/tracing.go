@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tracing"
+)
+
+// startSwapSpan start span of swap operation with attributes of swap args
+func startSwapSpan(name string, args *tokens.BuildTxArgs, attrs ...interface{}) *tracing.Span {
+	ctx := []interface{}{
+		"identifier", args.Identifier,
+		"swapType", args.SwapType.String(),
+		"fromChainID", args.FromChainID,
+		"toChainID", args.ToChainID,
+		"txid", args.SwapID,
+		"logIndex", args.LogIndex,
+		"refund", args.Refund,
+	}
+	return tracing.StartSpan(args.GetTraceID(), name, append(ctx, attrs...)...)
+}
+
+// withTraceID add trace ID of swap into log context if it can be derived from the context.
+// the trace ID is derived from (in order) 'traceID', 'args', 'swap', 'key',
+// or the combination of 'fromChainID', 'txid' ('swapID') and 'logIndex'.
+func withTraceID(context []interface{}) []interface{} {
+	var (
+		traceID                     string
+		fromChainID, txid, logIndex interface{}
+	)
+	for k := 0; k+2 <= len(context) && traceID == ""; k += 2 {
+		key, _ := context[k].(string)
+		value := context[k+1]
+		switch key {
+		case "traceID":
+			return context
+		case "args":
+			if args, ok := value.(*tokens.BuildTxArgs); ok && args != nil {
+				traceID = args.GetTraceID()
+			}
+		case "swap":
+			switch swap := value.(type) {
+			case *mongodb.MgoSwap:
+				if swap != nil {
+					traceID = tokens.GetTraceIDOfSwapKey(swap.Key)
+				}
+			case *mongodb.MgoSwapResult:
+				if swap != nil {
+					traceID = tokens.GetTraceIDOfSwapKey(swap.Key)
+				}
+			}
+		case "key":
+			if swapKey, ok := value.(string); ok && swapKey != "" {
+				traceID = tokens.GetTraceIDOfSwapKey(swapKey)
+			}
+		case "fromChainID":
+			fromChainID = value
+		case "txid", "swapID":
+			txid = value
+		case "logIndex":
+			logIndex = value
+		}
+	}
+	if traceID == "" && fromChainID != nil && txid != nil && logIndex != nil {
+		traceID = tokens.GetTraceIDOfSwapKey(fmt.Sprintf("%v:%v:%v", fromChainID, txid, logIndex))
+	}
+	if traceID == "" {
+		return context
+	}
+	return append(context[:len(context):len(context)], "traceID", traceID)
+}
@@ -27,28 +27,41 @@ var (
 
 	maxCheckFailedSwapLifetime       = int64(2 * 24 * 3600)
 	restIntervalInCheckFailedSwapJob = 60 * time.Second
+
+	maxRebroadcastLifetime       = int64(2 * 24 * 3600)
+	restIntervalInRebroadcastJob = 60 * time.Second
+
+	restIntervalInSubscribeJob = 10 * time.Second
+
+	maxRefundLifetime       = int64(7 * 24 * 3600)
+	restIntervalInRefundJob = 30 * time.Second
+
+	restIntervalInReverifyJob = 60 * time.Second
+
+	maxSwapInDedupAuditRounds = 20
 )
 
 func now() int64 {
 	return time.Now().Unix()
 }
 
+// the job name is used as log module, whose log level can be set separately
 func logWorker(job, subject string, context ...interface{}) {
-	log.Info("["+job+"] "+subject, context...)
+	log.WithModuleFields(job, withTraceID(context)...).Info("[" + job + "] " + subject)
 }
 
 func logWorkerError(job, subject string, err error, context ...interface{}) {
 	fields := []interface{}{"err", err}
 	fields = append(fields, context...)
-	log.Error("["+job+"] "+subject, fields...)
+	log.WithModuleFields(job, withTraceID(fields)...).Error("[" + job + "] " + subject)
 }
 
 func logWorkerWarn(job, subject string, context ...interface{}) {
-	log.Warn("["+job+"] "+subject, context...)
+	log.WithModuleFields(job, withTraceID(context)...).Warn("[" + job + "] " + subject)
 }
 
 func logWorkerTrace(job, subject string, context ...interface{}) {
-	log.Trace("["+job+"] "+subject, context...)
+	log.WithModuleFields(job, withTraceID(context)...).Trace("[" + job + "] " + subject)
 }
 
 func getSepTimeInFind(dist int64) int64 {
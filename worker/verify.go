@@ -13,6 +13,7 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 	"github.com/anyswap/CrossChain-Router/v3/tools/fifo"
+	"github.com/anyswap/CrossChain-Router/v3/tracing"
 	mapset "github.com/deckarep/golang-set"
 )
 
@@ -22,6 +23,8 @@ var (
 
 	cachedVerifyingSwaps    = mapset.NewSet()
 	maxCachedVerifyingSwaps = 100
+
+	errBigSwapValue = errors.New("big swap value")
 )
 
 // StartVerifyJob verify job
@@ -79,6 +82,10 @@ func startVerifyProducer() {
 				continue
 			}
 
+			if !IsChainLeaseOwner(swap.FromChainID) {
+				continue
+			}
+
 			err := dispatchVerifyTask(swap) // produce
 			ctx := []interface{}{"fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex}
 			if err == nil {
@@ -140,6 +147,12 @@ func startVerifyConsumer(chainID string) {
 			continue
 		}
 
+		if !IsChainLeaseOwner(chainID) {
+			logWorkerTrace("doVerify", "ignore verify task as chain lease is lost", "chainID", chainID, "key", swap.Key)
+			verifyTasksInQueue.Remove(swap.Key)
+			continue
+		}
+
 		ctx := []interface{}{"fromChainID", swap.FromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex}
 		err := processRouterSwapVerify(swap)
 		if err == nil {
@@ -161,6 +174,12 @@ func isBlacked(swap *mongodb.MgoSwap) bool {
 		params.IsAccountInBlackList(swap.TxTo)
 }
 
+// IsRefundBlacked forbid refund to black account or on black chain
+func IsRefundBlacked(fromChainID, from string) bool {
+	return params.IsChainIDInBlackList(fromChainID) ||
+		params.IsAccountInBlackList(from)
+}
+
 //nolint:funlen,gocyclo // ok
 func processRouterSwapVerify(swap *mongodb.MgoSwap) (err error) {
 	if router.IsChainIDPaused(swap.FromChainID) || router.IsChainIDPaused(swap.ToChainID) {
@@ -189,13 +208,21 @@ func processRouterSwapVerify(swap *mongodb.MgoSwap) (err error) {
 	var dbErr error
 	if isBlacked(swap) {
 		err = tokens.ErrSwapInBlacklist
-		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.SwapInBlacklist, now(), err.Error())
+		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.SwapInBlacklist, now(), err)
 		if dbErr != nil {
 			logWorkerError("verify", "verify router swap db error", dbErr, "fromChainID", fromChainID, "toChainID", swap.ToChainID, "txid", txid, "logIndex", logIndex)
 		}
 		return err
 	}
 
+	err = screenRouterSwap(swap)
+	if err != nil {
+		if !errors.Is(err, tokens.ErrSwapFlaggedByScreen) {
+			isProcessed = false
+		}
+		return err
+	}
+
 	bridge := router.GetBridgeByChainID(fromChainID)
 	if bridge == nil {
 		return tokens.ErrNoBridgeForChainID
@@ -208,11 +235,14 @@ func processRouterSwapVerify(swap *mongodb.MgoSwap) (err error) {
 		LogIndex:      logIndex,
 		AllowUnstable: false,
 	}
+	span := tracing.StartSpan(tokens.GetTraceIDOfSwapKey(swap.Key), "verify",
+		"fromChainID", fromChainID, "toChainID", swap.ToChainID, "txid", txid, "logIndex", logIndex)
 	swapInfo, err := bridge.VerifyTransaction(txid, verifyArgs)
+	span.End(err)
 	switch {
 	case err == nil:
 		if router.IsBigValueSwap(swapInfo) {
-			dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxWithBigValue, now(), "big swap value")
+			dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxWithBigValue, now(), errBigSwapValue)
 		} else {
 			dbErr = mongodb.PassRouterSwapVerify(fromChainID, txid, logIndex, now())
 			if dbErr == nil {
@@ -227,22 +257,22 @@ func processRouterSwapVerify(swap *mongodb.MgoSwap) (err error) {
 		if swap.InitTime+1000*maxTxNotFoundTime < nowMilli {
 			duration := time.Duration((nowMilli - swap.InitTime) / 1000 * int64(time.Second))
 			logWorker("verify", "set longer not found swap to verify failed", "fromChainID", fromChainID, "toChainID", swap.ToChainID, "txid", swap.TxID, "logIndex", swap.LogIndex, "inittime", swap.InitTime, "duration", duration.String())
-			dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxVerifyFailed, now(), err.Error())
-			_ = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, mongodb.TxVerifyFailed, now(), err.Error())
+			dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxVerifyFailed, now(), err)
+			_ = mongodb.UpdateRouterSwapResultStatus(fromChainID, txid, logIndex, mongodb.TxVerifyFailed, now(), err)
 		} else {
 			isProcessed = false
 			return err
 		}
 	case errors.Is(err, tokens.ErrTxWithWrongValue):
-		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxWithWrongValue, now(), err.Error())
+		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxWithWrongValue, now(), err)
 	case errors.Is(err, tokens.ErrTxWithWrongPath):
-		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxWithWrongPath, now(), err.Error())
+		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxWithWrongPath, now(), err)
 	case errors.Is(err, tokens.ErrMissTokenConfig):
-		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.MissTokenConfig, now(), err.Error())
+		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.MissTokenConfig, now(), err)
 	case errors.Is(err, tokens.ErrNoUnderlyingToken):
-		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.NoUnderlyingToken, now(), err.Error())
+		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.NoUnderlyingToken, now(), err)
 	default:
-		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxVerifyFailed, now(), err.Error())
+		dbErr = mongodb.UpdateRouterSwapStatus(fromChainID, txid, logIndex, mongodb.TxVerifyFailed, now(), err)
 	}
 
 	if dbErr != nil {
@@ -12,6 +12,8 @@ const interval = 10 * time.Millisecond
 func StartRouterSwapWork(isServer bool) {
 	logWorker("worker", "start router swap worker")
 
+	registerWorkerErrorCodes()
+
 	bridge.InitRouterBridges(isServer)
 	bridge.StartReloadRouterConfigTask()
 
@@ -25,12 +27,21 @@ func StartRouterSwapWork(isServer bool) {
 		return
 	}
 
+	loadDrainState()
+	startDrainSignalHandler()
+
+	StartChainLeaseJob()
+	time.Sleep(interval)
+
 	StartSwapJob()
 	time.Sleep(interval)
 
 	StartVerifyJob()
 	time.Sleep(interval)
 
+	StartReverifyJob()
+	time.Sleep(interval)
+
 	StartStableJob()
 	time.Sleep(interval)
 
@@ -41,4 +52,25 @@ func StartRouterSwapWork(isServer bool) {
 	time.Sleep(interval)
 
 	StartCheckFailedSwapJob()
+	time.Sleep(interval)
+
+	StartRebroadcastJob()
+	time.Sleep(interval)
+
+	StartRefundJob()
+	time.Sleep(interval)
+
+	StartSubscribeSwapJob()
+	time.Sleep(interval)
+
+	StartLiquidityMonitorJob()
+	time.Sleep(interval)
+
+	StartSwapInDedupAuditJob()
+	time.Sleep(interval)
+
+	StartAnalyticsJob()
+	time.Sleep(interval)
+
+	StartTokenAuditJob()
 }